@@ -0,0 +1,29 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+const (
+	// DNSReadyCondition condition reports on the successful reconciliation of the Cloud DNS private zone of the cluster.
+	DNSReadyCondition clusterv1.ConditionType = "DNSReady"
+
+	// DNSReconciliationFailedReason used to report failures while reconciling the Cloud DNS private zone.
+	DNSReconciliationFailedReason = "DNSReconciliationFailed"
+	// WaitingForControlPlaneEndpointReason used to report the Cloud DNS record waiting for the control plane endpoint.
+	WaitingForControlPlaneEndpointReason = "WaitingForControlPlaneEndpoint"
+)
@@ -25,6 +25,10 @@ const (
 	// ClusterFinalizer allows ReconcileGCPCluster to clean up GCP resources associated with GCPCluster before
 	// removing it from the apiserver.
 	ClusterFinalizer = "gcpcluster.infrastructure.cluster.x-k8s.io"
+
+	// DryRunAnnotation is the annotation which, when set to "true", makes the controllers report the changes they
+	// would make to the GCP resources of the annotated object as events, without applying them.
+	DryRunAnnotation = "infrastructure.cluster.x-k8s.io/dry-run"
 )
 
 // GCPClusterSpec defines the desired state of GCPCluster.
@@ -45,7 +49,7 @@ type GCPClusterSpec struct {
 
 	// FailureDomains is an optional field which is used to assign selected availability zones to a cluster
 	// FailureDomains if empty, defaults to all the zones in the selected region and if specified would override
-	// the default zones.
+	// the default zones. The zones must be within the region of the cluster.
 	// +optional
 	FailureDomains []string `json:"failureDomains,omitempty"`
 
@@ -56,6 +60,8 @@ type GCPClusterSpec struct {
 
 	// ResourceManagerTags is an optional set of tags to apply to GCP resources managed
 	// by the GCP provider. GCP supports a maximum of 50 tags per resource.
+	// The tags are applied to the instances and disks of the machines of the cluster, and
+	// are overridden by the tags of a GCPMachine with the same ParentID and Key.
 	// +maxItems=50
 	// +optional
 	ResourceManagerTags ResourceManagerTags `json:"resourceManagerTags,omitempty"`
@@ -73,6 +79,49 @@ type GCPClusterSpec struct {
 	// For instance, the user can specify a new endpoint for the compute service.
 	// +optional
 	ServiceEndpoints *ServiceEndpoints `json:"serviceEndpoints,omitempty"`
+
+	// DNS configures a Cloud DNS private zone in which the control plane endpoint is resolved by name
+	// from the network of the cluster. If not specified, no DNS resources are created.
+	// +optional
+	DNS *DNSSpec `json:"dns,omitempty"`
+
+	// CloudRouter configures a Cloud Router with BGP in the network of the cluster, used to exchange
+	// routes with on-premises networks over Cloud VPN or Cloud Interconnect. If not specified, no
+	// Cloud Router is created for hybrid connectivity.
+	// +optional
+	CloudRouter *CloudRouterSpec `json:"cloudRouter,omitempty"`
+}
+
+// CloudRouterSpec configures the BGP settings of the Cloud Router of the cluster.
+type CloudRouterSpec struct {
+	// ASN is the private Autonomous System Number of the Cloud Router, in the range
+	// 64512-65534 or 4200000000-4294967294.
+	// +kubebuilder:validation:Minimum=64512
+	// +kubebuilder:validation:Maximum=4294967294
+	ASN int64 `json:"asn"`
+
+	// AdvertisedIPRanges are the IP ranges advertised to the BGP peers in addition to the
+	// subnets of the network. If not set, only the subnets of the network are advertised.
+	// +optional
+	AdvertisedIPRanges []CloudRouterAdvertisedIPRange `json:"advertisedIPRanges,omitempty"`
+}
+
+// CloudRouterAdvertisedIPRange is an IP range advertised by the Cloud Router.
+type CloudRouterAdvertisedIPRange struct {
+	// Range is the IP range to advertise, in CIDR format such as 10.0.0.0/8.
+	Range string `json:"range"`
+
+	// Description is an optional description of the IP range.
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// DNSSpec configures the Cloud DNS private zone of the cluster.
+type DNSSpec struct {
+	// Domain is the DNS name of the private zone, such as "my-cluster.internal". The control plane
+	// endpoint is resolved as api.<domain>. This field is immutable.
+	// +kubebuilder:validation:Pattern=`^([a-z0-9]([-a-z0-9]*[a-z0-9])?\.)*[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Domain string `json:"domain"`
 }
 
 // GCPClusterStatus defines the observed state of GCPCluster.
@@ -82,6 +131,10 @@ type GCPClusterStatus struct {
 
 	// Bastion Instance `json:"bastion,omitempty"`
 	Ready bool `json:"ready"`
+
+	// Conditions defines current service state of the GCPCluster.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -111,6 +164,16 @@ type GCPClusterList struct {
 	Items           []GCPCluster `json:"items"`
 }
 
+// GetConditions returns the observations of the operational state of the GCPCluster resource.
+func (r *GCPCluster) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the GCPCluster to the predescribed clusterv1.Conditions.
+func (r *GCPCluster) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
 func init() {
 	SchemeBuilder.Register(&GCPCluster{}, &GCPClusterList{})
 }
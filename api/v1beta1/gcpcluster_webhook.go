@@ -17,15 +17,20 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
+	"net"
 	"reflect"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/util/location"
 )
 
 // clusterlog is for logging in this package.
@@ -55,6 +60,21 @@ func (c *GCPCluster) Default() {
 func (c *GCPCluster) ValidateCreate() (admission.Warnings, error) {
 	clusterlog.Info("validate create", "name", c.Name)
 
+	allErrs := validateFailureDomains(c.Spec)
+	if c.Spec.Network.Mtu != 0 {
+		allErrs = append(allErrs, validateNetworkMtu(c.Spec.Network.Mtu)...)
+	}
+	allErrs = append(allErrs, validateCustomSubnetMode(c.Spec)...)
+	allErrs = append(allErrs, validateInternalLoadBalancerPorts(c.Spec)...)
+	allErrs = append(allErrs, validateInternalLoadBalancerHealthCheck(c.Spec)...)
+	allErrs = append(allErrs, validateInternalLoadBalancerSubnet(c.Spec)...)
+	allErrs = append(allErrs, validateCloudRouter(c.Spec)...)
+	allErrs = append(allErrs, validateAdditionalFirewallRules(c.Name, c.Spec)...)
+	allErrs = append(allErrs, validateNodePortFirewall(c.Spec)...)
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(GroupVersion.WithKind("GCPCluster").GroupKind(), c.Name, allErrs)
+	}
+
 	return nil, nil
 }
 
@@ -85,27 +105,41 @@ func (c *GCPCluster) ValidateUpdate(oldRaw runtime.Object) (admission.Warnings,
 		)
 	}
 
-	if !reflect.DeepEqual(c.Spec.LoadBalancer, old.Spec.LoadBalancer) {
+	if !reflect.DeepEqual(withoutMutableLoadBalancerFields(c.Spec.LoadBalancer), withoutMutableLoadBalancerFields(old.Spec.LoadBalancer)) {
 		allErrs = append(allErrs,
 			field.Invalid(field.NewPath("spec", "LoadBalancer"),
 				c.Spec.LoadBalancer, "field is immutable"),
 		)
 	}
 
-	if c.Spec.Network.Mtu < int64(1300) {
+	if !reflect.DeepEqual(c.Spec.DNS, old.Spec.DNS) {
 		allErrs = append(allErrs,
-			field.Invalid(field.NewPath("spec", "Network", "Mtu"),
-				c.Spec.Network.Mtu, "field cannot be lesser than 1300"),
+			field.Invalid(field.NewPath("spec", "DNS"),
+				c.Spec.DNS, "field is immutable"),
 		)
 	}
 
-	if c.Spec.Network.Mtu > int64(8896) {
+	// The MTU of a network can only be changed when no VM is attached to it, which is never the case once
+	// the network of the cluster has been created.
+	if c.Spec.Network.Mtu != old.Spec.Network.Mtu && old.Status.Network.SelfLink != nil {
 		allErrs = append(allErrs,
 			field.Invalid(field.NewPath("spec", "Network", "Mtu"),
-				c.Spec.Network.Mtu, "field cannot be greater than 8896"),
+				c.Spec.Network.Mtu, "field cannot be changed once the network is created"),
 		)
 	}
 
+	allErrs = append(allErrs, validateNetworkMtu(c.Spec.Network.Mtu)...)
+	// Only check changed failure domains, so that clusters created before the check can still be updated.
+	if !reflect.DeepEqual(c.Spec.FailureDomains, old.Spec.FailureDomains) {
+		allErrs = append(allErrs, validateFailureDomains(c.Spec)...)
+	}
+	allErrs = append(allErrs, validateInternalLoadBalancerPorts(c.Spec)...)
+	allErrs = append(allErrs, validateInternalLoadBalancerHealthCheck(c.Spec)...)
+	allErrs = append(allErrs, validateInternalLoadBalancerSubnet(c.Spec)...)
+	allErrs = append(allErrs, validateCloudRouter(c.Spec)...)
+	allErrs = append(allErrs, validateAdditionalFirewallRules(c.Name, c.Spec)...)
+	allErrs = append(allErrs, validateNodePortFirewall(c.Spec)...)
+
 	if len(allErrs) == 0 {
 		return nil, nil
 	}
@@ -113,6 +147,249 @@ func (c *GCPCluster) ValidateUpdate(oldRaw runtime.Object) (admission.Warnings,
 	return nil, apierrors.NewInvalid(GroupVersion.WithKind("GCPCluster").GroupKind(), c.Name, allErrs)
 }
 
+// withoutMutableLoadBalancerFields returns a copy of the LoadBalancerSpec with the fields that
+// can be updated in place cleared, so the remaining fields can be checked for immutability.
+func withoutMutableLoadBalancerFields(spec LoadBalancerSpec) LoadBalancerSpec {
+	out := spec.DeepCopy()
+	out.ProxyHeader = nil
+	if out.InternalLoadBalancer != nil {
+		out.InternalLoadBalancer.InternalAccess = ""
+	}
+	return *out
+}
+
+// validateNetworkMtu checks that the MTU is within the values allowed by GCP.
+func validateNetworkMtu(mtu int64) field.ErrorList {
+	var allErrs field.ErrorList
+	if mtu < int64(1300) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "Network", "Mtu"),
+				mtu, "field cannot be lesser than 1300"),
+		)
+	}
+
+	if mtu > int64(8896) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "Network", "Mtu"),
+				mtu, "field cannot be greater than 8896"),
+		)
+	}
+	return allErrs
+}
+
+// validateFailureDomains checks that the failure domains are zones within the region of the cluster.
+func validateFailureDomains(spec GCPClusterSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, fd := range spec.FailureDomains {
+		loc, err := location.Parse(fd)
+		if err != nil || loc.Zone == nil {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "FailureDomains").Index(i),
+					fd, "expected a zone in the format <region>-<zone> such as us-central1-a"),
+			)
+			continue
+		}
+		if loc.Region != spec.Region {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "FailureDomains").Index(i),
+					fd, fmt.Sprintf("zone is not in the region %s of the cluster", spec.Region)),
+			)
+		}
+	}
+	return allErrs
+}
+
+// validateCustomSubnetMode checks that a network created in custom subnet mode has a subnet in the region of
+// the cluster, as no subnet is created automatically for it.
+func validateCustomSubnetMode(spec GCPClusterSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if ptr.Deref(spec.Network.AutoCreateSubnetworks, true) {
+		return allErrs
+	}
+	for _, subnet := range spec.Network.Subnets {
+		if subnet.Region == "" || subnet.Region == spec.Region {
+			return allErrs
+		}
+	}
+	allErrs = append(allErrs,
+		field.Required(field.NewPath("spec", "Network", "Subnets"),
+			fmt.Sprintf("a subnet in the region %s of the cluster is required when AutoCreateSubnetworks is false", spec.Region)),
+	)
+	return allErrs
+}
+
+// validateInternalLoadBalancerPorts checks that the additional ports of the Internal Load Balancer are
+// valid port numbers and are not repeated.
+func validateInternalLoadBalancerPorts(spec GCPClusterSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if spec.LoadBalancer.InternalLoadBalancer == nil {
+		return allErrs
+	}
+	seen := make(map[int32]bool)
+	for i, port := range spec.LoadBalancer.InternalLoadBalancer.Ports {
+		path := field.NewPath("spec", "LoadBalancer", "InternalLoadBalancer", "Ports").Index(i)
+		if port < 1 || port > 65535 {
+			allErrs = append(allErrs, field.Invalid(path, port, "must be between 1 and 65535"))
+			continue
+		}
+		if seen[port] {
+			allErrs = append(allErrs, field.Duplicate(path, port))
+		}
+		seen[port] = true
+	}
+	return allErrs
+}
+
+// validateInternalLoadBalancerHealthCheck checks that the health check of the Internal Load Balancer does not
+// time out after the next check is due, taking the defaults of the unset timings into account.
+func validateInternalLoadBalancerHealthCheck(spec GCPClusterSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if spec.LoadBalancer.InternalLoadBalancer == nil || spec.LoadBalancer.InternalLoadBalancer.HealthCheck == nil {
+		return allErrs
+	}
+	hc := spec.LoadBalancer.InternalLoadBalancer.HealthCheck
+	interval := ptr.Deref(hc.CheckIntervalSec, DefaultHealthCheckIntervalSec)
+	timeout := ptr.Deref(hc.TimeoutSec, DefaultHealthCheckTimeoutSec)
+	if timeout > interval {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "LoadBalancer", "InternalLoadBalancer", "HealthCheck", "TimeoutSec"),
+				timeout, fmt.Sprintf("must not be greater than the check interval of %d seconds", interval)),
+		)
+	}
+	return allErrs
+}
+
+// validateInternalLoadBalancerSubnet checks that the subnet of the Internal Load Balancer is in the region of the
+// cluster, as the Load Balancer is regional and its backends are the control plane instances of that region.
+func validateInternalLoadBalancerSubnet(spec GCPClusterSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if spec.LoadBalancer.InternalLoadBalancer == nil || spec.LoadBalancer.InternalLoadBalancer.Subnet == nil {
+		return allErrs
+	}
+	name := *spec.LoadBalancer.InternalLoadBalancer.Subnet
+	for _, subnet := range spec.Network.Subnets {
+		if subnet.Name == name && subnet.Region != "" && subnet.Region != spec.Region {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "LoadBalancer", "InternalLoadBalancer", "Subnet"),
+					name, fmt.Sprintf("subnet is in region %s, not in the region %s of the cluster", subnet.Region, spec.Region)),
+			)
+		}
+	}
+	return allErrs
+}
+
+// validateCloudRouter checks that the Cloud Router uses a private ASN and advertises valid IP ranges.
+func validateCloudRouter(spec GCPClusterSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if spec.CloudRouter == nil {
+		return allErrs
+	}
+
+	asn := spec.CloudRouter.ASN
+	if (asn < 64512 || asn > 65534) && (asn < 4200000000 || asn > 4294967294) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "CloudRouter", "ASN"),
+				asn, "field must be a private ASN in the range 64512-65534 or 4200000000-4294967294"),
+		)
+	}
+
+	for i, r := range spec.CloudRouter.AdvertisedIPRanges {
+		if _, _, err := net.ParseCIDR(r.Range); err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "CloudRouter", "AdvertisedIPRanges").Index(i).Child("Range"),
+					r.Range, "field must be a valid CIDR"),
+			)
+		}
+	}
+	return allErrs
+}
+
+// maxFirewallNameLength is the maximum length of the name of a GCP firewall rule.
+const maxFirewallNameLength = 63
+
+// validateAdditionalFirewallRules checks that the user-defined firewall rules have unique names, which
+// fit in a GCP firewall name once prefixed with the cluster name, and match valid ranges of their
+// direction: source ranges for INGRESS and destination ranges for EGRESS.
+func validateAdditionalFirewallRules(clusterName string, spec GCPClusterSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	names := map[string]bool{}
+	for i, rule := range spec.Network.AdditionalFirewallRules {
+		rulePath := field.NewPath("spec", "Network", "AdditionalFirewallRules").Index(i)
+		if names[rule.Name] {
+			allErrs = append(allErrs, field.Duplicate(rulePath.Child("Name"), rule.Name))
+		}
+		names[rule.Name] = true
+
+		if maxLength := maxFirewallNameLength - len(clusterName) - 1; len(rule.Name) > maxLength {
+			allErrs = append(allErrs,
+				field.TooLong(rulePath.Child("Name"), rule.Name, maxLength),
+			)
+		}
+
+		for j, r := range rule.SourceRanges {
+			if _, _, err := net.ParseCIDR(r); err != nil {
+				allErrs = append(allErrs,
+					field.Invalid(rulePath.Child("SourceRanges").Index(j), r, "field must be a valid CIDR"),
+				)
+			}
+		}
+		for j, r := range rule.DestinationRanges {
+			if _, _, err := net.ParseCIDR(r); err != nil {
+				allErrs = append(allErrs,
+					field.Invalid(rulePath.Child("DestinationRanges").Index(j), r, "field must be a valid CIDR"),
+				)
+			}
+		}
+
+		if rule.Direction == FirewallRuleDirectionEgress {
+			if len(rule.DestinationRanges) == 0 {
+				allErrs = append(allErrs,
+					field.Required(rulePath.Child("DestinationRanges"), "EGRESS rules must specify destination ranges"),
+				)
+			}
+			if len(rule.SourceRanges) > 0 {
+				allErrs = append(allErrs,
+					field.Forbidden(rulePath.Child("SourceRanges"), "EGRESS rules must specify destination ranges, not source ranges"),
+				)
+			}
+			continue
+		}
+
+		if len(rule.DestinationRanges) > 0 {
+			allErrs = append(allErrs,
+				field.Forbidden(rulePath.Child("DestinationRanges"), "INGRESS rules must specify source ranges, not destination ranges"),
+			)
+		}
+	}
+	return allErrs
+}
+
+// validateNodePortFirewall checks that the source ranges of the NodePort firewall rule are set when it is enabled,
+// and are valid CIDRs.
+func validateNodePortFirewall(spec GCPClusterSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if spec.Network.NodePortFirewall == nil {
+		return allErrs
+	}
+
+	if spec.Network.NodePortFirewall.Enabled && len(spec.Network.NodePortFirewall.SourceRanges) == 0 {
+		allErrs = append(allErrs,
+			field.Required(field.NewPath("spec", "Network", "NodePortFirewall", "SourceRanges"),
+				"source ranges must be set when the NodePort firewall rule is enabled"),
+		)
+	}
+
+	for i, r := range spec.Network.NodePortFirewall.SourceRanges {
+		if _, _, err := net.ParseCIDR(r); err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "Network", "NodePortFirewall", "SourceRanges").Index(i),
+					r, "field must be a valid CIDR"),
+			)
+		}
+	}
+	return allErrs
+}
+
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
 func (c *GCPCluster) ValidateDelete() (admission.Warnings, error) {
 	clusterlog.Info("validate delete", "name", c.Name)
@@ -17,9 +17,12 @@ limitations under the License.
 package v1beta1
 
 import (
+	"strings"
 	"testing"
 
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func TestGCPCluster_ValidateUpdate(t *testing.T) {
@@ -85,6 +88,162 @@ func TestGCPCluster_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "GCPCluster with MTU field changed once the network is created",
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Network: NetworkSpec{
+						Mtu: int64(1500),
+					},
+				},
+			},
+			oldCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Network: NetworkSpec{
+						Mtu: int64(1460),
+					},
+				},
+				Status: GCPClusterStatus{
+					Network: Network{
+						SelfLink: ptr.To("https://www.googleapis.com/compute/v1/projects/my-proj/global/networks/my-network"),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with failure domains outside of the region",
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region:         "us-central1",
+					FailureDomains: []string{"us-central1-a", "us-east1-b"},
+					Network: NetworkSpec{
+						Mtu: int64(1500),
+					},
+				},
+			},
+			oldCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					Network: NetworkSpec{
+						Mtu: int64(1500),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with unchanged failure domains predating the check",
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region:         "us-central1",
+					FailureDomains: []string{"us-central1-a", "us-east1-b"},
+					Network: NetworkSpec{
+						Mtu: int64(1500),
+					},
+					AdditionalLabels: Labels{"team": "infra"},
+				},
+			},
+			oldCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region:         "us-central1",
+					FailureDomains: []string{"us-central1-a", "us-east1-b"},
+					Network: NetworkSpec{
+						Mtu: int64(1500),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with DNS domain changed",
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					DNS: &DNSSpec{Domain: "new-cluster.internal"},
+					Network: NetworkSpec{
+						Mtu: int64(1500),
+					},
+				},
+			},
+			oldCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					DNS: &DNSSpec{Domain: "my-cluster.internal"},
+					Network: NetworkSpec{
+						Mtu: int64(1500),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with internal load balancer access changed",
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{
+						InternalLoadBalancer: &LoadBalancer{InternalAccess: InternalAccessGlobal},
+					},
+					Network: NetworkSpec{
+						Mtu: int64(1500),
+					},
+				},
+			},
+			oldCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{
+						InternalLoadBalancer: &LoadBalancer{InternalAccess: InternalAccessRegional},
+					},
+					Network: NetworkSpec{
+						Mtu: int64(1500),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with load balancer proxy header changed",
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{
+						ProxyHeader: ptr.To(ProxyHeaderProxyV1),
+					},
+					Network: NetworkSpec{
+						Mtu: int64(1500),
+					},
+				},
+			},
+			oldCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Network: NetworkSpec{
+						Mtu: int64(1500),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with internal load balancer subnet changed",
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{
+						InternalLoadBalancer: &LoadBalancer{Subnet: ptr.To("subnet-b")},
+					},
+					Network: NetworkSpec{
+						Mtu: int64(1500),
+					},
+				},
+			},
+			oldCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{
+						InternalLoadBalancer: &LoadBalancer{Subnet: ptr.To("subnet-a")},
+					},
+					Network: NetworkSpec{
+						Mtu: int64(1500),
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -99,3 +258,407 @@ func TestGCPCluster_ValidateUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestGCPCluster_ValidateCreate(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name    string
+		cluster *GCPCluster
+		wantErr bool
+	}{
+		{
+			name: "GCPCluster without failure domains",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with failure domains within the region",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region:         "us-central1",
+					FailureDomains: []string{"us-central1-a", "us-central1-c"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with a failure domain outside of the region",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region:         "us-central1",
+					FailureDomains: []string{"us-central1-a", "europe-west1-b"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with a region as failure domain",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region:         "us-central1",
+					FailureDomains: []string{"us-central1"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with internal load balancer ports",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					LoadBalancer: LoadBalancerSpec{
+						InternalLoadBalancer: &LoadBalancer{Ports: []int32{8132, 22623}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with duplicated internal load balancer ports",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					LoadBalancer: LoadBalancerSpec{
+						InternalLoadBalancer: &LoadBalancer{Ports: []int32{8132, 8132}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with an internal load balancer port out of range",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					LoadBalancer: LoadBalancerSpec{
+						InternalLoadBalancer: &LoadBalancer{Ports: []int32{70000}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with internal load balancer health check timings",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					LoadBalancer: LoadBalancerSpec{
+						InternalLoadBalancer: &LoadBalancer{
+							HealthCheck: &HealthCheck{CheckIntervalSec: ptr.To[int64](30), TimeoutSec: ptr.To[int64](30)},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with internal load balancer health check timeout greater than the default interval",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					LoadBalancer: LoadBalancerSpec{
+						InternalLoadBalancer: &LoadBalancer{
+							HealthCheck: &HealthCheck{TimeoutSec: ptr.To[int64](15)},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with internal load balancer subnet in another region",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					Network: NetworkSpec{
+						Subnets: Subnets{
+							{Name: "us", Region: "us-central1"},
+							{Name: "europe", Region: "europe-west1"},
+						},
+					},
+					LoadBalancer: LoadBalancerSpec{
+						InternalLoadBalancer: &LoadBalancer{Subnet: ptr.To("europe")},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster in custom subnet mode with a subnet in the region",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					Network: NetworkSpec{
+						AutoCreateSubnetworks: ptr.To(false),
+						Subnets:               Subnets{{Name: "control-plane", Region: "us-central1"}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster in custom subnet mode without subnets",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					Network: NetworkSpec{
+						AutoCreateSubnetworks: ptr.To(false),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster in custom subnet mode without a subnet in the region",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					Network: NetworkSpec{
+						AutoCreateSubnetworks: ptr.To(false),
+						Subnets:               Subnets{{Name: "europe", Region: "europe-west1"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with MTU field within the limits",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					Network: NetworkSpec{
+						Mtu: int64(8896),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with MTU field more than 8896",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					Network: NetworkSpec{
+						Mtu: int64(9000),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with a cloud router using a private ASN",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					CloudRouter: &CloudRouterSpec{
+						ASN: 65001,
+						AdvertisedIPRanges: []CloudRouterAdvertisedIPRange{
+							{Range: "10.100.0.0/16"},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with a cloud router using a public ASN",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					CloudRouter: &CloudRouterSpec{
+						ASN: 65535,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with a cloud router advertising an invalid IP range",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					CloudRouter: &CloudRouterSpec{
+						ASN: 4200000000,
+						AdvertisedIPRanges: []CloudRouterAdvertisedIPRange{
+							{Range: "10.100.0.0"},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with an egress firewall rule with destination ranges",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					Network: NetworkSpec{
+						AdditionalFirewallRules: []FirewallRule{
+							{
+								Name:              "deny-egress",
+								Direction:         FirewallRuleDirectionEgress,
+								Action:            FirewallRuleActionDeny,
+								Protocols:         []FirewallRuleProtocol{{Protocol: "all"}},
+								DestinationRanges: []string{"0.0.0.0/0"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with an egress firewall rule with source ranges",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					Network: NetworkSpec{
+						AdditionalFirewallRules: []FirewallRule{
+							{
+								Name:         "deny-egress",
+								Direction:    FirewallRuleDirectionEgress,
+								Action:       FirewallRuleActionDeny,
+								Protocols:    []FirewallRuleProtocol{{Protocol: "all"}},
+								SourceRanges: []string{"0.0.0.0/0"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with an ingress firewall rule with an invalid source range",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					Network: NetworkSpec{
+						AdditionalFirewallRules: []FirewallRule{
+							{
+								Name:         "allow-https",
+								Direction:    FirewallRuleDirectionIngress,
+								Protocols:    []FirewallRuleProtocol{{Protocol: "tcp", Ports: []string{"443"}}},
+								SourceRanges: []string{"10.0.0.0"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with a firewall rule name too long once prefixed with the cluster name",
+			cluster: &GCPCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					Network: NetworkSpec{
+						AdditionalFirewallRules: []FirewallRule{
+							{
+								Name:      strings.Repeat("a", 53),
+								Direction: FirewallRuleDirectionIngress,
+								Protocols: []FirewallRuleProtocol{{Protocol: "tcp", Ports: []string{"443"}}},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with an ingress firewall rule with destination ranges",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					Network: NetworkSpec{
+						AdditionalFirewallRules: []FirewallRule{
+							{
+								Name:              "allow-https",
+								Direction:         FirewallRuleDirectionIngress,
+								Protocols:         []FirewallRuleProtocol{{Protocol: "tcp", Ports: []string{"443"}}},
+								DestinationRanges: []string{"10.0.0.0/8"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with duplicated firewall rule names",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					Network: NetworkSpec{
+						AdditionalFirewallRules: []FirewallRule{
+							{Name: "allow-https", Protocols: []FirewallRuleProtocol{{Protocol: "tcp"}}},
+							{Name: "allow-https", Protocols: []FirewallRuleProtocol{{Protocol: "udp"}}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with a valid NodePort firewall source range",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					Network: NetworkSpec{
+						NodePortFirewall: &NodePortFirewallSpec{
+							Enabled:      true,
+							SourceRanges: []string{"10.0.0.0/8"},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with an invalid NodePort firewall source range",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					Network: NetworkSpec{
+						NodePortFirewall: &NodePortFirewallSpec{
+							Enabled:      true,
+							SourceRanges: []string{"10.0.0.0"},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with a NodePort firewall without source ranges",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Region: "us-central1",
+					Network: NetworkSpec{
+						NodePortFirewall: &NodePortFirewallSpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			warn, err := test.cluster.ValidateCreate()
+			if test.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(warn).To(BeNil())
+		})
+	}
+}
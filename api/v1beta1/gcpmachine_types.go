@@ -17,6 +17,9 @@ limitations under the License.
 package v1beta1
 
 import (
+	"slices"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -35,10 +38,79 @@ const (
 	PdStandardDiskType DiskType = "pd-standard"
 	// PdSsdDiskType defines the name for the ssd disk.
 	PdSsdDiskType DiskType = "pd-ssd"
+	// PdBalancedDiskType defines the name for the balanced disk.
+	PdBalancedDiskType DiskType = "pd-balanced"
 	// LocalSsdDiskType defines the name for the local ssd disk.
 	LocalSsdDiskType DiskType = "local-ssd"
 )
 
+// hyperdiskTypePrefix is the prefix shared by the names of the Hyperdisk types.
+const hyperdiskTypePrefix = "hyperdisk-"
+
+// IsHyperdisk returns true if the disk type is a Hyperdisk type such as hyperdisk-balanced.
+func (t DiskType) IsHyperdisk() bool {
+	return strings.HasPrefix(string(t), hyperdiskTypePrefix)
+}
+
+// localSSDDiskSize is the size in GBs of a local SSD disk.
+const localSSDDiskSize = 375
+
+// localSSDCounts are the numbers of local SSDs which can be attached to the machine types of a series with up to
+// MaxVCPUs vCPUs.
+type localSSDCounts struct {
+	MaxVCPUs int
+	Counts   []int
+}
+
+// Local SSDs can be attached to Compute Engine machine types in the following series, in the given numbers
+// depending on the vCPUs of the machine type:
+// reference: https://cloud.google.com/compute/docs/disks/local-ssd#choose_number_local_ssds
+var localSSDCountsByMachineSeries = map[string][]localSSDCounts{
+	"n1": {
+		{MaxVCPUs: 96, Counts: []int{1, 2, 3, 4, 5, 6, 7, 8, 16, 24}},
+	},
+	"n2": {
+		{MaxVCPUs: 10, Counts: []int{1, 2, 4, 8, 16, 24}},
+		{MaxVCPUs: 20, Counts: []int{2, 4, 8, 16, 24}},
+		{MaxVCPUs: 40, Counts: []int{4, 8, 16, 24}},
+		{MaxVCPUs: 80, Counts: []int{8, 16, 24}},
+		{MaxVCPUs: 128, Counts: []int{16, 24}},
+	},
+	"n2d": {
+		{MaxVCPUs: 16, Counts: []int{1, 2, 4, 8, 16, 24}},
+		{MaxVCPUs: 48, Counts: []int{2, 4, 8, 16, 24}},
+		{MaxVCPUs: 80, Counts: []int{4, 8, 16, 24}},
+		{MaxVCPUs: 224, Counts: []int{8, 16, 24}},
+	},
+	"c2": {
+		{MaxVCPUs: 4, Counts: []int{1, 2, 4, 8}},
+		{MaxVCPUs: 8, Counts: []int{2, 4, 8}},
+		{MaxVCPUs: 16, Counts: []int{4, 8}},
+		{MaxVCPUs: 60, Counts: []int{8}},
+	},
+	"c2d": {
+		{MaxVCPUs: 16, Counts: []int{1, 2, 4, 8}},
+		{MaxVCPUs: 32, Counts: []int{2, 4, 8}},
+		{MaxVCPUs: 56, Counts: []int{4, 8}},
+		{MaxVCPUs: 112, Counts: []int{8}},
+	},
+}
+
+// LocalSSDCountsForMachineType returns the numbers of local SSDs which can be attached to the machine type, and
+// false if they are not known.
+func LocalSSDCountsForMachineType(machineType string) ([]int, bool) {
+	vCPUs, ok := machineVCPUs(machineType)
+	if !ok {
+		return nil, false
+	}
+	for _, counts := range localSSDCountsByMachineSeries[machineSeries(machineType)] {
+		if vCPUs <= counts.MaxVCPUs {
+			return counts.Counts, true
+		}
+	}
+	return nil, false
+}
+
 // AttachedDiskSpec degined GCP machine disk.
 type AttachedDiskSpec struct {
 	// DeviceType is a device type of the attached disk.
@@ -55,9 +127,20 @@ type AttachedDiskSpec struct {
 	// Defaults to 30GB. For "local-ssd" size is always 375GB.
 	// +optional
 	Size *int64 `json:"size,omitempty"`
-	// EncryptionKey defines the KMS key to be used to encrypt the disk.
+	// EncryptionKey defines the customer-managed or customer-supplied key to be used to encrypt the disk,
+	// independently of the RootDiskEncryptionKey.
 	// +optional
 	EncryptionKey *CustomerEncryptionKey `json:"encryptionKey,omitempty"`
+	// ProvisionedIops is the number of I/O operations per second provisioned for the disk.
+	// Only supported by Hyperdisk types.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ProvisionedIops *int64 `json:"provisionedIops,omitempty"`
+	// ProvisionedThroughput is the throughput in MiB per second provisioned for the disk.
+	// Only supported by Hyperdisk types.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ProvisionedThroughput *int64 `json:"provisionedThroughput,omitempty"`
 }
 
 // IPForwarding represents the IP forwarding configuration for the GCP machine.
@@ -70,6 +153,60 @@ const (
 	IPForwardingDisabled IPForwarding = "Disabled"
 )
 
+// AliasIPRange is an alias IP range attached to the network interface of an instance.
+type AliasIPRange struct {
+	// IPCidrRange is the IP alias range to allocate for the instance. The range must belong to the subnetwork
+	// of the instance and may be a single IP address (such as 10.2.3.4), a netmask (such as /24) or a CIDR
+	// formatted string (such as 10.1.2.0/24).
+	IPCidrRange string `json:"ipCidrRange"`
+
+	// SubnetworkRangeName is the name of the secondary range of the subnetwork to allocate the alias range from.
+	// If not specified, the primary range of the subnetwork is used.
+	// +optional
+	SubnetworkRangeName string `json:"subnetworkRangeName,omitempty"`
+}
+
+// MachineScript is a script run by an instance, set either inline or from a Secret.
+// Either Content or SecretRef must be provided.
+// +kubebuilder:validation:MinProperties=1
+// +kubebuilder:validation:MaxProperties=1
+type MachineScript struct {
+	// Content is the content of the script.
+	// +optional
+	Content *string `json:"content,omitempty"`
+
+	// SecretRef is a reference to a key of a Secret holding the script. The Secret must be in the namespace of
+	// the GCPMachine.
+	// +optional
+	SecretRef *SecretKeyReference `json:"secretRef,omitempty"`
+}
+
+// BootstrapDataStorage defines a GCS bucket where the bootstrap data of an instance is stored when it is too large
+// to be passed in the instance metadata.
+type BootstrapDataStorage struct {
+	// Bucket is the name of an existing GCS bucket, which should not be publicly readable. The credentials of the
+	// cluster must be the ones of a service account able to create and delete objects in the bucket, and to sign blobs
+	// with its own identity through the IAM credentials API, which the signed URL of the object is created with.
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:MaxLength=222
+	Bucket string `json:"bucket"`
+
+	// Threshold is the size in bytes above which the bootstrap data is stored in the bucket. Defaults to 262144, the
+	// size limit of an instance metadata value.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Threshold *int64 `json:"threshold,omitempty"`
+}
+
+// SecretKeyReference is a reference to a key of a Secret.
+type SecretKeyReference struct {
+	// Name is the name of the Secret.
+	Name string `json:"name"`
+
+	// Key is the key of the Secret data.
+	Key string `json:"key"`
+}
+
 // SecureBootPolicy represents the secure boot configuration for the GCP machine.
 type SecureBootPolicy string
 
@@ -134,12 +271,22 @@ const (
 	ConfidentialComputePolicyEnabled ConfidentialComputePolicy = "Enabled"
 	// ConfidentialComputePolicyDisabled disables confidential compute for the GCP machine.
 	ConfidentialComputePolicyDisabled ConfidentialComputePolicy = "Disabled"
+	// ConfidentialComputePolicyTDX enables confidential compute with Intel TDX for the GCP machine.
+	ConfidentialComputePolicyTDX ConfidentialComputePolicy = "IntelTrustedDomainExtensions"
 )
 
 // Confidential VM supports Compute Engine machine types in the following series:
 // reference: https://cloud.google.com/compute/confidential-vm/docs/os-and-machine-type#machine-type
 var confidentialComputeSupportedMachineSeries = []string{"n2d", "c2d"}
 
+// Confidential VM with Intel TDX supports Compute Engine machine types in the following series:
+// reference: https://cloud.google.com/confidential-computing/confidential-vm/docs/supported-configurations#machine-type-cpu-zone
+var confidentialComputeTDXSupportedMachineSeries = []string{"c3"}
+
+// Accelerator-optimized machine types have GPUs attached, which prevent live migration, in the following series:
+// reference: https://cloud.google.com/compute/docs/accelerator-optimized-machines
+var acceleratorOptimizedMachineSeries = []string{"a2", "a3", "a4", "g2"}
+
 // HostMaintenancePolicy represents the desired behavior ase of a host maintenance event.
 type HostMaintenancePolicy string
 
@@ -226,9 +373,95 @@ const (
 	ProvisioningModelSpot ProvisioningModel = "Spot"
 )
 
+// InstanceTerminationAction is the action taken on the instance when it is preempted or reaches its
+// maximum run duration.
+type InstanceTerminationAction string
+
+const (
+	// InstanceTerminationActionStop stops the instance.
+	InstanceTerminationActionStop InstanceTerminationAction = "Stop"
+	// InstanceTerminationActionDelete deletes the instance.
+	InstanceTerminationActionDelete InstanceTerminationAction = "Delete"
+)
+
+// AdvancedMachineFeatures defines the advanced CPU features of the instance.
+type AdvancedMachineFeatures struct {
+	// EnableNestedVirtualization enables nested virtualization on the instance. It is not supported on the
+	// Intel Sandy Bridge and Ivy Bridge CPU platforms, which are rejected as MinCPUPlatform.
+	// +optional
+	EnableNestedVirtualization bool `json:"enableNestedVirtualization,omitempty"`
+
+	// ThreadsPerCore is the number of threads per physical core. Set to 1 to disable simultaneous
+	// multithreading. If unset, the maximum number of threads supported per core is used.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=2
+	// +optional
+	ThreadsPerCore *int64 `json:"threadsPerCore,omitempty"`
+
+	// VisibleCoreCount is the number of physical cores exposed to the instance. If unset, all the cores of
+	// the machine type are exposed.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	VisibleCoreCount *int64 `json:"visibleCoreCount,omitempty"`
+}
+
+// GuestOSFeature is a feature of the operating system of an image, as declared on the image.
+// +kubebuilder:validation:Enum=UEFI_COMPATIBLE;GVNIC;SEV_CAPABLE;SEV_SNP_CAPABLE;TDX_CAPABLE;VIRTIO_SCSI_MULTIQUEUE;MULTI_IP_SUBNET;IDPF
+type GuestOSFeature string
+
+const (
+	// GuestOSFeatureUEFICompatible is declared by images that boot with UEFI, as required by Shielded VM.
+	GuestOSFeatureUEFICompatible GuestOSFeature = "UEFI_COMPATIBLE"
+	// GuestOSFeatureGVNIC is declared by images that support the gVNIC network interface.
+	GuestOSFeatureGVNIC GuestOSFeature = "GVNIC"
+	// GuestOSFeatureSEVCapable is declared by images that support AMD SEV confidential compute.
+	GuestOSFeatureSEVCapable GuestOSFeature = "SEV_CAPABLE"
+	// GuestOSFeatureTDXCapable is declared by images that support Intel TDX confidential compute.
+	GuestOSFeatureTDXCapable GuestOSFeature = "TDX_CAPABLE"
+)
+
+// EgressBandwidthTier is the total egress bandwidth tier of an instance.
+type EgressBandwidthTier string
+
+const (
+	// EgressBandwidthTierDefault uses the default egress bandwidth of the machine type.
+	EgressBandwidthTierDefault EgressBandwidthTier = "Default"
+	// EgressBandwidthTierTier1 uses the Tier_1 higher egress bandwidth.
+	EgressBandwidthTierTier1 EgressBandwidthTier = "Tier1"
+)
+
+// Tier_1 networking performance supports Compute Engine machine types in the following series, from the given
+// number of vCPUs. The minimum of accelerator-optimized series depends on their GPUs instead, and is not checked.
+// reference: https://cloud.google.com/compute/docs/networking/configure-vm-with-high-bandwidth-configuration
+var tier1NetworkingMinVCPUsByMachineSeries = map[string]int{
+	"n2":  32,
+	"n2d": 32,
+	"c2":  30,
+	"c2d": 32,
+	"c3":  44,
+	"c3d": 60,
+	"c4":  30,
+	"h3":  88,
+	"m3":  32,
+	"z3":  88,
+	"a2":  0,
+	"a3":  0,
+	"g2":  0,
+}
+
+// NetworkPerformanceConfig defines the network performance configuration of the instance.
+type NetworkPerformanceConfig struct {
+	// TotalEgressBandwidthTier is the total egress bandwidth tier of the instance. Tier1 requires a machine type
+	// of a series that supports Tier_1 networking performance, with enough vCPUs for it. Tier1 also makes the network
+	// interface of the instance a gVNIC, which the image must support.
+	// +kubebuilder:validation:Enum=Default;Tier1
+	TotalEgressBandwidthTier EgressBandwidthTier `json:"totalEgressBandwidthTier"`
+}
+
 // GCPMachineSpec defines the desired state of GCPMachine.
 type GCPMachineSpec struct {
-	// InstanceType is the type of instance to create. Example: n1.standard-2
+	// InstanceType is the type of instance to create. Both predefined and custom machine types are
+	// supported. Example: n1-standard-2, e2-custom-4-8192
 	InstanceType string `json:"instanceType"`
 
 	// Subnet is a reference to the subnetwork to use for this instance. If not specified,
@@ -236,6 +469,36 @@ type GCPMachineSpec struct {
 	// +optional
 	Subnet *string `json:"subnet,omitempty"`
 
+	// AliasIPRanges are the alias IP ranges of the network interface of the instance, for example to assign
+	// the pod CIDR of the node. IP forwarding is always enabled on instances with alias IP ranges.
+	// +optional
+	AliasIPRanges []AliasIPRange `json:"aliasIPRanges,omitempty"`
+
+	// Zone is the zone in which to create the instance, for example us-central1-a. The failure domain of the
+	// owning Machine takes precedence over this field, which in turn takes precedence over the failure domains
+	// of the cluster. The zone must be within the region of the cluster.
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+
+	// InstanceNameTemplate is a Go template rendering the name of the instance, for example
+	// "{{ .project }}-{{ .machine.name }}". The available values are {{ .cluster.name }}, {{ .machine.name }},
+	// {{ .machine.namespace }}, {{ .project }} and {{ .role }}. The template must include {{ .machine.name }}, so
+	// that the instances have unique names. The rendered name must be a lowercase RFC 1035 label, and names longer
+	// than 63 characters are truncated and suffixed with a hash of the full name. If not specified, the instance is
+	// named after the GCPMachine.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	// +optional
+	InstanceNameTemplate *string `json:"instanceNameTemplate,omitempty"`
+
+	// Hostname is the custom hostname of the instance, which must be a fully qualified domain name in lowercase
+	// with at least two labels, for example node-1.example.com. The instance name and the provider ID are not
+	// affected. If not specified, the default internal DNS name of the instance is used. It cannot be set in a
+	// GCPMachineTemplate, as all the machines created from it would share the same hostname.
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	Hostname *string `json:"hostname,omitempty"`
+
 	// ProviderID is the unique identifier as specified by the cloud provider.
 	// +optional
 	ProviderID *string `json:"providerID,omitempty"`
@@ -244,8 +507,17 @@ type GCPMachineSpec struct {
 	// +optional
 	ImageFamily *string `json:"imageFamily,omitempty"`
 
-	// Image is the full reference to a valid image to be used for this machine.
-	// Takes precedence over ImageFamily.
+	// RequiredGuestOSFeatures are the guest OS features the image of the instance must declare, for example
+	// UEFI_COMPATIBLE for Shielded VM or SEV_CAPABLE for confidential compute. The image, or the latest image of
+	// the image family, is checked before the instance is created when it can be read with the credentials of
+	// the cluster.
+	// +listType=set
+	// +optional
+	RequiredGuestOSFeatures []GuestOSFeature `json:"requiredGuestOSFeatures,omitempty"`
+
+	// Image is the full reference to a valid image to be used for this machine, in the format
+	// projects/<project>/global/images/<image> or projects/<project>/global/images/family/<family>. Takes
+	// precedence over ImageFamily.
 	// +optional
 	Image *string `json:"image,omitempty"`
 
@@ -262,6 +534,51 @@ type GCPMachineSpec struct {
 	// +optional
 	AdditionalMetadata []MetadataItem `json:"additionalMetadata,omitempty"`
 
+	// EnableOSLogin sets the enable-oslogin metadata of the instance, which controls whether OS Login is used to
+	// manage the SSH access to the instance. Takes precedence over an enable-oslogin item of AdditionalMetadata.
+	// If not specified, the project metadata applies.
+	// +optional
+	EnableOSLogin *bool `json:"enableOSLogin,omitempty"`
+
+	// BlockProjectSSHKeys sets the block-project-ssh-keys metadata of the instance, which controls whether the SSH
+	// keys of the project metadata are allowed to access the instance. Takes precedence over a
+	// block-project-ssh-keys item of AdditionalMetadata. If not specified, project SSH keys are allowed.
+	// +optional
+	BlockProjectSSHKeys *bool `json:"blockProjectSSHKeys,omitempty"`
+
+	// EnableSerialPortLogging sets the serial-port-logging-enable metadata of the instance, which controls whether
+	// the output of the serial port is sent to Cloud Logging, for example to troubleshoot the boot of the instance.
+	// Takes precedence over a serial-port-logging-enable item of AdditionalMetadata. If not specified, the project
+	// metadata applies.
+	// +optional
+	EnableSerialPortLogging *bool `json:"enableSerialPortLogging,omitempty"`
+
+	// StartupScript sets the startup-script metadata of the instance, which is run on every boot of the instance.
+	// Takes precedence over a startup-script item of AdditionalMetadata.
+	// +optional
+	StartupScript *MachineScript `json:"startupScript,omitempty"`
+
+	// ShutdownScript sets the shutdown-script metadata of the instance, which is run when the instance is stopped
+	// or restarted. Takes precedence over a shutdown-script item of AdditionalMetadata.
+	// +optional
+	ShutdownScript *MachineScript `json:"shutdownScript,omitempty"`
+
+	// BootstrapDataStorage stores the bootstrap data in a GCS bucket when it exceeds the size limit of the instance
+	// metadata. The user-data of the instance then makes cloud-init include the object through a signed URL. The object
+	// is deleted once the node joined the cluster, or along with the GCPMachine.
+	// +optional
+	BootstrapDataStorage *BootstrapDataStorage `json:"bootstrapDataStorage,omitempty"`
+
+	// DeletionProtection prevents the instance from being deleted outside of Cluster API. The protection is
+	// cleared by the controller before the instance is deleted as part of the GCPMachine deletion.
+	// +optional
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
+
+	// EnableDisplayDevice enables the virtual display device of the instance, which is required by some screen
+	// capturing and remote desktop tools.
+	// +optional
+	EnableDisplayDevice bool `json:"enableDisplayDevice,omitempty"`
+
 	// IAMInstanceProfile is a name of an IAM instance profile to assign to the instance
 	// +optional
 	// IAMInstanceProfile string `json:"iamInstanceProfile,omitempty"`
@@ -285,6 +602,8 @@ type GCPMachineSpec struct {
 
 	// RootDeviceSize is the size of the root volume in GB.
 	// Defaults to 30.
+	// On an existing GCPMachine, it can be increased to grow the root disk, but never decreased or unset.
+	// The root filesystem is grown by most images on the next boot, otherwise it has to be grown from the guest.
 	// +optional
 	RootDeviceSize int64 `json:"rootDeviceSize,omitempty"`
 
@@ -298,12 +617,35 @@ type GCPMachineSpec struct {
 	// +optional
 	RootDeviceType *DiskType `json:"rootDeviceType,omitempty"`
 
+	// RootDeviceProvisionedIops is the number of I/O operations per second provisioned for the root volume.
+	// Only supported when RootDeviceType is a Hyperdisk type.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RootDeviceProvisionedIops *int64 `json:"rootDeviceProvisionedIops,omitempty"`
+
+	// RootDeviceProvisionedThroughput is the throughput in MiB per second provisioned for the root volume.
+	// Only supported when RootDeviceType is a Hyperdisk type.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RootDeviceProvisionedThroughput *int64 `json:"rootDeviceProvisionedThroughput,omitempty"`
+
+	// DiskResourcePolicies are the names of resource policies, such as snapshot schedules, to attach to the
+	// root volume. The resource policies must exist in the region of the cluster.
+	// +listType=set
+	// +kubebuilder:validation:items:Pattern=`^[a-z]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:items:MaxLength=63
+	// +optional
+	DiskResourcePolicies []string `json:"diskResourcePolicies,omitempty"`
+
 	// AdditionalDisks are optional non-boot attached disks.
 	// +optional
 	AdditionalDisks []AttachedDiskSpec `json:"additionalDisks,omitempty"`
 
 	// ServiceAccount specifies the service account email and which scopes to assign to the machine.
 	// Defaults to: email: "default", scope: []{compute.CloudPlatformScope}
+	// When set, the scopes replace the default scope, so that least-privilege scopes can be used,
+	// and an empty email uses the default service account. Setting no scopes gives the machine no
+	// access to Google Cloud APIs through the service account.
 	// +optional
 	ServiceAccount *ServiceAccount `json:"serviceAccounts,omitempty"`
 
@@ -318,8 +660,21 @@ type GCPMachineSpec struct {
 	// +optional
 	ProvisioningModel *ProvisioningModel `json:"provisioningModel,omitempty"`
 
+	// MaxRunDuration is the duration after which the instance is terminated with the InstanceTerminationAction,
+	// for time-bounded workloads. Only supported by Spot and preemptible instances.
+	// +optional
+	MaxRunDuration *metav1.Duration `json:"maxRunDuration,omitempty"`
+
+	// InstanceTerminationAction is the action taken on the instance when it is preempted or reaches its
+	// MaxRunDuration. Only supported by Spot and preemptible instances. When unspecified, the instance is
+	// stopped, or deleted if MaxRunDuration is set.
+	// +kubebuilder:validation:Enum=Stop;Delete
+	// +optional
+	InstanceTerminationAction *InstanceTerminationAction `json:"instanceTerminationAction,omitempty"`
+
 	// IPForwarding Allows this instance to send and receive packets with non-matching destination or source IPs.
 	// This is required if you plan to use this instance to forward routes. Defaults to enabled.
+	// It cannot be disabled when AliasIPRanges are set.
 	// +kubebuilder:validation:Enum=Enabled;Disabled
 	// +kubebuilder:default=Enabled
 	// +optional
@@ -331,22 +686,55 @@ type GCPMachineSpec struct {
 
 	// OnHostMaintenance determines the behavior when a maintenance event occurs that might cause the instance to reboot.
 	// If omitted, the platform chooses a default, which is subject to change over time, currently that default is "Migrate".
+	// Instances with GPUs attached cannot be live migrated, so "Terminate" is always used for accelerator-optimized
+	// instance types, and the instances are restarted after the maintenance event unless they are Spot or preemptible.
 	// +kubebuilder:validation:Enum=Migrate;Terminate;
 	// +optional
 	OnHostMaintenance *HostMaintenancePolicy `json:"onHostMaintenance,omitempty"`
 
+	// AutomaticRestart determines whether the instance is restarted when it is terminated by Compute Engine, for
+	// example on host maintenance. Spot and preemptible instances cannot be restarted automatically, so it cannot
+	// be true for them. If omitted, standard instances are restarted automatically.
+	// +optional
+	AutomaticRestart *bool `json:"automaticRestart,omitempty"`
+
 	// ConfidentialCompute Defines whether the instance should have confidential compute enabled.
 	// If enabled OnHostMaintenance is required to be set to "Terminate".
+	// "Enabled" uses AMD SEV and requires an N2D or C2D instance type, "IntelTrustedDomainExtensions" uses
+	// Intel TDX and requires a C3 instance type.
 	// If omitted, the platform chooses a default, which is subject to change over time, currently that default is false.
-	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +kubebuilder:validation:Enum=Enabled;Disabled;IntelTrustedDomainExtensions
 	// +optional
 	ConfidentialCompute *ConfidentialComputePolicy `json:"confidentialCompute,omitempty"`
 
+	// AdvancedMachineFeatures defines the advanced CPU features of the instance, such as nested virtualization.
+	// +optional
+	AdvancedMachineFeatures *AdvancedMachineFeatures `json:"advancedMachineFeatures,omitempty"`
+
+	// NetworkPerformanceConfig defines the network performance configuration of the instance, such as the
+	// Tier_1 higher egress bandwidth.
+	// +optional
+	NetworkPerformanceConfig *NetworkPerformanceConfig `json:"networkPerformanceConfig,omitempty"`
+
+	// MinCPUPlatform is the minimum CPU platform of the instance, such as "Intel Haswell" or "AMD Milan".
+	// +optional
+	MinCPUPlatform *string `json:"minCPUPlatform,omitempty"`
+
 	// RootDiskEncryptionKey defines the KMS key to be used to encrypt the root disk.
 	// +optional
 	RootDiskEncryptionKey *CustomerEncryptionKey `json:"rootDiskEncryptionKey,omitempty"`
 }
 
+// IsSpotOrPreemptible returns true if the machine is a Spot or preemptible VM.
+func (s *GCPMachineSpec) IsSpotOrPreemptible() bool {
+	return s.Preemptible || (s.ProvisioningModel != nil && *s.ProvisioningModel == ProvisioningModelSpot)
+}
+
+// HasAccelerators returns true if the instance type of the machine is accelerator-optimized, with GPUs attached.
+func (s *GCPMachineSpec) HasAccelerators() bool {
+	return slices.Contains(acceleratorOptimizedMachineSeries, machineSeries(s.InstanceType))
+}
+
 // MetadataItem defines a single piece of metadata associated with an instance.
 type MetadataItem struct {
 	// Key is the identifier for the metadata entry.
@@ -368,6 +756,11 @@ type GCPMachineStatus struct {
 	// +optional
 	InstanceStatus *InstanceStatus `json:"instanceState,omitempty"`
 
+	// BootstrapDataDeleted is true once the bootstrap data stored in the bucket of the BootstrapDataStorage
+	// has been deleted, after the node joined the cluster.
+	// +optional
+	BootstrapDataDeleted bool `json:"bootstrapDataDeleted,omitempty"`
+
 	// FailureReason will be set in the event that there is a terminal problem
 	// reconciling the Machine and will contain a succinct value suitable
 	// for machine interpretation.
@@ -19,7 +19,11 @@ package v1beta1
 import (
 	"fmt"
 	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"k8s.io/utils/strings/slices"
 
@@ -31,8 +35,27 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/util/location"
 )
 
+// nestedVirtualizationUnsupportedCPUPlatforms are the CPU platforms older than Intel Haswell, which do not support
+// nested virtualization.
+var nestedVirtualizationUnsupportedCPUPlatforms = []string{"Intel Sandy Bridge", "Intel Ivy Bridge"}
+
+// imageReferenceRegex matches a fully-qualified image or image family reference, optionally as a Compute Engine API
+// URL.
+var imageReferenceRegex = regexp.MustCompile(`^(https://www\.googleapis\.com/compute/v1/)?projects/[^/]+/global/images/(family/)?[a-z]([-a-z0-9]*[a-z0-9])?$`)
+
+// instanceNameRegex matches a lowercase RFC 1035 label.
+var instanceNameRegex = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+
+// hostnameRegex matches a fully qualified domain name of at least two RFC 1035 labels.
+var hostnameRegex = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?(\.[a-z]([-a-z0-9]*[a-z0-9])?)+$`)
+
+// serviceAccountEmailRegex matches the email of a Google-managed or user-managed service account.
+var serviceAccountEmailRegex = regexp.MustCompile(`^[a-z0-9][-a-z0-9._]*@[a-z0-9][-a-z0-9.]*\.gserviceaccount\.com$`)
+
 // log is for logging in this package.
 var _ = logf.Log.WithName("gcpmachine-resource")
 
@@ -51,10 +74,14 @@ var _ webhook.Validator = &GCPMachine{}
 func (m *GCPMachine) ValidateCreate() (admission.Warnings, error) {
 	clusterlog.Info("validate create", "name", m.Name)
 
-	if err := validateConfidentialCompute(m.Spec); err != nil {
-		return nil, err
+	warnings, err := validateGCPMachineSpec(m.Spec)
+	if err != nil {
+		return warnings, err
+	}
+	if allErrs := validateServiceAccountSpec(m.Spec, field.NewPath("spec")); len(allErrs) > 0 {
+		return warnings, apierrors.NewInvalid(GroupVersion.WithKind("GCPMachine").GroupKind(), m.Name, allErrs)
 	}
-	return nil, validateCustomerEncryptionKey(m.Spec)
+	return warnings, nil
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
@@ -87,6 +114,23 @@ func (m *GCPMachine) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 	delete(oldGCPMachineSpec, "additionalNetworkTags")
 	delete(newGCPMachineSpec, "additionalNetworkTags")
 
+	// allow changes to the metadata, which is merged into the metadata of the running instance
+	for _, key := range []string{"additionalMetadata", "enableOSLogin", "blockProjectSSHKeys", "enableSerialPortLogging"} {
+		delete(oldGCPMachineSpec, key)
+		delete(newGCPMachineSpec, key)
+	}
+
+	// allow the root disk to grow, as disks cannot shrink. Unsetting the size would shrink the disk back to the
+	// size of the image, and is rejected as well.
+	if oldSize := old.(*GCPMachine).Spec.RootDeviceSize; m.Spec.RootDeviceSize < oldSize {
+		return nil, apierrors.NewInvalid(GroupVersion.WithKind("GCPMachine").GroupKind(), m.Name, field.ErrorList{
+			field.Invalid(field.NewPath("spec", "rootDeviceSize"), m.Spec.RootDeviceSize,
+				fmt.Sprintf("cannot be decreased from %d", oldSize)),
+		})
+	}
+	delete(oldGCPMachineSpec, "rootDeviceSize")
+	delete(newGCPMachineSpec, "rootDeviceSize")
+
 	if !reflect.DeepEqual(oldGCPMachineSpec, newGCPMachineSpec) {
 		return nil, apierrors.NewInvalid(GroupVersion.WithKind("GCPMachine").GroupKind(), m.Name, field.ErrorList{
 			field.Forbidden(field.NewPath("spec"), "cannot be modified"),
@@ -108,20 +152,313 @@ func (m *GCPMachine) Default() {
 	clusterlog.Info("default", "name", m.Name)
 }
 
+// validateGCPMachineSpec validates the spec shared by GCPMachines and GCPMachineTemplates, so that a template is
+// rejected for the same reasons as the machines created from it.
+func validateGCPMachineSpec(spec GCPMachineSpec) (admission.Warnings, error) {
+	if err := validateConfidentialCompute(spec); err != nil {
+		return nil, err
+	}
+	if err := validateOnHostMaintenance(spec); err != nil {
+		return nil, err
+	}
+	if err := validateAutomaticRestart(spec); err != nil {
+		return nil, err
+	}
+	if err := validateZone(spec); err != nil {
+		return nil, err
+	}
+	if err := validateProvisionedPerformance(spec); err != nil {
+		return nil, err
+	}
+	if err := validateAdditionalDisks(spec); err != nil {
+		return nil, err
+	}
+	if err := validateTermination(spec); err != nil {
+		return nil, err
+	}
+	if err := validateIPForwarding(spec); err != nil {
+		return nil, err
+	}
+	if err := validateHostname(spec); err != nil {
+		return nil, err
+	}
+	if err := validateInstanceNameTemplate(spec); err != nil {
+		return nil, err
+	}
+	if err := validateNetworkPerformanceConfig(spec); err != nil {
+		return nil, err
+	}
+	if err := validateAdvancedMachineFeatures(spec); err != nil {
+		return nil, err
+	}
+	warnings, err := validateImage(spec)
+	if err != nil {
+		return warnings, err
+	}
+	warnings = append(warnings, validateServiceAccount(spec)...)
+	return warnings, validateCustomerEncryptionKey(spec)
+}
+
+func validateOnHostMaintenance(spec GCPMachineSpec) error {
+	if spec.HasAccelerators() && spec.OnHostMaintenance != nil && *spec.OnHostMaintenance == HostMaintenancePolicyMigrate {
+		return fmt.Errorf("instance type %s has GPUs attached, which require OnHostMaintenance to be set to %s", spec.InstanceType, HostMaintenancePolicyTerminate)
+	}
+	return nil
+}
+
+func validateAutomaticRestart(spec GCPMachineSpec) error {
+	if spec.AutomaticRestart != nil && *spec.AutomaticRestart && spec.IsSpotOrPreemptible() {
+		return errors.New("AutomaticRestart cannot be enabled for Spot or preemptible instances")
+	}
+	return nil
+}
+
 func validateConfidentialCompute(spec GCPMachineSpec) error {
-	if spec.ConfidentialCompute != nil && *spec.ConfidentialCompute == ConfidentialComputePolicyEnabled {
-		if spec.OnHostMaintenance == nil || *spec.OnHostMaintenance == HostMaintenancePolicyMigrate {
-			return fmt.Errorf("ConfidentialCompute require OnHostMaintenance to be set to %s, the current value is: %s", HostMaintenancePolicyTerminate, HostMaintenancePolicyMigrate)
+	if spec.ConfidentialCompute == nil {
+		return nil
+	}
+
+	var supportedMachineSeries []string
+	switch *spec.ConfidentialCompute {
+	case ConfidentialComputePolicyEnabled:
+		supportedMachineSeries = confidentialComputeSupportedMachineSeries
+	case ConfidentialComputePolicyTDX:
+		supportedMachineSeries = confidentialComputeTDXSupportedMachineSeries
+	default:
+		return nil
+	}
+
+	if spec.OnHostMaintenance == nil || *spec.OnHostMaintenance == HostMaintenancePolicyMigrate {
+		return fmt.Errorf("ConfidentialCompute require OnHostMaintenance to be set to %s, the current value is: %s", HostMaintenancePolicyTerminate, HostMaintenancePolicyMigrate)
+	}
+
+	if !slices.Contains(supportedMachineSeries, machineSeries(spec.InstanceType)) {
+		return fmt.Errorf("ConfidentialCompute %s require instance type in the following series: %s", *spec.ConfidentialCompute, supportedMachineSeries)
+	}
+	return nil
+}
+
+// machineSeries returns the machine series of a predefined or custom instance type, for example n2d for
+// both n2d-standard-4 and n2d-custom-4-8192. Custom machine types without a series prefix are N1 machines.
+func machineSeries(instanceType string) string {
+	series := strings.Split(instanceType, "-")[0]
+	if series == "custom" {
+		return "n1"
+	}
+	return series
+}
+
+// machineVCPUs returns the number of vCPUs of a predefined or custom instance type, for example 32 for both
+// n2-standard-32 and n2-custom-32-131072, and false if the instance type does not tell it.
+func machineVCPUs(instanceType string) (int, bool) {
+	parts := strings.Split(instanceType, "-")
+	i := slices.Index(parts, "custom")
+	if i < 0 {
+		i = 1
+	}
+	if i+1 >= len(parts) {
+		return 0, false
+	}
+	vCPUs, err := strconv.Atoi(parts[i+1])
+	return vCPUs, err == nil
+}
+
+func validateServiceAccount(spec GCPMachineSpec) admission.Warnings {
+	if spec.ServiceAccount != nil && len(spec.ServiceAccount.Scopes) == 0 {
+		return admission.Warnings{"ServiceAccount has no scopes, the machine has no access to Google Cloud APIs through the service account"}
+	}
+	return nil
+}
+
+// validateServiceAccountSpec checks that the service account email is either the default one or the email of
+// a service account, and that the scopes are OAuth scope URLs or known aliases.
+func validateServiceAccountSpec(spec GCPMachineSpec, fldPath *field.Path) field.ErrorList {
+	if spec.ServiceAccount == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	fldPath = fldPath.Child("serviceAccounts")
+	if email := spec.ServiceAccount.Email; email != "" && email != ServiceAccountDefaultEmail && !serviceAccountEmailRegex.MatchString(email) {
+		allErrs = append(allErrs,
+			field.Invalid(fldPath.Child("email"), email, fmt.Sprintf("must be %q or a service account email", ServiceAccountDefaultEmail)),
+		)
+	}
+	for i, scope := range spec.ServiceAccount.Scopes {
+		if _, ok := scopeAliases[scope]; ok {
+			continue
+		}
+		if !strings.HasPrefix(scope, scopeURLPrefix) || len(scope) == len(scopeURLPrefix) {
+			allErrs = append(allErrs,
+				field.Invalid(fldPath.Child("scopes").Index(i), scope, fmt.Sprintf("must be an OAuth scope URL starting with %s or a known scope alias", scopeURLPrefix)),
+			)
 		}
+	}
+	return allErrs
+}
+
+func validateImage(spec GCPMachineSpec) (admission.Warnings, error) {
+	if spec.Image == nil {
+		return nil, nil
+	}
+	if !imageReferenceRegex.MatchString(*spec.Image) {
+		return nil, fmt.Errorf("Image %s is not a fully-qualified image reference, the expected format is projects/<project>/global/images/<image> or projects/<project>/global/images/family/<family>", *spec.Image)
+	}
+	if spec.ImageFamily != nil {
+		return admission.Warnings{"ImageFamily is ignored when Image is set"}, nil
+	}
+	return nil, nil
+}
+
+func validateZone(spec GCPMachineSpec) error {
+	if spec.Zone == nil {
+		return nil
+	}
+	loc, err := location.Parse(*spec.Zone)
+	if err != nil || loc.Zone == nil {
+		return fmt.Errorf("Zone %s is not a valid zone, the expected format is <region>-<zone> such as us-central1-a", *spec.Zone)
+	}
+	return nil
+}
 
-		machineSeries := strings.Split(spec.InstanceType, "-")[0]
-		if !slices.Contains(confidentialComputeSupportedMachineSeries, machineSeries) {
-			return fmt.Errorf("ConfidentialCompute require instance type in the following series: %s", confidentialComputeSupportedMachineSeries)
+func validateAdvancedMachineFeatures(spec GCPMachineSpec) error {
+	if spec.AdvancedMachineFeatures == nil || !spec.AdvancedMachineFeatures.EnableNestedVirtualization {
+		return nil
+	}
+	if spec.MinCPUPlatform != nil && slices.Contains(nestedVirtualizationUnsupportedCPUPlatforms, *spec.MinCPUPlatform) {
+		return fmt.Errorf("nested virtualization is not supported on the %s CPU platform, set MinCPUPlatform to a later platform or leave it unset", *spec.MinCPUPlatform)
+	}
+	return nil
+}
+
+func validateTermination(spec GCPMachineSpec) error {
+	if spec.MaxRunDuration == nil && spec.InstanceTerminationAction == nil {
+		return nil
+	}
+	if !spec.Preemptible && (spec.ProvisioningModel == nil || *spec.ProvisioningModel == ProvisioningModelStandard) {
+		return fmt.Errorf("MaxRunDuration and InstanceTerminationAction require ProvisioningModel to be set to %s or Preemptible to be true", ProvisioningModelSpot)
+	}
+	if spec.MaxRunDuration != nil && spec.MaxRunDuration.Duration < time.Second {
+		return fmt.Errorf("MaxRunDuration %s must be at least one second", spec.MaxRunDuration.Duration)
+	}
+	return nil
+}
+
+func validateIPForwarding(spec GCPMachineSpec) error {
+	if spec.IPForwarding != nil && *spec.IPForwarding == IPForwardingDisabled && len(spec.AliasIPRanges) > 0 {
+		return errors.New("IPForwarding cannot be disabled when AliasIPRanges are set")
+	}
+	return nil
+}
+
+// validateInstanceNameTemplate renders the instance name template with sample values, so that templates which
+// cannot be rendered or render invalid names are rejected. The template is rendered for two machines, as the
+// name must depend on the machine name for the instances of a cluster to have unique names.
+func validateInstanceNameTemplate(spec GCPMachineSpec) error {
+	if spec.InstanceNameTemplate == nil {
+		return nil
+	}
+	params := InstanceNameParams{
+		ClusterName: "cluster",
+		MachineName: "machine",
+		Namespace:   "default",
+		Project:     "project",
+		Role:        "node",
+	}
+	name, err := RenderInstanceName(*spec.InstanceNameTemplate, params)
+	if err != nil {
+		return fmt.Errorf("InstanceNameTemplate %q is invalid: %w", *spec.InstanceNameTemplate, err)
+	}
+	if !instanceNameRegex.MatchString(name) {
+		return fmt.Errorf("InstanceNameTemplate %q must render a lowercase RFC 1035 label, got %q", *spec.InstanceNameTemplate, name)
+	}
+	params.MachineName = "other-machine"
+	if otherName, _ := RenderInstanceName(*spec.InstanceNameTemplate, params); otherName == name {
+		return fmt.Errorf("InstanceNameTemplate %q must include {{ .machine.name }}", *spec.InstanceNameTemplate)
+	}
+	return nil
+}
+
+func validateHostname(spec GCPMachineSpec) error {
+	if spec.Hostname == nil {
+		return nil
+	}
+	if len(*spec.Hostname) > 253 || !hostnameRegex.MatchString(*spec.Hostname) {
+		return fmt.Errorf("Hostname %q must be a fully qualified domain name of at least two RFC 1035 labels of at most 253 characters", *spec.Hostname)
+	}
+	for _, label := range strings.Split(*spec.Hostname, ".") {
+		if len(label) > 63 {
+			return fmt.Errorf("Hostname %q label %q must be no more than 63 characters", *spec.Hostname, label)
+		}
+	}
+	return nil
+}
+
+func validateNetworkPerformanceConfig(spec GCPMachineSpec) error {
+	if spec.NetworkPerformanceConfig == nil || spec.NetworkPerformanceConfig.TotalEgressBandwidthTier != EgressBandwidthTierTier1 {
+		return nil
+	}
+	minVCPUs, ok := tier1NetworkingMinVCPUsByMachineSeries[machineSeries(spec.InstanceType)]
+	if !ok {
+		series := make([]string, 0, len(tier1NetworkingMinVCPUsByMachineSeries))
+		for name := range tier1NetworkingMinVCPUsByMachineSeries {
+			series = append(series, name)
 		}
+		sort.Strings(series)
+		return fmt.Errorf("TotalEgressBandwidthTier %s require instance type in the following series: %s", EgressBandwidthTierTier1, series)
+	}
+	if vCPUs, ok := machineVCPUs(spec.InstanceType); ok && vCPUs < minVCPUs {
+		return fmt.Errorf("TotalEgressBandwidthTier %s require instance type %s to have at least %d vCPUs", EgressBandwidthTierTier1, spec.InstanceType, minVCPUs)
 	}
 	return nil
 }
 
+func validateProvisionedPerformance(spec GCPMachineSpec) error {
+	if spec.RootDeviceProvisionedIops != nil || spec.RootDeviceProvisionedThroughput != nil {
+		if spec.RootDeviceType == nil || !spec.RootDeviceType.IsHyperdisk() {
+			return errors.New("RootDeviceProvisionedIops and RootDeviceProvisionedThroughput require RootDeviceType to be a Hyperdisk type")
+		}
+	}
+
+	for i, disk := range spec.AdditionalDisks {
+		if disk.ProvisionedIops != nil || disk.ProvisionedThroughput != nil {
+			if disk.DeviceType == nil || !disk.DeviceType.IsHyperdisk() {
+				return fmt.Errorf("AdditionalDisks[%d] ProvisionedIops and ProvisionedThroughput require DeviceType to be a Hyperdisk type", i)
+			}
+		}
+	}
+	return nil
+}
+
+func validateAdditionalDisks(spec GCPMachineSpec) error {
+	localSSDCount := 0
+	for i, disk := range spec.AdditionalDisks {
+		if disk.DeviceType == nil || *disk.DeviceType != LocalSsdDiskType {
+			continue
+		}
+
+		if disk.Size != nil && *disk.Size != localSSDDiskSize {
+			return fmt.Errorf("AdditionalDisks[%d] Size cannot be set for DeviceType %s, its size is always %dGB", i, LocalSsdDiskType, localSSDDiskSize)
+		}
+		localSSDCount++
+	}
+
+	if localSSDCount == 0 {
+		return nil
+	}
+	// The numbers of local SSDs are only validated for the instance types they are known for.
+	counts, ok := LocalSSDCountsForMachineType(spec.InstanceType)
+	if !ok {
+		return nil
+	}
+	for _, count := range counts {
+		if count == localSSDCount {
+			return nil
+		}
+	}
+	return fmt.Errorf("AdditionalDisks of DeviceType %s must number one of %v for instance type %s, got %d", LocalSsdDiskType, counts, spec.InstanceType, localSSDCount)
+}
+
 func checkKeyType(key *CustomerEncryptionKey) error {
 	switch key.KeyType {
 	case CustomerManagedKey:
@@ -17,20 +17,35 @@ limitations under the License.
 package v1beta1
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 func TestGCPMachine_ValidateCreate(t *testing.T) {
 	g := NewWithT(t)
 	confidentialComputeEnabled := ConfidentialComputePolicyEnabled
+	confidentialComputeTDX := ConfidentialComputePolicyTDX
 	onHostMaintenanceTerminate := HostMaintenancePolicyTerminate
 	onHostMaintenanceMigrate := HostMaintenancePolicyMigrate
+	localSSDDisks := func(count int) []AttachedDiskSpec {
+		disks := make([]AttachedDiskSpec, count)
+		for i := range disks {
+			disks[i].DeviceType = ptr.To(LocalSsdDiskType)
+		}
+		return disks
+	}
 	tests := []struct {
 		name string
 		*GCPMachine
-		wantErr bool
+		wantErr      bool
+		wantWarnings admission.Warnings
 	}{
 		{
 			name: "GCPMachined with OnHostMaintenance set to Terminate - valid",
@@ -85,6 +100,279 @@ func TestGCPMachine_ValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "GCPMachined with ConfidentialCompute enabled and supported custom instance type - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:        "n2d-custom-4-8192",
+					ConfidentialCompute: &confidentialComputeEnabled,
+					OnHostMaintenance:   &onHostMaintenanceTerminate,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachined with ConfidentialCompute enabled and N1 custom instance type - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:        "custom-4-8192",
+					ConfidentialCompute: &confidentialComputeEnabled,
+					OnHostMaintenance:   &onHostMaintenanceTerminate,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachined with ConfidentialCompute TDX and C3 instance type - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:        "c3-standard-4",
+					ConfidentialCompute: &confidentialComputeTDX,
+					OnHostMaintenance:   &onHostMaintenanceTerminate,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachined with ConfidentialCompute TDX and default OnHostMaintenance (Migrate) - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:        "c3-standard-4",
+					ConfidentialCompute: &confidentialComputeTDX,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachined with ConfidentialCompute TDX and N2D instance type - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:        "n2d-standard-4",
+					ConfidentialCompute: &confidentialComputeTDX,
+					OnHostMaintenance:   &onHostMaintenanceTerminate,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with AliasIPRanges - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					AliasIPRanges: []AliasIPRange{{IPCidrRange: "10.1.2.0/24"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with AliasIPRanges and IPForwarding disabled - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					AliasIPRanges: []AliasIPRange{{IPCidrRange: "10.1.2.0/24"}},
+					IPForwarding:  ptr.To(IPForwardingDisabled),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with fully qualified Hostname - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					Hostname: ptr.To("node-1.example.com"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with single label Hostname - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					Hostname: ptr.To("node-1"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with Hostname label starting with a digit - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					Hostname: ptr.To("1node.example.com"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with uppercase Hostname - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					Hostname: ptr.To("Node-1.example.com"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with Hostname label longer than 63 characters - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					Hostname: ptr.To(strings.Repeat("a", 64) + ".example.com"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with GPUs and OnHostMaintenance Terminate - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:      "a2-highgpu-1g",
+					OnHostMaintenance: ptr.To(HostMaintenancePolicyTerminate),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with GPUs and OnHostMaintenance Migrate - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:      "a2-highgpu-1g",
+					OnHostMaintenance: ptr.To(HostMaintenancePolicyMigrate),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with AutomaticRestart disabled for a Spot instance - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					ProvisioningModel: ptr.To(ProvisioningModelSpot),
+					AutomaticRestart:  ptr.To(false),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with AutomaticRestart enabled for a Spot instance - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					ProvisioningModel: ptr.To(ProvisioningModelSpot),
+					AutomaticRestart:  ptr.To(true),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with InstanceNameTemplate - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceNameTemplate: ptr.To("{{ .project }}-{{ .machine.name }}"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with InstanceNameTemplate referencing an unknown value - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceNameTemplate: ptr.To("{{ .environment }}-{{ .machine.name }}"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with InstanceNameTemplate rendering an invalid name - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceNameTemplate: ptr.To("Prod_{{ .machine.name }}"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with InstanceNameTemplate not including the machine name - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceNameTemplate: ptr.To("{{ .cluster.name }}-{{ .role }}"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with BootstrapDataStorage - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					BootstrapDataStorage: &BootstrapDataStorage{Bucket: "my-bucket"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with BootstrapDataStorage and StartupScript - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					BootstrapDataStorage: &BootstrapDataStorage{Bucket: "my-bucket"},
+					StartupScript:        &MachineScript{Content: ptr.To("echo hello")},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with Tier1 egress bandwidth and supported instance type - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:             "n2-standard-32",
+					NetworkPerformanceConfig: &NetworkPerformanceConfig{TotalEgressBandwidthTier: EgressBandwidthTierTier1},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with Tier1 egress bandwidth and unsupported instance type - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:             "e2-standard-32",
+					NetworkPerformanceConfig: &NetworkPerformanceConfig{TotalEgressBandwidthTier: EgressBandwidthTierTier1},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with Tier1 egress bandwidth and too few vCPUs - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:             "n2-standard-16",
+					NetworkPerformanceConfig: &NetworkPerformanceConfig{TotalEgressBandwidthTier: EgressBandwidthTierTier1},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with Tier1 egress bandwidth and custom instance type with enough vCPUs - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:             "n2-custom-32-131072",
+					NetworkPerformanceConfig: &NetworkPerformanceConfig{TotalEgressBandwidthTier: EgressBandwidthTierTier1},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with Tier1 egress bandwidth and accelerator-optimized instance type - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:             "a3-highgpu-8g",
+					NetworkPerformanceConfig: &NetworkPerformanceConfig{TotalEgressBandwidthTier: EgressBandwidthTierTier1},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with Default egress bandwidth and any instance type - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:             "e2-standard-4",
+					NetworkPerformanceConfig: &NetworkPerformanceConfig{TotalEgressBandwidthTier: EgressBandwidthTierDefault},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "GCPMachine with RootDiskEncryptionKey KeyType Managed and Managed field set",
 			GCPMachine: &GCPMachine{
@@ -165,17 +453,540 @@ func TestGCPMachine_ValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
-	}
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			t.Parallel()
-			warn, err := test.GCPMachine.ValidateCreate()
-			if test.wantErr {
-				g.Expect(err).To(HaveOccurred())
-			} else {
-				g.Expect(err).NotTo(HaveOccurred())
-			}
-			g.Expect(warn).To(BeNil())
+		{
+			name: "GCPMachine with a zone",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					Zone: ptr.To("us-central1-a"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with a region as zone",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					Zone: ptr.To("us-central1"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with a fully-qualified image",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					Image: ptr.To("projects/my-images/global/images/golden"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with an image family as image",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					Image: ptr.To("projects/my-images/global/images/family/golden"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with a short image name",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					Image: ptr.To("golden"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with both image and image family",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					Image:       ptr.To("projects/my-images/global/images/golden"),
+					ImageFamily: ptr.To("projects/my-images/global/images/family/golden"),
+				},
+			},
+			wantErr:      false,
+			wantWarnings: admission.Warnings{"ImageFamily is ignored when Image is set"},
+		},
+		{
+			name: "GCPMachine with provisioned IOPS and throughput on Hyperdisk root and additional disks - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:                    "c3-standard-4",
+					RootDeviceType:                  ptr.To(DiskType("hyperdisk-balanced")),
+					RootDeviceProvisionedIops:       ptr.To[int64](5000),
+					RootDeviceProvisionedThroughput: ptr.To[int64](200),
+					AdditionalDisks: []AttachedDiskSpec{
+						{
+							DeviceType:      ptr.To(DiskType("hyperdisk-extreme")),
+							ProvisionedIops: ptr.To[int64](10000),
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with provisioned IOPS on a persistent disk root - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:              "n2-standard-4",
+					RootDeviceType:            ptr.To(PdSsdDiskType),
+					RootDeviceProvisionedIops: ptr.To[int64](5000),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with provisioned throughput on the default root disk type - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:                    "n2-standard-4",
+					RootDeviceProvisionedThroughput: ptr.To[int64](200),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with provisioned throughput on a persistent disk additional disk - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2-standard-4",
+					AdditionalDisks: []AttachedDiskSpec{
+						{
+							DeviceType:            ptr.To(PdStandardDiskType),
+							ProvisionedThroughput: ptr.To[int64](200),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with local SSD, balanced and Hyperdisk additional disks - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2-standard-4",
+					AdditionalDisks: []AttachedDiskSpec{
+						{DeviceType: ptr.To(LocalSsdDiskType)},
+						{DeviceType: ptr.To(PdBalancedDiskType), Size: ptr.To[int64](100)},
+						{DeviceType: ptr.To(DiskType("hyperdisk-balanced"))},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with a pd-extreme additional disk - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2-standard-4",
+					AdditionalDisks: []AttachedDiskSpec{
+						{DeviceType: ptr.To(DiskType("pd-extreme"))},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with a local SSD additional disk of overridden size - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2-standard-4",
+					AdditionalDisks: []AttachedDiskSpec{
+						{DeviceType: ptr.To(LocalSsdDiskType), Size: ptr.To[int64](500)},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with more local SSD additional disks than the machine series supports - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:    "c2-standard-8",
+					AdditionalDisks: localSSDDisks(9),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with fewer local SSD additional disks than the machine type supports - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:    "n2-standard-32",
+					AdditionalDisks: localSSDDisks(2),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with a number of local SSD additional disks the machine type supports - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:    "n2-custom-32-131072",
+					AdditionalDisks: localSSDDisks(4),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with a local SSD additional disk on a machine series of unknown limits - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "c3d-standard-8-lssd",
+					AdditionalDisks: []AttachedDiskSpec{
+						{DeviceType: ptr.To(LocalSsdDiskType)},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with MaxRunDuration and InstanceTerminationAction on a Spot instance - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:              "n2-standard-4",
+					ProvisioningModel:         ptr.To(ProvisioningModelSpot),
+					MaxRunDuration:            &metav1.Duration{Duration: 4 * time.Hour},
+					InstanceTerminationAction: ptr.To(InstanceTerminationActionDelete),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with MaxRunDuration on a preemptible instance - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:   "n2-standard-4",
+					Preemptible:    true,
+					MaxRunDuration: &metav1.Duration{Duration: 30 * time.Minute},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with InstanceTerminationAction on a Standard instance - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:              "n2-standard-4",
+					ProvisioningModel:         ptr.To(ProvisioningModelStandard),
+					InstanceTerminationAction: ptr.To(InstanceTerminationActionStop),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with MaxRunDuration and the default provisioning model - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:   "n2-standard-4",
+					MaxRunDuration: &metav1.Duration{Duration: 4 * time.Hour},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with MaxRunDuration shorter than a second - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:      "n2-standard-4",
+					ProvisioningModel: ptr.To(ProvisioningModelSpot),
+					MaxRunDuration:    &metav1.Duration{Duration: time.Millisecond},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with nested virtualization and a Haswell or later CPU platform - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2-standard-4",
+					AdvancedMachineFeatures: &AdvancedMachineFeatures{
+						EnableNestedVirtualization: true,
+						ThreadsPerCore:             ptr.To[int64](1),
+					},
+					MinCPUPlatform: ptr.To("Intel Cascade Lake"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with nested virtualization on an AMD machine type without CPU platform - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2d-standard-4",
+					AdvancedMachineFeatures: &AdvancedMachineFeatures{
+						EnableNestedVirtualization: true,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with nested virtualization and a pre-Haswell CPU platform - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n1-standard-4",
+					AdvancedMachineFeatures: &AdvancedMachineFeatures{
+						EnableNestedVirtualization: true,
+					},
+					MinCPUPlatform: ptr.To("Intel Ivy Bridge"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with a service account and explicit scopes - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2-standard-4",
+					ServiceAccount: &ServiceAccount{
+						Email:  "nodes@my-proj.iam.gserviceaccount.com",
+						Scopes: []string{"https://www.googleapis.com/auth/devstorage.read_only"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with the default service account and scope aliases - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2-standard-4",
+					ServiceAccount: &ServiceAccount{
+						Email:  "default",
+						Scopes: []string{"storage-ro", "logging-write"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with a malformed service account email - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2-standard-4",
+					ServiceAccount: &ServiceAccount{
+						Email:  "nodes@my-proj",
+						Scopes: []string{"cloud-platform"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with an unknown scope alias - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2-standard-4",
+					ServiceAccount: &ServiceAccount{
+						Email:  "nodes@my-proj.iam.gserviceaccount.com",
+						Scopes: []string{"storage-readonly"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with a service account and no scopes - valid with a warning",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2-standard-4",
+					ServiceAccount: &ServiceAccount{
+						Email: "nodes@my-proj.iam.gserviceaccount.com",
+					},
+				},
+			},
+			wantErr:      false,
+			wantWarnings: admission.Warnings{"ServiceAccount has no scopes, the machine has no access to Google Cloud APIs through the service account"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			warn, err := test.GCPMachine.ValidateCreate()
+			if test.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			if test.wantWarnings != nil {
+				g.Expect(warn).To(Equal(test.wantWarnings))
+			} else {
+				g.Expect(warn).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestGCPMachine_ValidateUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name          string
+		newGCPMachine *GCPMachine
+		oldGCPMachine *GCPMachine
+		wantErr       bool
+	}{
+		{
+			name: "GCPMachine with a larger root device size",
+			newGCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:   "n2d-standard-4",
+					RootDeviceSize: 50,
+				},
+			},
+			oldGCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:   "n2d-standard-4",
+					RootDeviceSize: 30,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with a smaller root device size",
+			newGCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:   "n2d-standard-4",
+					RootDeviceSize: 20,
+				},
+			},
+			oldGCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:   "n2d-standard-4",
+					RootDeviceSize: 30,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with an unset root device size",
+			newGCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2d-standard-4",
+				},
+			},
+			oldGCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:   "n2d-standard-4",
+					RootDeviceSize: 50,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with a root device size set from the default",
+			newGCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:   "n2d-standard-4",
+					RootDeviceSize: 50,
+				},
+			},
+			oldGCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2d-standard-4",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with changed metadata",
+			newGCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:       "n2d-standard-4",
+					AdditionalMetadata: []MetadataItem{{Key: "team", Value: ptr.To("a")}},
+					EnableOSLogin:      ptr.To(true),
+				},
+			},
+			oldGCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2d-standard-4",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with a changed instance type",
+			newGCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2d-standard-8",
+				},
+			},
+			oldGCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2d-standard-4",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			warn, err := test.newGCPMachine.ValidateUpdate(test.oldGCPMachine)
+			if test.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(warn).To(BeNil())
+		})
+	}
+}
+
+func TestValidateServiceAccountSpec(t *testing.T) {
+	g := NewWithT(t)
+
+	allErrs := validateServiceAccountSpec(GCPMachineSpec{
+		ServiceAccount: &ServiceAccount{
+			Email:  "nodes@my-proj",
+			Scopes: []string{"cloud-platform", "storage-readonly"},
+		},
+	}, field.NewPath("spec"))
+
+	g.Expect(allErrs).To(HaveLen(2))
+	g.Expect(allErrs[0].Field).To(Equal("spec.serviceAccounts.email"))
+	g.Expect(allErrs[0].BadValue).To(Equal("nodes@my-proj"))
+	g.Expect(allErrs[1].Field).To(Equal("spec.serviceAccounts.scopes[1]"))
+	g.Expect(allErrs[1].BadValue).To(Equal("storage-readonly"))
+}
+
+func TestGCPMachine_Default(t *testing.T) {
+	g := NewWithT(t)
+	tests := []struct {
+		name               string
+		spec               GCPMachineSpec
+		wantMinCPUPlatform *string
+	}{
+		{
+			name: "nested virtualization does not default the CPU platform",
+			spec: GCPMachineSpec{
+				AdvancedMachineFeatures: &AdvancedMachineFeatures{EnableNestedVirtualization: true},
+			},
+		},
+		{
+			name: "nested virtualization keeps the CPU platform",
+			spec: GCPMachineSpec{
+				AdvancedMachineFeatures: &AdvancedMachineFeatures{EnableNestedVirtualization: true},
+				MinCPUPlatform:          ptr.To("Intel Ice Lake"),
+			},
+			wantMinCPUPlatform: ptr.To("Intel Ice Lake"),
+		},
+		{
+			name: "no CPU platform without nested virtualization",
+			spec: GCPMachineSpec{
+				AdvancedMachineFeatures: &AdvancedMachineFeatures{ThreadsPerCore: ptr.To[int64](1)},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := &GCPMachine{Spec: test.spec}
+			m.Default()
+			g.Expect(m.Spec.MinCPUPlatform).To(Equal(test.wantMinCPUPlatform))
 		})
 	}
 }
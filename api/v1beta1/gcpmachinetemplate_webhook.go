@@ -47,7 +47,17 @@ var _ webhook.Validator = &GCPMachineTemplate{}
 func (r *GCPMachineTemplate) ValidateCreate() (admission.Warnings, error) {
 	clusterlog.Info("validate create", "name", r.Name)
 
-	return nil, validateConfidentialCompute(r.Spec.Template.Spec)
+	if r.Spec.Template.Spec.Hostname != nil {
+		return nil, errors.New("Hostname is not supported in GCPMachineTemplate, as all the machines created from it would share it")
+	}
+	warnings, err := validateGCPMachineSpec(r.Spec.Template.Spec)
+	if err != nil {
+		return warnings, err
+	}
+	if allErrs := validateServiceAccountSpec(r.Spec.Template.Spec, field.NewPath("spec", "template", "spec")); len(allErrs) > 0 {
+		return warnings, apierrors.NewInvalid(GroupVersion.WithKind("GCPMachineTemplate").GroupKind(), r.Name, allErrs)
+	}
+	return warnings, nil
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
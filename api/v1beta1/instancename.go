@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"text/template"
+)
+
+// maxInstanceNameLength is the maximum length of the name of a GCP instance.
+const maxInstanceNameLength = 63
+
+// InstanceNameParams holds the values available to an instance name template.
+type InstanceNameParams struct {
+	// ClusterName is the name of the cluster, available as {{ .cluster.name }}.
+	ClusterName string
+	// MachineName is the name of the GCPMachine, available as {{ .machine.name }}.
+	MachineName string
+	// Namespace is the namespace of the GCPMachine, available as {{ .machine.namespace }}.
+	Namespace string
+	// Project is the project of the cluster, available as {{ .project }}.
+	Project string
+	// Role is the role of the machine, either control-plane or node, available as {{ .role }}.
+	Role string
+}
+
+// RenderInstanceName renders an instance name template. Names longer than the 63 characters allowed by GCP are
+// truncated and suffixed with a hash of the full name, so that they remain unique.
+func RenderInstanceName(nameTemplate string, params InstanceNameParams) (string, error) {
+	tmpl, err := template.New("instanceName").Option("missingkey=error").Parse(nameTemplate)
+	if err != nil {
+		return "", fmt.Errorf("parsing instance name template: %w", err)
+	}
+
+	var b strings.Builder
+	data := map[string]interface{}{
+		"cluster": map[string]string{
+			"name": params.ClusterName,
+		},
+		"machine": map[string]string{
+			"name":      params.MachineName,
+			"namespace": params.Namespace,
+		},
+		"project": params.Project,
+		"role":    params.Role,
+	}
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("rendering instance name template: %w", err)
+	}
+
+	name := b.String()
+	if len(name) <= maxInstanceNameLength {
+		return name, nil
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	suffix := fmt.Sprintf("-%08x", h.Sum32())
+	return strings.TrimRight(name[:maxInstanceNameLength-len(suffix)], "-") + suffix, nil
+}
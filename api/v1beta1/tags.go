@@ -16,6 +16,8 @@ limitations under the License.
 
 package v1beta1
 
+import "slices"
+
 // ResourceManagerTags is an slice of ResourceManagerTag structs.
 type ResourceManagerTags []ResourceManagerTag
 
@@ -58,7 +60,17 @@ type ResourceManagerTag struct {
 	Value string `json:"value"`
 }
 
-// Merge merges resource manager tags in receiver and other.
+// Merge merges resource manager tags in receiver and other. A tag in other replaces the tag of the
+// receiver with the same ParentID and Key.
 func (t *ResourceManagerTags) Merge(other ResourceManagerTags) {
-	*t = append(*t, other...)
+	for _, tag := range other {
+		i := slices.IndexFunc(*t, func(existing ResourceManagerTag) bool {
+			return existing.ParentID == tag.ParentID && existing.Key == tag.Key
+		})
+		if i >= 0 {
+			(*t)[i] = tag
+			continue
+		}
+		*t = append(*t, tag)
+	}
 }
@@ -56,6 +56,11 @@ type Network struct {
 	// +optional
 	Router *string `json:"router,omitempty"`
 
+	// CloudRouter is the full reference to the Cloud Router created for hybrid connectivity,
+	// which is deleted once it is removed from the spec.
+	// +optional
+	CloudRouter *string `json:"cloudRouter,omitempty"`
+
 	// APIServerAddress is the IPV4 global address assigned to the load balancer
 	// created for the API Server.
 	// +optional
@@ -119,7 +124,8 @@ type NetworkSpec struct {
 	//
 	// An auto mode VPC network starts with one subnet per region. Each
 	// subnet has a predetermined range as described in Auto mode VPC
-	// network IP ranges.
+	// network IP ranges. A "custom" mode VPC network requires at least one
+	// subnet in the region of the cluster.
 	//
 	// Defaults to true.
 	// +optional
@@ -140,13 +146,146 @@ type NetworkSpec struct {
 	// Mtu: Maximum Transmission Unit in bytes. The minimum value for this field is
 	// 1300 and the maximum value is 8896. The suggested value is 1500, which is
 	// the default MTU used on the Internet, or 8896 if you want to use Jumbo
-	// frames. If unspecified, the value defaults to 1460.
+	// frames. If unspecified, the value defaults to 1460. It can't be changed once
+	// the network is created, as GCP requires no VM to be attached to the network.
 	// More info: https://pkg.go.dev/google.golang.org/api/compute/v1#Network
 	// +kubebuilder:validation:Minimum:=1300
 	// +kubebuilder:validation:Maximum:=8896
 	// +kubebuilder:default:=1460
 	// +optional
 	Mtu int64 `json:"mtu,omitempty"`
+
+	// NodePortFirewall configures a firewall rule opening the NodePort range to the worker nodes.
+	// +optional
+	NodePortFirewall *NodePortFirewallSpec `json:"nodePortFirewall,omitempty"`
+
+	// AdditionalFirewallRules are user-defined firewall rules created in the network of the cluster,
+	// in addition to the ones created by default. Rules changed out of band are restored, and rules
+	// removed from the list are deleted, as are all of them with the cluster.
+	// +optional
+	AdditionalFirewallRules []FirewallRule `json:"additionalFirewallRules,omitempty"`
+
+	// FirewallLogConfig configures the logging of the firewall rules created by default. It is
+	// also used by the additional firewall rules that do not set their own LogConfig.
+	// +optional
+	FirewallLogConfig *FirewallLogConfig `json:"firewallLogConfig,omitempty"`
+}
+
+// FirewallLogMetadata defines the metadata included in the firewall rule logs.
+type FirewallLogMetadata string
+
+const (
+	// FirewallLogMetadataIncludeAll includes all the metadata in the firewall rule logs.
+	FirewallLogMetadataIncludeAll = FirewallLogMetadata("INCLUDE_ALL_METADATA")
+
+	// FirewallLogMetadataExcludeAll excludes all the metadata from the firewall rule logs.
+	FirewallLogMetadataExcludeAll = FirewallLogMetadata("EXCLUDE_ALL_METADATA")
+)
+
+// FirewallLogConfig configures the logging of a firewall rule.
+type FirewallLogConfig struct {
+	// Enabled turns on the logging of the connections matched by the firewall rule.
+	Enabled bool `json:"enabled"`
+
+	// Metadata defines the metadata included in the logs when logging is enabled.
+	// Defaults to INCLUDE_ALL_METADATA.
+	// +kubebuilder:validation:Enum=INCLUDE_ALL_METADATA;EXCLUDE_ALL_METADATA
+	// +optional
+	Metadata *FirewallLogMetadata `json:"metadata,omitempty"`
+}
+
+// FirewallRuleDirection is the direction of traffic a firewall rule applies to.
+type FirewallRuleDirection string
+
+const (
+	// FirewallRuleDirectionIngress applies the firewall rule to incoming traffic.
+	FirewallRuleDirectionIngress = FirewallRuleDirection("INGRESS")
+
+	// FirewallRuleDirectionEgress applies the firewall rule to outgoing traffic.
+	FirewallRuleDirectionEgress = FirewallRuleDirection("EGRESS")
+)
+
+// FirewallRuleAction is the action taken on the traffic matched by a firewall rule.
+type FirewallRuleAction string
+
+const (
+	// FirewallRuleActionAllow allows the matched traffic.
+	FirewallRuleActionAllow = FirewallRuleAction("Allow")
+
+	// FirewallRuleActionDeny denies the matched traffic.
+	FirewallRuleActionDeny = FirewallRuleAction("Deny")
+)
+
+// FirewallRule defines a user-defined firewall rule of the network of the cluster.
+type FirewallRule struct {
+	// Name is the name of the firewall rule. It is prefixed with the name of the cluster
+	// to make it unique in the project.
+	Name string `json:"name"`
+
+	// Direction is the direction of traffic the rule applies to. INGRESS rules match
+	// SourceRanges, while EGRESS rules match DestinationRanges.
+	// +kubebuilder:validation:Enum=INGRESS;EGRESS
+	// +kubebuilder:default=INGRESS
+	// +optional
+	Direction FirewallRuleDirection `json:"direction,omitempty"`
+
+	// Action is the action taken on the matched traffic.
+	// +kubebuilder:validation:Enum=Allow;Deny
+	// +kubebuilder:default=Allow
+	// +optional
+	Action FirewallRuleAction `json:"action,omitempty"`
+
+	// Protocols are the protocols and ports matched by the rule.
+	// +kubebuilder:validation:MinItems=1
+	Protocols []FirewallRuleProtocol `json:"protocols"`
+
+	// Priority is the priority of the rule, from 0 (highest) to 65535 (lowest).
+	// Defaults to 1000.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Priority *int64 `json:"priority,omitempty"`
+
+	// SourceRanges are the CIDR ranges matched by an INGRESS rule.
+	// +optional
+	SourceRanges []string `json:"sourceRanges,omitempty"`
+
+	// DestinationRanges are the CIDR ranges matched by an EGRESS rule.
+	// +optional
+	DestinationRanges []string `json:"destinationRanges,omitempty"`
+
+	// TargetTags are the network tags of the instances the rule applies to. If not set, the rule
+	// applies to all the instances of the network.
+	// +optional
+	TargetTags []string `json:"targetTags,omitempty"`
+
+	// LogConfig configures the logging of the firewall rule. If not set, the FirewallLogConfig of
+	// the network is used.
+	// +optional
+	LogConfig *FirewallLogConfig `json:"logConfig,omitempty"`
+}
+
+// FirewallRuleProtocol defines a protocol and ports matched by a firewall rule.
+type FirewallRuleProtocol struct {
+	// Protocol is the IP protocol, such as tcp, udp, icmp or all.
+	Protocol string `json:"protocol"`
+
+	// Ports are the ports or port ranges, such as 443 or 8000-9000. If not set, all the ports of
+	// the protocol are matched.
+	// +optional
+	Ports []string `json:"ports,omitempty"`
+}
+
+// NodePortFirewallSpec configures the firewall rule opening the NodePort range (30000-32767) of the
+// worker nodes, so that workloads exposed by NodePort services are reachable.
+type NodePortFirewallSpec struct {
+	// Enabled creates the firewall rule. Disabling it deletes the rule.
+	Enabled bool `json:"enabled"`
+
+	// SourceRanges are the CIDR ranges allowed to reach the NodePort range.
+	// Required when the firewall rule is enabled.
+	// +optional
+	SourceRanges []string `json:"sourceRanges,omitempty"`
 }
 
 // LoadBalancerType defines the Load Balancer that should be created.
@@ -177,6 +316,14 @@ type LoadBalancerSpec struct {
 	// +optional
 	APIServerInstanceGroupTagOverride *string `json:"apiServerInstanceGroupTagOverride,omitempty"`
 
+	// APIServerInstanceGroupNameSuffix is appended to the name of the API Server Instance Groups, which
+	// otherwise are named <cluster>-<tag>-<zone>. Set it to a value unique to the cluster, such as a short hash,
+	// to avoid collisions with the Instance Groups of clusters with the same name in the same project.
+	// +kubebuilder:validation:MaxLength=8
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]+$`
+	// +optional
+	APIServerInstanceGroupNameSuffix *string `json:"apiServerInstanceGroupNameSuffix,omitempty"`
+
 	// LoadBalancerType defines the type of Load Balancer that should be created.
 	// If not set, a Global External Proxy Load Balancer will be created by default.
 	// +optional
@@ -185,8 +332,47 @@ type LoadBalancerSpec struct {
 	// InternalLoadBalancer is the configuration for an Internal Passthrough Network Load Balancer.
 	// +optional
 	InternalLoadBalancer *LoadBalancer `json:"internalLoadBalancer,omitempty"`
+
+	// ProxyHeader defines the type of proxy header the Global External Proxy Load Balancer
+	// prepends to the traffic sent to the backends. Set it to PROXY_V1 for the backends to
+	// receive the original client IP address. The target proxy is updated in place when this
+	// value changes. If not set, NONE is used.
+	// +kubebuilder:validation:Enum=NONE;PROXY_V1
+	// +optional
+	ProxyHeader *ProxyHeader `json:"proxyHeader,omitempty"`
+
+	// ExternalLoadBalancingScheme is the load balancing scheme of the Global External Proxy Load
+	// Balancer. EXTERNAL creates a classic proxy Network Load Balancer, and EXTERNAL_MANAGED
+	// creates a global external proxy Network Load Balancer based on the Envoy proxies. The
+	// scheme is set on the backend service and forwarding rule, which must match, and the target
+	// proxy uses the scheme of its backend service. If not set, EXTERNAL is used.
+	// +kubebuilder:validation:Enum=EXTERNAL;EXTERNAL_MANAGED
+	// +optional
+	ExternalLoadBalancingScheme *LoadBalancingScheme `json:"externalLoadBalancingScheme,omitempty"`
 }
 
+// LoadBalancingScheme is the load balancing scheme of a Load Balancer.
+type LoadBalancingScheme string
+
+const (
+	// LoadBalancingSchemeExternal is the scheme of the classic external Load Balancers.
+	LoadBalancingSchemeExternal = LoadBalancingScheme("EXTERNAL")
+
+	// LoadBalancingSchemeExternalManaged is the scheme of the Envoy based external Load Balancers.
+	LoadBalancingSchemeExternalManaged = LoadBalancingScheme("EXTERNAL_MANAGED")
+)
+
+// ProxyHeader is the type of proxy header prepended by a target proxy.
+type ProxyHeader string
+
+const (
+	// ProxyHeaderNone does not prepend a proxy header.
+	ProxyHeaderNone = ProxyHeader("NONE")
+
+	// ProxyHeaderProxyV1 prepends a PROXY protocol version 1 header.
+	ProxyHeaderProxyV1 = ProxyHeader("PROXY_V1")
+)
+
 // SubnetSpec configures an GCP Subnet.
 type SubnetSpec struct {
 	// Name defines a unique identifier to reference this resource.
@@ -341,10 +527,59 @@ type ServiceAccount struct {
 	Email string `json:"email,omitempty"`
 
 	// Scopes: The list of scopes to be made available for this service
-	// account.
+	// account. Each scope is either an OAuth scope URL or one of the
+	// gcloud aliases, e.g. "cloud-platform" or "storage-ro".
 	Scopes []string `json:"scopes,omitempty"`
 }
 
+// ServiceAccountDefaultEmail is the email referring to the Compute Engine default service account.
+const ServiceAccountDefaultEmail = "default"
+
+// scopeURLPrefix is the prefix shared by the Google OAuth scope URLs.
+const scopeURLPrefix = "https://www.googleapis.com/auth/"
+
+// scopeAliases maps the gcloud scope aliases to their OAuth scope URL.
+// reference: https://cloud.google.com/sdk/gcloud/reference/compute/instances/create#--scopes
+var scopeAliases = map[string]string{
+	"bigquery":              scopeURLPrefix + "bigquery",
+	"cloud-platform":        scopeURLPrefix + "cloud-platform",
+	"cloud-source-repos":    scopeURLPrefix + "source.full_control",
+	"cloud-source-repos-ro": scopeURLPrefix + "source.read_only",
+	"compute-ro":            scopeURLPrefix + "compute.readonly",
+	"compute-rw":            scopeURLPrefix + "compute",
+	"datastore":             scopeURLPrefix + "datastore",
+	"logging-write":         scopeURLPrefix + "logging.write",
+	"monitoring":            scopeURLPrefix + "monitoring",
+	"monitoring-read":       scopeURLPrefix + "monitoring.read",
+	"monitoring-write":      scopeURLPrefix + "monitoring.write",
+	"pubsub":                scopeURLPrefix + "pubsub",
+	"service-control":       scopeURLPrefix + "servicecontrol",
+	"service-management":    scopeURLPrefix + "service.management.readonly",
+	"sql-admin":             scopeURLPrefix + "sqlservice.admin",
+	"storage-full":          scopeURLPrefix + "devstorage.full_control",
+	"storage-ro":            scopeURLPrefix + "devstorage.read_only",
+	"storage-rw":            scopeURLPrefix + "devstorage.read_write",
+	"taskqueue":             scopeURLPrefix + "taskqueue",
+	"trace":                 scopeURLPrefix + "trace.append",
+	"userinfo-email":        scopeURLPrefix + "userinfo.email",
+}
+
+// ScopeURLs returns the OAuth scope URLs of the service account, resolving the scope aliases.
+func (s *ServiceAccount) ScopeURLs() []string {
+	if s.Scopes == nil {
+		return nil
+	}
+
+	urls := make([]string, 0, len(s.Scopes))
+	for _, scope := range s.Scopes {
+		if url, ok := scopeAliases[scope]; ok {
+			scope = url
+		}
+		urls = append(urls, scope)
+	}
+	return urls
+}
+
 // ObjectReference is a reference to another Kubernetes object instance.
 type ObjectReference struct {
 	// Namespace of the referent.
@@ -370,5 +605,90 @@ type LoadBalancer struct {
 	// Subnet is the name of the subnet to use for a regional Load Balancer. A subnet is
 	// required for the Load Balancer, if not defined the first configured subnet will be
 	// used.
+	// The subnet must be in the region of the cluster, as the backends of the Load Balancer are.
 	Subnet *string `json:"subnet,omitempty"`
+
+	// InternalAccess defines the access for the Internal Passthrough Load Balancer.
+	// It determines whether the load balancer allows global level access or region level access.
+	// The forwarding rule is updated in place when this value changes.
+	// +kubebuilder:validation:Enum=Regional;Global
+	// +kubebuilder:default=Regional
+	// +optional
+	InternalAccess InternalAccess `json:"internalAccess,omitempty"`
+
+	// Ports are the ports exposed by the Internal Passthrough Load Balancer in addition to the
+	// API server port, such as 8132 for konnectivity. If not set, port 22623 used for the
+	// OpenShift ignition service is exposed. Set it to an empty list to only expose the API
+	// server port.
+	// +kubebuilder:validation:MaxItems=4
+	// +kubebuilder:validation:items:Minimum=1
+	// +kubebuilder:validation:items:Maximum=65535
+	// +optional
+	Ports []int32 `json:"ports,omitempty"`
+
+	// HealthCheck configures the timings of the health check of the Internal Passthrough Load
+	// Balancer. The defaults can be too aggressive for control planes that take a long time to
+	// restart.
+	// +optional
+	HealthCheck *HealthCheck `json:"healthCheck,omitempty"`
 }
+
+const (
+	// DefaultHealthCheckIntervalSec is the default interval between two Load Balancer health checks.
+	DefaultHealthCheckIntervalSec = int64(10)
+
+	// DefaultHealthCheckTimeoutSec is the default timeout of a Load Balancer health check.
+	DefaultHealthCheckTimeoutSec = int64(5)
+
+	// DefaultHealthCheckHealthyThreshold is the default number of successes to mark a backend healthy.
+	DefaultHealthCheckHealthyThreshold = int64(5)
+
+	// DefaultHealthCheckUnhealthyThreshold is the default number of failures to mark a backend unhealthy.
+	DefaultHealthCheckUnhealthyThreshold = int64(3)
+)
+
+// HealthCheck configures the timings of a Load Balancer health check. Unset fields use the
+// defaults of 10s interval, 5s timeout, 5 healthy and 3 unhealthy consecutive probes.
+type HealthCheck struct {
+	// CheckIntervalSec is how often, in seconds, to send a health check.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=300
+	// +optional
+	CheckIntervalSec *int64 `json:"checkIntervalSec,omitempty"`
+
+	// TimeoutSec is how long, in seconds, to wait before claiming a health check failure.
+	// It must not be greater than CheckIntervalSec.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=300
+	// +optional
+	TimeoutSec *int64 `json:"timeoutSec,omitempty"`
+
+	// HealthyThreshold is the number of consecutive successes needed for an unhealthy
+	// backend to be marked healthy.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	HealthyThreshold *int64 `json:"healthyThreshold,omitempty"`
+
+	// UnhealthyThreshold is the number of consecutive failures needed for a healthy
+	// backend to be marked unhealthy.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	UnhealthyThreshold *int64 `json:"unhealthyThreshold,omitempty"`
+}
+
+// InternalAccess defines the access for the Internal Passthrough Load Balancer.
+type InternalAccess string
+
+const (
+	// InternalAccessRegional restricts access to clients in the same region as the load balancer.
+	InternalAccessRegional = InternalAccess("Regional")
+
+	// InternalAccessGlobal allows access from clients in any region.
+	InternalAccessGlobal = InternalAccess("Global")
+)
+
+// DefaultInternalLoadBalancerPorts are the ports exposed by the Internal Passthrough Load Balancer in
+// addition to the API server port when none are configured.
+var DefaultInternalLoadBalancerPorts = []int32{22623}
@@ -21,11 +21,52 @@ limitations under the License.
 package v1beta1
 
 import (
-	"k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdvancedMachineFeatures) DeepCopyInto(out *AdvancedMachineFeatures) {
+	*out = *in
+	if in.ThreadsPerCore != nil {
+		in, out := &in.ThreadsPerCore, &out.ThreadsPerCore
+		*out = new(int64)
+		**out = **in
+	}
+	if in.VisibleCoreCount != nil {
+		in, out := &in.VisibleCoreCount, &out.VisibleCoreCount
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdvancedMachineFeatures.
+func (in *AdvancedMachineFeatures) DeepCopy() *AdvancedMachineFeatures {
+	if in == nil {
+		return nil
+	}
+	out := new(AdvancedMachineFeatures)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AliasIPRange) DeepCopyInto(out *AliasIPRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AliasIPRange.
+func (in *AliasIPRange) DeepCopy() *AliasIPRange {
+	if in == nil {
+		return nil
+	}
+	out := new(AliasIPRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AttachedDiskSpec) DeepCopyInto(out *AttachedDiskSpec) {
 	*out = *in
@@ -44,6 +85,16 @@ func (in *AttachedDiskSpec) DeepCopyInto(out *AttachedDiskSpec) {
 		*out = new(CustomerEncryptionKey)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ProvisionedIops != nil {
+		in, out := &in.ProvisionedIops, &out.ProvisionedIops
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ProvisionedThroughput != nil {
+		in, out := &in.ProvisionedThroughput, &out.ProvisionedThroughput
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttachedDiskSpec.
@@ -56,6 +107,26 @@ func (in *AttachedDiskSpec) DeepCopy() *AttachedDiskSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapDataStorage) DeepCopyInto(out *BootstrapDataStorage) {
+	*out = *in
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapDataStorage.
+func (in *BootstrapDataStorage) DeepCopy() *BootstrapDataStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapDataStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildParams) DeepCopyInto(out *BuildParams) {
 	*out = *in
@@ -83,6 +154,41 @@ func (in *BuildParams) DeepCopy() *BuildParams {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudRouterAdvertisedIPRange) DeepCopyInto(out *CloudRouterAdvertisedIPRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudRouterAdvertisedIPRange.
+func (in *CloudRouterAdvertisedIPRange) DeepCopy() *CloudRouterAdvertisedIPRange {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudRouterAdvertisedIPRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudRouterSpec) DeepCopyInto(out *CloudRouterSpec) {
+	*out = *in
+	if in.AdvertisedIPRanges != nil {
+		in, out := &in.AdvertisedIPRanges, &out.AdvertisedIPRanges
+		*out = make([]CloudRouterAdvertisedIPRange, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudRouterSpec.
+func (in *CloudRouterSpec) DeepCopy() *CloudRouterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudRouterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomerEncryptionKey) DeepCopyInto(out *CustomerEncryptionKey) {
 	*out = *in
@@ -113,6 +219,21 @@ func (in *CustomerEncryptionKey) DeepCopy() *CustomerEncryptionKey {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSSpec) DeepCopyInto(out *DNSSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSpec.
+func (in *DNSSpec) DeepCopy() *DNSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Filter) DeepCopyInto(out *Filter) {
 	*out = *in
@@ -133,6 +254,93 @@ func (in *Filter) DeepCopy() *Filter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallLogConfig) DeepCopyInto(out *FirewallLogConfig) {
+	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(FirewallLogMetadata)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallLogConfig.
+func (in *FirewallLogConfig) DeepCopy() *FirewallLogConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallLogConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallRule) DeepCopyInto(out *FirewallRule) {
+	*out = *in
+	if in.Protocols != nil {
+		in, out := &in.Protocols, &out.Protocols
+		*out = make([]FirewallRuleProtocol, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SourceRanges != nil {
+		in, out := &in.SourceRanges, &out.SourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DestinationRanges != nil {
+		in, out := &in.DestinationRanges, &out.DestinationRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TargetTags != nil {
+		in, out := &in.TargetTags, &out.TargetTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LogConfig != nil {
+		in, out := &in.LogConfig, &out.LogConfig
+		*out = new(FirewallLogConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallRule.
+func (in *FirewallRule) DeepCopy() *FirewallRule {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallRuleProtocol) DeepCopyInto(out *FirewallRuleProtocol) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallRuleProtocol.
+func (in *FirewallRuleProtocol) DeepCopy() *FirewallRuleProtocol {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallRuleProtocol)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCPCluster) DeepCopyInto(out *GCPCluster) {
 	*out = *in
@@ -225,6 +433,16 @@ func (in *GCPClusterSpec) DeepCopyInto(out *GCPClusterSpec) {
 		*out = new(ServiceEndpoints)
 		**out = **in
 	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(DNSSpec)
+		**out = **in
+	}
+	if in.CloudRouter != nil {
+		in, out := &in.CloudRouter, &out.CloudRouter
+		*out = new(CloudRouterSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterSpec.
@@ -248,6 +466,13 @@ func (in *GCPClusterStatus) DeepCopyInto(out *GCPClusterStatus) {
 		}
 	}
 	in.Network.DeepCopyInto(&out.Network)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(apiv1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterStatus.
@@ -418,6 +643,26 @@ func (in *GCPMachineSpec) DeepCopyInto(out *GCPMachineSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.AliasIPRanges != nil {
+		in, out := &in.AliasIPRanges, &out.AliasIPRanges
+		*out = make([]AliasIPRange, len(*in))
+		copy(*out, *in)
+	}
+	if in.Zone != nil {
+		in, out := &in.Zone, &out.Zone
+		*out = new(string)
+		**out = **in
+	}
+	if in.InstanceNameTemplate != nil {
+		in, out := &in.InstanceNameTemplate, &out.InstanceNameTemplate
+		*out = new(string)
+		**out = **in
+	}
+	if in.Hostname != nil {
+		in, out := &in.Hostname, &out.Hostname
+		*out = new(string)
+		**out = **in
+	}
 	if in.ProviderID != nil {
 		in, out := &in.ProviderID, &out.ProviderID
 		*out = new(string)
@@ -428,6 +673,11 @@ func (in *GCPMachineSpec) DeepCopyInto(out *GCPMachineSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.RequiredGuestOSFeatures != nil {
+		in, out := &in.RequiredGuestOSFeatures, &out.RequiredGuestOSFeatures
+		*out = make([]GuestOSFeature, len(*in))
+		copy(*out, *in)
+	}
 	if in.Image != nil {
 		in, out := &in.Image, &out.Image
 		*out = new(string)
@@ -447,6 +697,36 @@ func (in *GCPMachineSpec) DeepCopyInto(out *GCPMachineSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.EnableOSLogin != nil {
+		in, out := &in.EnableOSLogin, &out.EnableOSLogin
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BlockProjectSSHKeys != nil {
+		in, out := &in.BlockProjectSSHKeys, &out.BlockProjectSSHKeys
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableSerialPortLogging != nil {
+		in, out := &in.EnableSerialPortLogging, &out.EnableSerialPortLogging
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StartupScript != nil {
+		in, out := &in.StartupScript, &out.StartupScript
+		*out = new(MachineScript)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ShutdownScript != nil {
+		in, out := &in.ShutdownScript, &out.ShutdownScript
+		*out = new(MachineScript)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BootstrapDataStorage != nil {
+		in, out := &in.BootstrapDataStorage, &out.BootstrapDataStorage
+		*out = new(BootstrapDataStorage)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PublicIP != nil {
 		in, out := &in.PublicIP, &out.PublicIP
 		*out = new(bool)
@@ -467,6 +747,21 @@ func (in *GCPMachineSpec) DeepCopyInto(out *GCPMachineSpec) {
 		*out = new(DiskType)
 		**out = **in
 	}
+	if in.RootDeviceProvisionedIops != nil {
+		in, out := &in.RootDeviceProvisionedIops, &out.RootDeviceProvisionedIops
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RootDeviceProvisionedThroughput != nil {
+		in, out := &in.RootDeviceProvisionedThroughput, &out.RootDeviceProvisionedThroughput
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DiskResourcePolicies != nil {
+		in, out := &in.DiskResourcePolicies, &out.DiskResourcePolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.AdditionalDisks != nil {
 		in, out := &in.AdditionalDisks, &out.AdditionalDisks
 		*out = make([]AttachedDiskSpec, len(*in))
@@ -484,6 +779,16 @@ func (in *GCPMachineSpec) DeepCopyInto(out *GCPMachineSpec) {
 		*out = new(ProvisioningModel)
 		**out = **in
 	}
+	if in.MaxRunDuration != nil {
+		in, out := &in.MaxRunDuration, &out.MaxRunDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.InstanceTerminationAction != nil {
+		in, out := &in.InstanceTerminationAction, &out.InstanceTerminationAction
+		*out = new(InstanceTerminationAction)
+		**out = **in
+	}
 	if in.IPForwarding != nil {
 		in, out := &in.IPForwarding, &out.IPForwarding
 		*out = new(IPForwarding)
@@ -499,11 +804,31 @@ func (in *GCPMachineSpec) DeepCopyInto(out *GCPMachineSpec) {
 		*out = new(HostMaintenancePolicy)
 		**out = **in
 	}
+	if in.AutomaticRestart != nil {
+		in, out := &in.AutomaticRestart, &out.AutomaticRestart
+		*out = new(bool)
+		**out = **in
+	}
 	if in.ConfidentialCompute != nil {
 		in, out := &in.ConfidentialCompute, &out.ConfidentialCompute
 		*out = new(ConfidentialComputePolicy)
 		**out = **in
 	}
+	if in.AdvancedMachineFeatures != nil {
+		in, out := &in.AdvancedMachineFeatures, &out.AdvancedMachineFeatures
+		*out = new(AdvancedMachineFeatures)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPerformanceConfig != nil {
+		in, out := &in.NetworkPerformanceConfig, &out.NetworkPerformanceConfig
+		*out = new(NetworkPerformanceConfig)
+		**out = **in
+	}
+	if in.MinCPUPlatform != nil {
+		in, out := &in.MinCPUPlatform, &out.MinCPUPlatform
+		*out = new(string)
+		**out = **in
+	}
 	if in.RootDiskEncryptionKey != nil {
 		in, out := &in.RootDiskEncryptionKey, &out.RootDiskEncryptionKey
 		*out = new(CustomerEncryptionKey)
@@ -526,7 +851,7 @@ func (in *GCPMachineStatus) DeepCopyInto(out *GCPMachineStatus) {
 	*out = *in
 	if in.Addresses != nil {
 		in, out := &in.Addresses, &out.Addresses
-		*out = make([]v1.NodeAddress, len(*in))
+		*out = make([]corev1.NodeAddress, len(*in))
 		copy(*out, *in)
 	}
 	if in.InstanceStatus != nil {
@@ -662,6 +987,56 @@ func (in *GCPShieldedInstanceConfig) DeepCopy() *GCPShieldedInstanceConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
+	*out = *in
+	if in.CheckIntervalSec != nil {
+		in, out := &in.CheckIntervalSec, &out.CheckIntervalSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TimeoutSec != nil {
+		in, out := &in.TimeoutSec, &out.TimeoutSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HealthyThreshold != nil {
+		in, out := &in.HealthyThreshold, &out.HealthyThreshold
+		*out = new(int64)
+		**out = **in
+	}
+	if in.UnhealthyThreshold != nil {
+		in, out := &in.UnhealthyThreshold, &out.UnhealthyThreshold
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheck.
+func (in *HealthCheck) DeepCopy() *HealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceNameParams) DeepCopyInto(out *InstanceNameParams) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceNameParams.
+func (in *InstanceNameParams) DeepCopy() *InstanceNameParams {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceNameParams)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in Labels) DeepCopyInto(out *Labels) {
 	{
@@ -696,6 +1071,16 @@ func (in *LoadBalancer) DeepCopyInto(out *LoadBalancer) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(HealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancer.
@@ -716,6 +1101,11 @@ func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.APIServerInstanceGroupNameSuffix != nil {
+		in, out := &in.APIServerInstanceGroupNameSuffix, &out.APIServerInstanceGroupNameSuffix
+		*out = new(string)
+		**out = **in
+	}
 	if in.LoadBalancerType != nil {
 		in, out := &in.LoadBalancerType, &out.LoadBalancerType
 		*out = new(LoadBalancerType)
@@ -726,6 +1116,16 @@ func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
 		*out = new(LoadBalancer)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ProxyHeader != nil {
+		in, out := &in.ProxyHeader, &out.ProxyHeader
+		*out = new(ProxyHeader)
+		**out = **in
+	}
+	if in.ExternalLoadBalancingScheme != nil {
+		in, out := &in.ExternalLoadBalancingScheme, &out.ExternalLoadBalancingScheme
+		*out = new(LoadBalancingScheme)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerSpec.
@@ -738,6 +1138,31 @@ func (in *LoadBalancerSpec) DeepCopy() *LoadBalancerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineScript) DeepCopyInto(out *MachineScript) {
+	*out = *in
+	if in.Content != nil {
+		in, out := &in.Content, &out.Content
+		*out = new(string)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretKeyReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineScript.
+func (in *MachineScript) DeepCopy() *MachineScript {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineScript)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagedKey) DeepCopyInto(out *ManagedKey) {
 	*out = *in
@@ -793,6 +1218,11 @@ func (in *Network) DeepCopyInto(out *Network) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.CloudRouter != nil {
+		in, out := &in.CloudRouter, &out.CloudRouter
+		*out = new(string)
+		**out = **in
+	}
 	if in.APIServerAddress != nil {
 		in, out := &in.APIServerAddress, &out.APIServerAddress
 		*out = new(string)
@@ -857,6 +1287,21 @@ func (in *Network) DeepCopy() *Network {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPerformanceConfig) DeepCopyInto(out *NetworkPerformanceConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPerformanceConfig.
+func (in *NetworkPerformanceConfig) DeepCopy() *NetworkPerformanceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPerformanceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 	*out = *in
@@ -887,6 +1332,23 @@ func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.NodePortFirewall != nil {
+		in, out := &in.NodePortFirewall, &out.NodePortFirewall
+		*out = new(NodePortFirewallSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalFirewallRules != nil {
+		in, out := &in.AdditionalFirewallRules, &out.AdditionalFirewallRules
+		*out = make([]FirewallRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FirewallLogConfig != nil {
+		in, out := &in.FirewallLogConfig, &out.FirewallLogConfig
+		*out = new(FirewallLogConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
@@ -899,6 +1361,26 @@ func (in *NetworkSpec) DeepCopy() *NetworkSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePortFirewallSpec) DeepCopyInto(out *NodePortFirewallSpec) {
+	*out = *in
+	if in.SourceRanges != nil {
+		in, out := &in.SourceRanges, &out.SourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePortFirewallSpec.
+func (in *NodePortFirewallSpec) DeepCopy() *NodePortFirewallSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePortFirewallSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
 	*out = *in
@@ -969,6 +1451,21 @@ func (in ResourceManagerTagsMap) DeepCopy() ResourceManagerTagsMap {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyReference) DeepCopyInto(out *SecretKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyReference.
+func (in *SecretKeyReference) DeepCopy() *SecretKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceAccount) DeepCopyInto(out *ServiceAccount) {
 	*out = *in
@@ -18,11 +18,24 @@ limitations under the License.
 package gcperrors
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
 	"google.golang.org/api/googleapi"
 )
 
+// quotaExceededReason is the reason of the Google API errors reporting an exhausted quota.
+const quotaExceededReason = "quotaExceeded"
+
+// transientReasons are the reasons of the Google API errors which are resolved by retrying the request, despite
+// their bad request or forbidden code.
+var transientReasons = map[string]bool{
+	"rateLimitExceeded":     true,
+	"userRateLimitExceeded": true,
+	"resourceNotReady":      true,
+}
+
 // IsNotFound reports whether err is a Google API error
 // with http.StatusNotFround.
 func IsNotFound(err error) bool {
@@ -43,3 +56,45 @@ func IgnoreNotFound(err error) error {
 
 	return err
 }
+
+// IsQuotaExceeded reports whether err is a Google API error
+// reporting an exhausted quota.
+func IsQuotaExceeded(err error) bool {
+	var ae *googleapi.Error
+	if !errors.As(err, &ae) {
+		return false
+	}
+
+	for _, item := range ae.Errors {
+		if item.Reason == quotaExceededReason {
+			return true
+		}
+	}
+
+	// Failed operations only carry the error code in their message.
+	return strings.Contains(ae.Message, "QUOTA_EXCEEDED")
+}
+
+// IsTerminal reports whether err is a Google API error that
+// retrying the request won't resolve, e.g. an invalid request,
+// a denied permission or an exhausted quota. Rate limited
+// requests and resources which are not ready yet are not terminal.
+func IsTerminal(err error) bool {
+	var ae *googleapi.Error
+	if !errors.As(err, &ae) {
+		return false
+	}
+
+	for _, item := range ae.Errors {
+		if transientReasons[item.Reason] {
+			return false
+		}
+	}
+
+	switch ae.Code {
+	case http.StatusBadRequest, http.StatusForbidden:
+		return true
+	default:
+		return IsQuotaExceeded(err)
+	}
+}
@@ -22,6 +22,8 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/storage/v1"
 	corev1 "k8s.io/api/core/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -30,6 +32,9 @@ import (
 // Cloud alias for cloud.Cloud interface.
 type Cloud = cloud.Cloud
 
+// RateLimiter alias for cloud.RateLimiter interface.
+type RateLimiter = cloud.RateLimiter
+
 // Reconciler is a generic interface used by components offering a type of service.
 type Reconciler interface {
 	Reconcile(ctx context.Context) error
@@ -48,9 +53,21 @@ type Client interface {
 	NetworkCloud() Cloud
 }
 
+// DryRunner is an interface which can tell whether the changes to GCP resources are only reported.
+type DryRunner interface {
+	DryRun() bool
+	RecordDryRun(action, kind, name string)
+}
+
 // ClusterGetter is an interface which can get cluster information.
 type ClusterGetter interface {
 	Client
+	DryRunner
+	ComputeService() *compute.Service
+	RateLimiter() RateLimiter
+	StorageService(ctx context.Context) (*storage.Service, error)
+	CredentialsServiceAccount(ctx context.Context) (string, error)
+	SignBlob(ctx context.Context, serviceAccount string, payload []byte) ([]byte, error)
 	Project() string
 	Region() string
 	Name() string
@@ -80,8 +97,10 @@ type Cluster interface {
 // MachineGetter is an interface which can get machine information.
 type MachineGetter interface {
 	Client
+	DryRunner
 	Name() string
 	Namespace() string
+	InstanceName() (string, error)
 	Zone() string
 	Project() string
 	Role() string
@@ -90,12 +109,14 @@ type MachineGetter interface {
 	GetInstanceID() *string
 	GetProviderID() string
 	GetBootstrapData() (string, error)
+	GetStartupScript() (string, error)
+	GetShutdownScript() (string, error)
 	GetInstanceStatus() *infrav1.InstanceStatus
 }
 
 // MachineSetter is an interface which can set machine information.
 type MachineSetter interface {
-	SetProviderID()
+	SetProviderID() error
 	SetInstanceStatus(v infrav1.InstanceStatus)
 	SetFailureMessage(v error)
 	SetFailureReason(v string)
@@ -19,16 +19,22 @@ package scope
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 
 	computerest "cloud.google.com/go/compute/apiv1"
 	container "cloud.google.com/go/container/apiv1"
 	credentials "cloud.google.com/go/iam/credentials/apiv1"
+	"cloud.google.com/go/iam/credentials/apiv1/credentialspb"
 	resourcemanager "cloud.google.com/go/resourcemanager/apiv3"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/pkg/errors"
 	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/dns/v1"
 	"google.golang.org/api/option"
+	"google.golang.org/api/storage/v1"
+	htransport "google.golang.org/api/transport/http"
+	"google.golang.org/grpc"
 	"k8s.io/client-go/pkg/version"
 	"k8s.io/client-go/util/flowcontrol"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
@@ -38,6 +44,8 @@ import (
 // GCPServices contains all the gcp services used by the scopes.
 type GCPServices struct {
 	Compute *compute.Service
+	DNS     *dns.Service
+	Storage *storage.Service
 }
 
 // GCPRateLimiter implements cloud.RateLimiter.
@@ -60,16 +68,16 @@ func (rl *GCPRateLimiter) Accept(ctx context.Context, key *cloud.RateLimitKey) e
 	return nil
 }
 
-// Observe does nothing.
+// Observe does nothing, the metrics of the calls are recorded by the HTTP client of the compute service.
 func (rl *GCPRateLimiter) Observe(context.Context, error, *cloud.RateLimitKey) {
 	// noop
 }
 
-func newCloud(project string, service GCPServices) cloud.Cloud {
+func newCloud(project string, service GCPServices, rateLimiter cloud.RateLimiter) cloud.Cloud {
 	return cloud.NewGCE(&cloud.Service{
 		GA:            service.Compute,
 		ProjectRouter: &cloud.SingleProjectRouter{ID: project},
-		RateLimiter:   &GCPRateLimiter{},
+		RateLimiter:   rateLimiter,
 	})
 }
 
@@ -89,6 +97,23 @@ func defaultClientOptions(ctx context.Context, credentialsRef *infrav1.ObjectRef
 	return opts, nil
 }
 
+// grpcMetricsOption returns the client option recording the metrics of the gRPC based clients.
+func grpcMetricsOption() option.ClientOption {
+	return option.WithGRPCDialOption(grpc.WithChainUnaryInterceptor(metricsUnaryClientInterceptor))
+}
+
+// httpMetricsOption returns the client option recording the metrics of the REST based clients. The REST clients
+// do not accept a wrapped transport, so the authenticated HTTP client is built from the other options of the client.
+func httpMetricsOption(ctx context.Context, opts []option.ClientOption) (option.ClientOption, error) {
+	opts = append(opts, option.WithScopes(compute.CloudPlatformScope))
+	transport, err := htransport.NewTransport(ctx, http.DefaultTransport, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating gcp http transport: %w", err)
+	}
+
+	return option.WithHTTPClient(&http.Client{Transport: &metricsRoundTripper{next: transport}}), nil
+}
+
 func newComputeService(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client, endpoints *infrav1.ServiceEndpoints) (*compute.Service, error) {
 	opts, err := defaultClientOptions(ctx, credentialsRef, crClient)
 	if err != nil {
@@ -99,6 +124,12 @@ func newComputeService(ctx context.Context, credentialsRef *infrav1.ObjectRefere
 		opts = append(opts, option.WithEndpoint(endpoints.ComputeServiceEndpoint))
 	}
 
+	metricsOpt, err := httpMetricsOption(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, metricsOpt)
+
 	computeSvc, err := compute.NewService(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("creating new compute service instance: %w", err)
@@ -107,6 +138,54 @@ func newComputeService(ctx context.Context, credentialsRef *infrav1.ObjectRefere
 	return computeSvc, nil
 }
 
+func newDNSService(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client, endpoints *infrav1.ServiceEndpoints) (*dns.Service, error) {
+	opts, err := defaultClientOptions(ctx, credentialsRef, crClient)
+	if err != nil {
+		return nil, fmt.Errorf("getting default gcp client options: %w", err)
+	}
+
+	if endpoints != nil && endpoints.DNSServiceEndpoint != "" {
+		opts = append(opts, option.WithEndpoint(endpoints.DNSServiceEndpoint))
+	}
+
+	metricsOpt, err := httpMetricsOption(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, metricsOpt)
+
+	dnsSvc, err := dns.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating new dns service instance: %w", err)
+	}
+
+	return dnsSvc, nil
+}
+
+func newStorageService(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client, endpoints *infrav1.ServiceEndpoints) (*storage.Service, error) {
+	opts, err := defaultClientOptions(ctx, credentialsRef, crClient)
+	if err != nil {
+		return nil, fmt.Errorf("getting default gcp client options: %w", err)
+	}
+
+	if endpoints != nil && endpoints.StorageServiceEndpoint != "" {
+		opts = append(opts, option.WithEndpoint(endpoints.StorageServiceEndpoint))
+	}
+
+	metricsOpt, err := httpMetricsOption(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, metricsOpt)
+
+	storageSvc, err := storage.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating new storage service instance: %w", err)
+	}
+
+	return storageSvc, nil
+}
+
 func newClusterManagerClient(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client, endpoints *infrav1.ServiceEndpoints) (*container.ClusterManagerClient, error) {
 	opts, err := defaultClientOptions(ctx, credentialsRef, crClient)
 	if err != nil {
@@ -117,6 +196,8 @@ func newClusterManagerClient(ctx context.Context, credentialsRef *infrav1.Object
 		opts = append(opts, option.WithEndpoint(endpoints.ContainerServiceEndpoint))
 	}
 
+	opts = append(opts, grpcMetricsOption())
+
 	managedClusterClient, err := container.NewClusterManagerClient(ctx, opts...)
 	if err != nil {
 		return nil, errors.Errorf("failed to create gcp cluster manager client: %v", err)
@@ -135,6 +216,8 @@ func newIamCredentialsClient(ctx context.Context, credentialsRef *infrav1.Object
 		opts = append(opts, option.WithEndpoint(endpoints.IAMServiceEndpoint))
 	}
 
+	opts = append(opts, grpcMetricsOption())
+
 	credentialsClient, err := credentials.NewIamCredentialsClient(ctx, opts...)
 	if err != nil {
 		return nil, errors.Errorf("failed to create gcp ciam credentials client: %v", err)
@@ -143,6 +226,38 @@ func newIamCredentialsClient(ctx context.Context, credentialsRef *infrav1.Object
 	return credentialsClient, nil
 }
 
+// credentialsServiceAccount returns the email of the service account of the credentials.
+func credentialsServiceAccount(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client) (string, error) {
+	credential, err := getCredentials(ctx, credentialsRef, crClient)
+	if err != nil {
+		return "", fmt.Errorf("getting gcp credentials: %w", err)
+	}
+	if credential.ClientEmail == "" {
+		return "", errors.New("gcp credentials are not the ones of a service account")
+	}
+
+	return credential.ClientEmail, nil
+}
+
+// signBlob signs the payload with the given service account through the IAM credentials API.
+func signBlob(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client, endpoints *infrav1.ServiceEndpoints, serviceAccount string, payload []byte) ([]byte, error) {
+	credentialsClient, err := newIamCredentialsClient(ctx, credentialsRef, crClient, endpoints)
+	if err != nil {
+		return nil, err
+	}
+	defer credentialsClient.Close()
+
+	resp, err := credentialsClient.SignBlob(ctx, &credentialspb.SignBlobRequest{
+		Name:    "projects/-/serviceAccounts/" + serviceAccount,
+		Payload: payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("signing blob with service account %s: %w", serviceAccount, err)
+	}
+
+	return resp.GetSignedBlob(), nil
+}
+
 func newInstanceGroupManagerClient(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client, endpoints *infrav1.ServiceEndpoints) (*computerest.InstanceGroupManagersClient, error) {
 	opts, err := defaultClientOptions(ctx, credentialsRef, crClient)
 	if err != nil {
@@ -153,6 +268,12 @@ func newInstanceGroupManagerClient(ctx context.Context, credentialsRef *infrav1.
 		opts = append(opts, option.WithEndpoint(endpoints.ComputeServiceEndpoint))
 	}
 
+	metricsOpt, err := httpMetricsOption(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, metricsOpt)
+
 	instanceGroupManagersClient, err := computerest.NewInstanceGroupManagersRESTClient(ctx, opts...)
 	if err != nil {
 		return nil, errors.Errorf("failed to create gcp instance group managers rest client: %v", err)
@@ -175,6 +296,8 @@ func newTagBindingsClient(ctx context.Context, credentialsRef *infrav1.ObjectRef
 		return nil, fmt.Errorf("getting default gcp client options: %w", err)
 	}
 
+	opts = append(opts, grpcMetricsOption())
+
 	client, err := resourcemanager.NewTagBindingsClient(ctx, opts...)
 	if err != nil {
 		return nil, errors.Errorf("failed to create gcp tag binding client: %v", err)
@@ -24,10 +24,13 @@ import (
 
 	"github.com/pkg/errors"
 	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/dns/v1"
+	"google.golang.org/api/storage/v1"
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -38,6 +41,8 @@ type ClusterScopeParams struct {
 	Client     client.Client
 	Cluster    *clusterv1.Cluster
 	GCPCluster *infrav1.GCPCluster
+	// DryRun makes the changes to GCP resources reported without being applied.
+	DryRun bool
 }
 
 // NewClusterScope creates a new Scope from the supplied parameters.
@@ -59,6 +64,15 @@ func NewClusterScope(ctx context.Context, params ClusterScopeParams) (*ClusterSc
 		params.GCPServices.Compute = computeSvc
 	}
 
+	if params.GCPServices.DNS == nil && params.GCPCluster.Spec.DNS != nil {
+		dnsSvc, err := newDNSService(ctx, params.GCPCluster.Spec.CredentialsRef, params.Client, params.GCPCluster.Spec.ServiceEndpoints)
+		if err != nil {
+			return nil, errors.Errorf("failed to create gcp dns client: %v", err)
+		}
+
+		params.GCPServices.DNS = dnsSvc
+	}
+
 	helper, err := patch.NewHelper(params.GCPCluster, params.Client)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to init patch helper")
@@ -70,6 +84,8 @@ func NewClusterScope(ctx context.Context, params ClusterScopeParams) (*ClusterSc
 		GCPCluster:  params.GCPCluster,
 		GCPServices: params.GCPServices,
 		patchHelper: helper,
+		dryRun:      params.DryRun,
+		rateLimiter: &GCPRateLimiter{},
 	}, nil
 }
 
@@ -77,6 +93,8 @@ func NewClusterScope(ctx context.Context, params ClusterScopeParams) (*ClusterSc
 type ClusterScope struct {
 	client      client.Client
 	patchHelper *patch.Helper
+	dryRun      bool
+	rateLimiter cloud.RateLimiter
 
 	Cluster    *clusterv1.Cluster
 	GCPCluster *infrav1.GCPCluster
@@ -87,12 +105,46 @@ type ClusterScope struct {
 
 // Cloud returns initialized cloud.
 func (s *ClusterScope) Cloud() cloud.Cloud {
-	return newCloud(s.Project(), s.GCPServices)
+	return newCloud(s.Project(), s.GCPServices, s.rateLimiter)
 }
 
 // NetworkCloud returns initialized cloud.
 func (s *ClusterScope) NetworkCloud() cloud.Cloud {
-	return newCloud(s.NetworkProject(), s.GCPServices)
+	return newCloud(s.NetworkProject(), s.GCPServices, s.rateLimiter)
+}
+
+// ComputeService returns the compute API client, for the calls not covered by Cloud.
+func (s *ClusterScope) ComputeService() *compute.Service {
+	return s.Compute
+}
+
+// RateLimiter returns the rate limiter of Cloud, for the calls made with ComputeService.
+func (s *ClusterScope) RateLimiter() cloud.RateLimiter {
+	return s.rateLimiter
+}
+
+// StorageService returns the storage API client, which is only created when first needed.
+func (s *ClusterScope) StorageService(ctx context.Context) (*storage.Service, error) {
+	if s.Storage == nil {
+		storageSvc, err := newStorageService(ctx, s.GCPCluster.Spec.CredentialsRef, s.client, s.GCPCluster.Spec.ServiceEndpoints)
+		if err != nil {
+			return nil, errors.Errorf("failed to create gcp storage client: %v", err)
+		}
+
+		s.Storage = storageSvc
+	}
+
+	return s.Storage, nil
+}
+
+// CredentialsServiceAccount returns the email of the service account of the cluster credentials.
+func (s *ClusterScope) CredentialsServiceAccount(ctx context.Context) (string, error) {
+	return credentialsServiceAccount(ctx, s.GCPCluster.Spec.CredentialsRef, s.client)
+}
+
+// SignBlob signs the payload with the given service account.
+func (s *ClusterScope) SignBlob(ctx context.Context, serviceAccount string, payload []byte) ([]byte, error) {
+	return signBlob(ctx, s.GCPCluster.Spec.CredentialsRef, s.client, s.GCPCluster.Spec.ServiceEndpoints, serviceAccount, payload)
 }
 
 // Project returns the current project name.
@@ -190,6 +242,21 @@ func (s *ClusterScope) FailureDomains() clusterv1.FailureDomains {
 	return s.GCPCluster.Status.FailureDomains
 }
 
+// ConditionSetter returns a condition setter (which is GCPCluster itself).
+func (s *ClusterScope) ConditionSetter() conditions.Setter {
+	return s.GCPCluster
+}
+
+// DryRun returns true if the changes to GCP resources are reported without being applied.
+func (s *ClusterScope) DryRun() bool {
+	return s.dryRun || hasDryRunAnnotation(s.GCPCluster)
+}
+
+// RecordDryRun records a change to a GCP resource which is skipped in dry-run mode.
+func (s *ClusterScope) RecordDryRun(action, kind, name string) {
+	recordDryRun(s.GCPCluster, action, kind, name)
+}
+
 // ANCHOR_END: ClusterGetter
 
 // ANCHOR: ClusterSetter
@@ -242,6 +309,40 @@ func (s *ClusterScope) NatRouterSpec() *compute.Router {
 	}
 }
 
+// CloudRouterEnabled returns true if a Cloud Router with BGP is configured for hybrid connectivity.
+func (s *ClusterScope) CloudRouterEnabled() bool {
+	return s.GCPCluster.Spec.CloudRouter != nil
+}
+
+// CloudRouterSpec returns google compute router spec used for hybrid connectivity.
+func (s *ClusterScope) CloudRouterSpec() *compute.Router {
+	bgp := &compute.RouterBgp{
+		AdvertiseMode: "DEFAULT",
+	}
+	if s.CloudRouterEnabled() {
+		bgp.Asn = s.GCPCluster.Spec.CloudRouter.ASN
+		if ranges := s.GCPCluster.Spec.CloudRouter.AdvertisedIPRanges; len(ranges) > 0 {
+			// Custom advertisements replace the default ones, so the subnets of the network must
+			// be advertised explicitly.
+			bgp.AdvertiseMode = "CUSTOM"
+			bgp.AdvertisedGroups = []string{"ALL_SUBNETS"}
+			for _, r := range ranges {
+				bgp.AdvertisedIpRanges = append(bgp.AdvertisedIpRanges, &compute.RouterAdvertisedIpRange{
+					Range:       r.Range,
+					Description: r.Description,
+				})
+			}
+		}
+	}
+
+	return &compute.Router{
+		Name:        fmt.Sprintf("%s-%s", s.Name(), "bgp-router"),
+		Description: infrav1.ClusterTagKey(s.Name()),
+		Network:     s.NetworkLink(),
+		Bgp:         bgp,
+	}
+}
+
 // ANCHOR_END: ClusterNetworkSpec
 
 // SubnetSpecs returns google compute subnets spec.
@@ -272,6 +373,9 @@ func (s *ClusterScope) SubnetSpecs() []*compute.Subnetwork {
 
 // ANCHOR: ClusterFirewallSpec
 
+// nodePortRange is the default NodePort range of Kubernetes services.
+const nodePortRange = "30000-32767"
+
 // FirewallRulesSpec returns google compute firewall spec.
 func (s *ClusterScope) FirewallRulesSpec() []*compute.Firewall {
 	firewallRules := []*compute.Firewall{
@@ -315,9 +419,113 @@ func (s *ClusterScope) FirewallRulesSpec() []*compute.Firewall {
 		},
 	}
 
+	if s.NodePortFirewallEnabled() {
+		firewallRules = append(firewallRules, s.NodePortFirewallRuleSpec())
+	}
+
+	logConfig := firewallLogConfig(s.GCPCluster.Spec.Network.FirewallLogConfig)
+	for _, firewallRule := range firewallRules {
+		firewallRule.LogConfig = logConfig
+	}
+
+	return firewallRules
+}
+
+// firewallLogConfig returns google compute firewall log config, or nil when logging is not configured.
+func firewallLogConfig(logConfig *infrav1.FirewallLogConfig) *compute.FirewallLogConfig {
+	if logConfig == nil {
+		return nil
+	}
+
+	if !logConfig.Enabled {
+		return &compute.FirewallLogConfig{
+			Enable:          false,
+			ForceSendFields: []string{"Enable"},
+		}
+	}
+
+	return &compute.FirewallLogConfig{
+		Enable:   true,
+		Metadata: string(ptr.Deref(logConfig.Metadata, infrav1.FirewallLogMetadataIncludeAll)),
+	}
+}
+
+// AdditionalFirewallRulesSpec returns google compute firewall specs of the user-defined firewall rules.
+// Their names are prefixed with the name of the cluster, so they are unique in the project.
+func (s *ClusterScope) AdditionalFirewallRulesSpec() []*compute.Firewall {
+	firewallRules := []*compute.Firewall{}
+	for _, rule := range s.GCPCluster.Spec.Network.AdditionalFirewallRules {
+		firewall := &compute.Firewall{
+			Name:              fmt.Sprintf("%s-%s", s.Name(), rule.Name),
+			Description:       infrav1.ClusterTagKey(s.Name()),
+			Network:           s.NetworkLink(),
+			Direction:         string(rule.Direction),
+			Priority:          ptr.Deref(rule.Priority, 1000),
+			SourceRanges:      rule.SourceRanges,
+			DestinationRanges: rule.DestinationRanges,
+			TargetTags:        rule.TargetTags,
+			LogConfig:         firewallLogConfig(s.GCPCluster.Spec.Network.FirewallLogConfig),
+		}
+		if rule.LogConfig != nil {
+			firewall.LogConfig = firewallLogConfig(rule.LogConfig)
+		}
+		if firewall.Direction == "" {
+			firewall.Direction = string(infrav1.FirewallRuleDirectionIngress)
+		}
+		for _, protocol := range rule.Protocols {
+			if rule.Action == infrav1.FirewallRuleActionDeny {
+				firewall.Denied = append(firewall.Denied, &compute.FirewallDenied{
+					IPProtocol: protocol.Protocol,
+					Ports:      protocol.Ports,
+				})
+				continue
+			}
+			firewall.Allowed = append(firewall.Allowed, &compute.FirewallAllowed{
+				IPProtocol: protocol.Protocol,
+				Ports:      protocol.Ports,
+			})
+		}
+		firewallRules = append(firewallRules, firewall)
+	}
+
 	return firewallRules
 }
 
+// NodePortFirewallEnabled returns true if the firewall rule for the NodePort range is enabled.
+func (s *ClusterScope) NodePortFirewallEnabled() bool {
+	return s.GCPCluster.Spec.Network.NodePortFirewall != nil && s.GCPCluster.Spec.Network.NodePortFirewall.Enabled
+}
+
+// NodePortFirewallRuleSpec returns google compute firewall spec opening the NodePort range to the worker nodes.
+func (s *ClusterScope) NodePortFirewallRuleSpec() *compute.Firewall {
+	var sourceRanges []string
+	if s.GCPCluster.Spec.Network.NodePortFirewall != nil {
+		sourceRanges = s.GCPCluster.Spec.Network.NodePortFirewall.SourceRanges
+	}
+
+	return &compute.Firewall{
+		Name:        fmt.Sprintf("allow-%s-nodeports", s.Name()),
+		Description: infrav1.ClusterTagKey(s.Name()),
+		Network:     s.NetworkLink(),
+		Priority:    1000,
+		Allowed: []*compute.FirewallAllowed{
+			{
+				IPProtocol: "TCP",
+				Ports:      []string{nodePortRange},
+			},
+			{
+				IPProtocol: "UDP",
+				Ports:      []string{nodePortRange},
+			},
+		},
+		Direction:    "INGRESS",
+		SourceRanges: sourceRanges,
+		TargetTags: []string{
+			s.Name() + "-node",
+		},
+	}
+}
+
 // ANCHOR_END: ClusterFirewallSpec
 
 // ANCHOR: ClusterControlPlaneSpec
@@ -335,13 +543,19 @@ func (s *ClusterScope) AddressSpec(lbname string) *compute.Address {
 func (s *ClusterScope) BackendServiceSpec(lbname string) *compute.BackendService {
 	return &compute.BackendService{
 		Name:                fmt.Sprintf("%s-%s", s.Name(), lbname),
-		LoadBalancingScheme: "EXTERNAL",
+		LoadBalancingScheme: s.externalLoadBalancingScheme(),
 		PortName:            "apiserver",
 		Protocol:            "TCP",
 		TimeoutSec:          int64((10 * time.Minute).Seconds()),
 	}
 }
 
+// externalLoadBalancingScheme returns the load balancing scheme of the external Load Balancer, which must be the
+// same on its backend service and forwarding rule.
+func (s *ClusterScope) externalLoadBalancingScheme() string {
+	return string(ptr.Deref(s.LoadBalancer().ExternalLoadBalancingScheme, infrav1.LoadBalancingSchemeExternal))
+}
+
 // ForwardingRuleSpec returns google compute forwarding-rule spec.
 func (s *ClusterScope) ForwardingRuleSpec(lbname string) *compute.ForwardingRule {
 	port := int32(443)
@@ -352,7 +566,7 @@ func (s *ClusterScope) ForwardingRuleSpec(lbname string) *compute.ForwardingRule
 	return &compute.ForwardingRule{
 		Name:                fmt.Sprintf("%s-%s", s.Name(), lbname),
 		IPProtocol:          "TCP",
-		LoadBalancingScheme: "EXTERNAL",
+		LoadBalancingScheme: s.externalLoadBalancingScheme(),
 		PortRange:           portRange,
 		Labels:              s.AdditionalLabels(),
 	}
@@ -368,19 +582,28 @@ func (s *ClusterScope) HealthCheckSpec(lbname string) *compute.HealthCheck {
 			PortSpecification: "USE_FIXED_PORT",
 			RequestPath:       "/readyz",
 		},
-		CheckIntervalSec:   10,
-		TimeoutSec:         5,
-		HealthyThreshold:   5,
-		UnhealthyThreshold: 3,
+		CheckIntervalSec:   infrav1.DefaultHealthCheckIntervalSec,
+		TimeoutSec:         infrav1.DefaultHealthCheckTimeoutSec,
+		HealthyThreshold:   infrav1.DefaultHealthCheckHealthyThreshold,
+		UnhealthyThreshold: infrav1.DefaultHealthCheckUnhealthyThreshold,
 	}
 }
 
+// apiServerInstanceGroupName returns the name of the API Server instance-group of the cluster in the given zone.
+func apiServerInstanceGroupName(clusterName string, loadBalancer infrav1.LoadBalancerSpec, zone string) string {
+	tag := ptr.Deref(loadBalancer.APIServerInstanceGroupTagOverride, infrav1.APIServerRoleTagValue)
+	name := fmt.Sprintf("%s-%s-%s", clusterName, tag, zone)
+	if suffix := ptr.Deref(loadBalancer.APIServerInstanceGroupNameSuffix, ""); suffix != "" {
+		name = fmt.Sprintf("%s-%s", name, suffix)
+	}
+	return name
+}
+
 // InstanceGroupSpec returns google compute instance-group spec.
 func (s *ClusterScope) InstanceGroupSpec(zone string) *compute.InstanceGroup {
 	port := ptr.Deref(s.GCPCluster.Spec.Network.LoadBalancerBackendPort, 6443)
-	tag := ptr.Deref(s.GCPCluster.Spec.LoadBalancer.APIServerInstanceGroupTagOverride, infrav1.APIServerRoleTagValue)
 	return &compute.InstanceGroup{
-		Name: fmt.Sprintf("%s-%s-%s", s.Name(), tag, zone),
+		Name: apiServerInstanceGroupName(s.Name(), s.GCPCluster.Spec.LoadBalancer, zone),
 		NamedPorts: []*compute.NamedPort{
 			{
 				Name: "apiserver",
@@ -394,12 +617,56 @@ func (s *ClusterScope) InstanceGroupSpec(zone string) *compute.InstanceGroup {
 func (s *ClusterScope) TargetTCPProxySpec() *compute.TargetTcpProxy {
 	return &compute.TargetTcpProxy{
 		Name:        fmt.Sprintf("%s-%s", s.Name(), infrav1.APIServerRoleTagValue),
-		ProxyHeader: "NONE",
+		ProxyHeader: string(ptr.Deref(s.LoadBalancer().ProxyHeader, infrav1.ProxyHeaderNone)),
 	}
 }
 
 // ANCHOR_END: ClusterControlPlaneSpec
 
+// ANCHOR: ClusterDNSSpec
+
+// DNSService returns the Cloud DNS API client.
+func (s *ClusterScope) DNSService() *dns.Service {
+	return s.DNS
+}
+
+// DNSSpec returns the Cloud DNS configuration of the cluster, or nil when the cluster doesn't use DNS.
+func (s *ClusterScope) DNSSpec() *infrav1.DNSSpec {
+	return s.GCPCluster.Spec.DNS
+}
+
+// ManagedZoneSpec returns the Cloud DNS private zone spec, visible from the network of the cluster.
+func (s *ClusterScope) ManagedZoneSpec() *dns.ManagedZone {
+	return &dns.ManagedZone{
+		Name:        fmt.Sprintf("%s-private-zone", s.Name()),
+		DnsName:     s.GCPCluster.Spec.DNS.Domain + ".",
+		Description: infrav1.ClusterTagKey(s.Name()),
+		Visibility:  "private",
+		PrivateVisibilityConfig: &dns.ManagedZonePrivateVisibilityConfig{
+			Networks: []*dns.ManagedZonePrivateVisibilityConfigNetwork{
+				{NetworkUrl: "https://www.googleapis.com/compute/v1/" + s.NetworkLink()},
+			},
+		},
+		Labels: infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.Name(),
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Additional:  s.AdditionalLabels(),
+		}),
+	}
+}
+
+// APIServerRecordSetSpec returns the Cloud DNS record spec resolving the control plane endpoint.
+func (s *ClusterScope) APIServerRecordSetSpec() *dns.ResourceRecordSet {
+	return &dns.ResourceRecordSet{
+		Name:    fmt.Sprintf("api.%s.", s.GCPCluster.Spec.DNS.Domain),
+		Type:    "A",
+		Ttl:     300,
+		Rrdatas: []string{s.ControlPlaneEndpoint().Host},
+	}
+}
+
+// ANCHOR_END: ClusterDNSSpec
+
 // PatchObject persists the cluster configuration and status.
 func (s *ClusterScope) PatchObject() error {
 	return s.patchHelper.Patch(context.TODO(), s.GCPCluster)
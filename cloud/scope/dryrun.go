@@ -0,0 +1,33 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// hasDryRunAnnotation returns true if the object is annotated to be reconciled in dry-run mode.
+func hasDryRunAnnotation(obj client.Object) bool {
+	return obj.GetAnnotations()[infrav1.DryRunAnnotation] == "true"
+}
+
+// recordDryRun records a change to a GCP resource which is skipped in dry-run mode as an event of the object.
+func recordDryRun(obj client.Object, action, kind, name string) {
+	record.Eventf(obj, "DryRun", "Dry-run: would %s %s %s", action, kind, name)
+}
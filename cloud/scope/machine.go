@@ -20,8 +20,11 @@ package scope
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"path"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -29,6 +32,7 @@ import (
 	"github.com/pkg/errors"
 	"golang.org/x/mod/semver"
 	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/storage/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
@@ -36,6 +40,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/providerid"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/location"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/patch"
@@ -98,21 +103,94 @@ func (m *MachineScope) NetworkCloud() cloud.Cloud {
 	return m.ClusterGetter.NetworkCloud()
 }
 
+// ComputeService returns the compute API client of the cluster.
+func (m *MachineScope) ComputeService() *compute.Service {
+	return m.ClusterGetter.ComputeService()
+}
+
+// RateLimiter returns the rate limiter of the cluster.
+func (m *MachineScope) RateLimiter() cloud.RateLimiter {
+	return m.ClusterGetter.RateLimiter()
+}
+
+// StorageService returns the storage API client of the cluster.
+func (m *MachineScope) StorageService(ctx context.Context) (*storage.Service, error) {
+	return m.ClusterGetter.StorageService(ctx)
+}
+
+// CredentialsServiceAccount returns the email of the service account of the cluster credentials.
+func (m *MachineScope) CredentialsServiceAccount(ctx context.Context) (string, error) {
+	return m.ClusterGetter.CredentialsServiceAccount(ctx)
+}
+
+// SignBlob signs the payload with the given service account.
+func (m *MachineScope) SignBlob(ctx context.Context, serviceAccount string, payload []byte) ([]byte, error) {
+	return m.ClusterGetter.SignBlob(ctx, serviceAccount, payload)
+}
+
+// BootstrapDataStorage returns the GCS bucket where large bootstrap data is stored, or nil if none is set.
+func (m *MachineScope) BootstrapDataStorage() *infrav1.BootstrapDataStorage {
+	return m.GCPMachine.Spec.BootstrapDataStorage
+}
+
 // Zone returns the FailureDomain for the GCPMachine.
+// The failure domain of the Machine takes precedence over the zone of the GCPMachine,
+// which takes precedence over the failure domains of the cluster.
 func (m *MachineScope) Zone() string {
-	if m.Machine.Spec.FailureDomain == nil {
-		fd := m.ClusterGetter.FailureDomains()
-		if len(fd) == 0 {
-			return ""
-		}
-		zones := make([]string, 0, len(fd))
-		for zone := range fd {
-			zones = append(zones, zone)
-		}
-		sort.Strings(zones)
-		return zones[0]
+	if m.Machine.Spec.FailureDomain != nil {
+		return *m.Machine.Spec.FailureDomain
+	}
+	if m.GCPMachine.Spec.Zone != nil {
+		return *m.GCPMachine.Spec.Zone
+	}
+	// Keep the zone of an existing instance, as the selection below depends on the failure domains
+	// of the cluster, which may change.
+	if zone := zoneFromProviderID(m.GetProviderID()); zone != "" {
+		return zone
 	}
-	return *m.Machine.Spec.FailureDomain
+	fd := m.ClusterGetter.FailureDomains()
+	if len(fd) == 0 {
+		return ""
+	}
+	zones := make([]string, 0, len(fd))
+	for zone := range fd {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	// Spread the machines across the zones, with a stable zone for each machine across reconciles.
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(m.Name()))
+	return zones[h.Sum32()%uint32(len(zones))]
+}
+
+// zoneFromProviderID returns the zone of a provider ID in the form gce://<project>/<zone>/<name>, or an
+// empty string if the provider ID is not set or invalid.
+func zoneFromProviderID(providerID string) string {
+	trimmed, ok := strings.CutPrefix(providerID, providerid.Prefix)
+	if !ok {
+		return ""
+	}
+	parts := strings.Split(trimmed, "/")
+	if len(parts) != 3 {
+		return ""
+	}
+	return parts[1]
+}
+
+// ValidateZone checks that the zone of the GCPMachine is within the region of the cluster.
+func (m *MachineScope) ValidateZone() error {
+	zone := m.Zone()
+	if zone == "" {
+		return nil
+	}
+	loc, err := location.Parse(zone)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse zone %q", zone)
+	}
+	if loc.Region != m.ClusterGetter.Region() {
+		return errors.Errorf("zone %q is not within the cluster region %q", zone, m.ClusterGetter.Region())
+	}
+	return nil
 }
 
 // Project return the project for the GCPMachine's cluster.
@@ -130,10 +208,49 @@ func (m *MachineScope) Namespace() string {
 	return m.GCPMachine.Namespace
 }
 
+// ClusterName returns the name of the cluster of the machine.
+func (m *MachineScope) ClusterName() string {
+	return m.ClusterGetter.Name()
+}
+
+// HasNodeRef returns true if the node of the machine joined the cluster.
+func (m *MachineScope) HasNodeRef() bool {
+	return m.Machine.Status.NodeRef != nil
+}
+
+// BootstrapDataDeleted returns true if the bootstrap data stored in the bucket of the BootstrapDataStorage was
+// deleted.
+func (m *MachineScope) BootstrapDataDeleted() bool {
+	return m.GCPMachine.Status.BootstrapDataDeleted
+}
+
+// SetBootstrapDataDeleted records that the bootstrap data stored in the bucket of the BootstrapDataStorage was
+// deleted.
+func (m *MachineScope) SetBootstrapDataDeleted() {
+	m.GCPMachine.Status.BootstrapDataDeleted = true
+}
+
+// InstanceName returns the name of the instance, rendered from the InstanceNameTemplate of the GCPMachine if set.
+func (m *MachineScope) InstanceName() (string, error) {
+	if m.GCPMachine.Spec.InstanceNameTemplate == nil {
+		return m.Name(), nil
+	}
+	name, err := infrav1.RenderInstanceName(*m.GCPMachine.Spec.InstanceNameTemplate, infrav1.InstanceNameParams{
+		ClusterName: m.ClusterGetter.Name(),
+		MachineName: m.Name(),
+		Namespace:   m.Namespace(),
+		Project:     m.ClusterGetter.Project(),
+		Role:        m.Role(),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to render the instance name of GCPMachine %s/%s", m.Namespace(), m.Name())
+	}
+	return name, nil
+}
+
 // ControlPlaneGroupName returns the control-plane instance group name.
 func (m *MachineScope) ControlPlaneGroupName() string {
-	tag := ptr.Deref(m.ClusterGetter.LoadBalancer().APIServerInstanceGroupTagOverride, infrav1.APIServerRoleTagValue)
-	return fmt.Sprintf("%s-%s-%s", m.ClusterGetter.Name(), tag, m.Zone())
+	return apiServerInstanceGroupName(m.ClusterGetter.Name(), m.ClusterGetter.LoadBalancer(), m.Zone())
 }
 
 // IsControlPlane returns true if the machine is a control plane.
@@ -169,14 +286,30 @@ func (m *MachineScope) GetProviderID() string {
 	return ""
 }
 
+// DryRun returns true if the changes to GCP resources are reported without being applied, which is the case when
+// either the GCPMachine or its cluster is in dry-run mode.
+func (m *MachineScope) DryRun() bool {
+	return m.ClusterGetter.DryRun() || hasDryRunAnnotation(m.GCPMachine)
+}
+
+// RecordDryRun records a change to a GCP resource which is skipped in dry-run mode.
+func (m *MachineScope) RecordDryRun(action, kind, name string) {
+	recordDryRun(m.GCPMachine, action, kind, name)
+}
+
 // ANCHOR_END: MachineGetter
 
 // ANCHOR: MachineSetter
 
 // SetProviderID sets the GCPMachine providerID in spec.
-func (m *MachineScope) SetProviderID() {
-	providerID, _ := providerid.New(m.ClusterGetter.Project(), m.Zone(), m.Name())
+func (m *MachineScope) SetProviderID() error {
+	instanceName, err := m.InstanceName()
+	if err != nil {
+		return err
+	}
+	providerID, _ := providerid.New(m.ClusterGetter.Project(), m.Zone(), instanceName)
 	m.GCPMachine.Spec.ProviderID = ptr.To[string](providerID.String())
+	return nil
 }
 
 // GetInstanceStatus returns the GCPMachine instance status.
@@ -221,6 +354,26 @@ func (m *MachineScope) SetAddresses(addressList []corev1.NodeAddress) {
 
 // ANCHOR: MachineInstanceSpec
 
+// RequiredGuestOSFeatures returns the guest OS features the image of the instance must declare.
+func (m *MachineScope) RequiredGuestOSFeatures() []string {
+	features := make([]string, 0, len(m.GCPMachine.Spec.RequiredGuestOSFeatures)+1)
+	for _, feature := range m.GCPMachine.Spec.RequiredGuestOSFeatures {
+		features = append(features, string(feature))
+	}
+	// The gVNIC network interface of the Tier_1 egress bandwidth has to be supported by the image.
+	if m.usesGVNIC() && !slices.Contains(features, string(infrav1.GuestOSFeatureGVNIC)) {
+		features = append(features, string(infrav1.GuestOSFeatureGVNIC))
+	}
+	return features
+}
+
+// usesGVNIC returns true if the network interface of the instance is a gVNIC, which the Tier_1 egress bandwidth
+// requires.
+func (m *MachineScope) usesGVNIC() bool {
+	config := m.GCPMachine.Spec.NetworkPerformanceConfig
+	return config != nil && config.TotalEgressBandwidthTier == infrav1.EgressBandwidthTierTier1
+}
+
 // InstanceImageSpec returns compute instance image attched-disk spec.
 func (m *MachineScope) InstanceImageSpec() *compute.AttachedDisk {
 	version := ""
@@ -246,32 +399,47 @@ func (m *MachineScope) InstanceImageSpec() *compute.AttachedDisk {
 		InitializeParams: &compute.AttachedDiskInitializeParams{
 			DiskSizeGb:          m.GCPMachine.Spec.RootDeviceSize,
 			DiskType:            path.Join("zones", m.Zone(), "diskTypes", string(diskType)),
-			ResourceManagerTags: shared.ResourceTagConvert(context.TODO(), m.GCPMachine.Spec.ResourceManagerTags),
+			ResourceManagerTags: shared.ResourceTagConvert(context.TODO(), m.ResourceManagerTags()),
 			SourceImage:         sourceImage,
 			Labels:              m.ClusterGetter.AdditionalLabels().AddLabels(m.GCPMachine.Spec.AdditionalLabels),
 		},
 	}
+	for _, policy := range m.GCPMachine.Spec.DiskResourcePolicies {
+		disk.InitializeParams.ResourcePolicies = append(disk.InitializeParams.ResourcePolicies,
+			path.Join("projects", m.ClusterGetter.Project(), "regions", m.ClusterGetter.Region(), "resourcePolicies", policy))
+	}
+	if diskType.IsHyperdisk() {
+		disk.InitializeParams.ProvisionedIops = ptr.Deref(m.GCPMachine.Spec.RootDeviceProvisionedIops, 0)
+		disk.InitializeParams.ProvisionedThroughput = ptr.Deref(m.GCPMachine.Spec.RootDeviceProvisionedThroughput, 0)
+	}
 
-	if m.GCPMachine.Spec.RootDiskEncryptionKey != nil {
-		if m.GCPMachine.Spec.RootDiskEncryptionKey.KeyType == infrav1.CustomerManagedKey && m.GCPMachine.Spec.RootDiskEncryptionKey.ManagedKey != nil {
-			disk.DiskEncryptionKey = &compute.CustomerEncryptionKey{
-				KmsKeyName: m.GCPMachine.Spec.RootDiskEncryptionKey.ManagedKey.KMSKeyName,
-			}
-			if m.GCPMachine.Spec.RootDiskEncryptionKey.KMSKeyServiceAccount != nil {
-				disk.DiskEncryptionKey.KmsKeyServiceAccount = *m.GCPMachine.Spec.RootDiskEncryptionKey.KMSKeyServiceAccount
-			}
-		} else if m.GCPMachine.Spec.RootDiskEncryptionKey.KeyType == infrav1.CustomerSuppliedKey && m.GCPMachine.Spec.RootDiskEncryptionKey.SuppliedKey != nil {
-			disk.DiskEncryptionKey = &compute.CustomerEncryptionKey{
-				RawKey:          string(m.GCPMachine.Spec.RootDiskEncryptionKey.SuppliedKey.RawKey),
-				RsaEncryptedKey: string(m.GCPMachine.Spec.RootDiskEncryptionKey.SuppliedKey.RSAEncryptedKey),
-			}
-			if m.GCPMachine.Spec.RootDiskEncryptionKey.KMSKeyServiceAccount != nil {
-				disk.DiskEncryptionKey.KmsKeyServiceAccount = *m.GCPMachine.Spec.RootDiskEncryptionKey.KMSKeyServiceAccount
-			}
+	disk.DiskEncryptionKey = diskEncryptionKey(m.GCPMachine.Spec.RootDiskEncryptionKey)
+
+	return disk
+}
+
+// diskEncryptionKey converts a CustomerEncryptionKey to the encryption key of a disk, or nil if no key is set.
+func diskEncryptionKey(key *infrav1.CustomerEncryptionKey) *compute.CustomerEncryptionKey {
+	if key == nil {
+		return nil
+	}
+
+	var diskKey *compute.CustomerEncryptionKey
+	if key.KeyType == infrav1.CustomerManagedKey && key.ManagedKey != nil {
+		diskKey = &compute.CustomerEncryptionKey{
+			KmsKeyName: key.ManagedKey.KMSKeyName,
 		}
+	} else if key.KeyType == infrav1.CustomerSuppliedKey && key.SuppliedKey != nil {
+		diskKey = &compute.CustomerEncryptionKey{
+			RawKey:          string(key.SuppliedKey.RawKey),
+			RsaEncryptedKey: string(key.SuppliedKey.RSAEncryptedKey),
+		}
+	}
+	if diskKey != nil && key.KMSKeyServiceAccount != nil {
+		diskKey.KmsKeyServiceAccount = *key.KMSKeyServiceAccount
 	}
 
-	return disk
+	return diskKey
 }
 
 // InstanceAdditionalDiskSpec returns compute instance additional attched-disk spec.
@@ -283,7 +451,7 @@ func (m *MachineScope) InstanceAdditionalDiskSpec() []*compute.AttachedDisk {
 			InitializeParams: &compute.AttachedDiskInitializeParams{
 				DiskSizeGb:          ptr.Deref(disk.Size, 30),
 				DiskType:            path.Join("zones", m.Zone(), "diskTypes", string(*disk.DeviceType)),
-				ResourceManagerTags: shared.ResourceTagConvert(context.TODO(), m.GCPMachine.Spec.ResourceManagerTags),
+				ResourceManagerTags: shared.ResourceTagConvert(context.TODO(), m.ResourceManagerTags()),
 			},
 		}
 		if strings.HasSuffix(additionalDisk.InitializeParams.DiskType, string(infrav1.LocalSsdDiskType)) {
@@ -296,24 +464,11 @@ func (m *MachineScope) InstanceAdditionalDiskSpec() []*compute.AttachedDisk {
 			// https://cloud.google.com/compute/docs/disks/local-ssd#choose_an_interface
 			additionalDisk.Interface = "NVME"
 		}
-		if disk.EncryptionKey != nil {
-			if m.GCPMachine.Spec.RootDiskEncryptionKey.KeyType == infrav1.CustomerManagedKey && m.GCPMachine.Spec.RootDiskEncryptionKey.ManagedKey != nil {
-				additionalDisk.DiskEncryptionKey = &compute.CustomerEncryptionKey{
-					KmsKeyName: m.GCPMachine.Spec.RootDiskEncryptionKey.ManagedKey.KMSKeyName,
-				}
-				if m.GCPMachine.Spec.RootDiskEncryptionKey.KMSKeyServiceAccount != nil {
-					additionalDisk.DiskEncryptionKey.KmsKeyServiceAccount = *m.GCPMachine.Spec.RootDiskEncryptionKey.KMSKeyServiceAccount
-				}
-			} else if m.GCPMachine.Spec.RootDiskEncryptionKey.KeyType == infrav1.CustomerSuppliedKey && m.GCPMachine.Spec.RootDiskEncryptionKey.SuppliedKey != nil {
-				additionalDisk.DiskEncryptionKey = &compute.CustomerEncryptionKey{
-					RawKey:          string(m.GCPMachine.Spec.RootDiskEncryptionKey.SuppliedKey.RawKey),
-					RsaEncryptedKey: string(m.GCPMachine.Spec.RootDiskEncryptionKey.SuppliedKey.RSAEncryptedKey),
-				}
-				if m.GCPMachine.Spec.RootDiskEncryptionKey.KMSKeyServiceAccount != nil {
-					additionalDisk.DiskEncryptionKey.KmsKeyServiceAccount = *m.GCPMachine.Spec.RootDiskEncryptionKey.KMSKeyServiceAccount
-				}
-			}
+		if disk.DeviceType.IsHyperdisk() {
+			additionalDisk.InitializeParams.ProvisionedIops = ptr.Deref(disk.ProvisionedIops, 0)
+			additionalDisk.InitializeParams.ProvisionedThroughput = ptr.Deref(disk.ProvisionedThroughput, 0)
 		}
+		additionalDisk.DiskEncryptionKey = diskEncryptionKey(disk.EncryptionKey)
 
 		additionalDisks = append(additionalDisks, additionalDisk)
 	}
@@ -327,6 +482,10 @@ func (m *MachineScope) InstanceNetworkInterfaceSpec() *compute.NetworkInterface
 		Network: path.Join("projects", m.ClusterGetter.NetworkProject(), "global", "networks", m.ClusterGetter.NetworkName()),
 	}
 
+	if m.usesGVNIC() {
+		networkInterface.NicType = "GVNIC"
+	}
+
 	if m.GCPMachine.Spec.PublicIP != nil && *m.GCPMachine.Spec.PublicIP {
 		networkInterface.AccessConfigs = []*compute.AccessConfig{
 			{
@@ -340,21 +499,31 @@ func (m *MachineScope) InstanceNetworkInterfaceSpec() *compute.NetworkInterface
 		networkInterface.Subnetwork = path.Join("projects", m.ClusterGetter.NetworkProject(), "regions", m.ClusterGetter.Region(), "subnetworks", *m.GCPMachine.Spec.Subnet)
 	}
 
+	for _, aliasIPRange := range m.GCPMachine.Spec.AliasIPRanges {
+		networkInterface.AliasIpRanges = append(networkInterface.AliasIpRanges, &compute.AliasIpRange{
+			IpCidrRange:         aliasIPRange.IPCidrRange,
+			SubnetworkRangeName: aliasIPRange.SubnetworkRangeName,
+		})
+	}
+
 	return networkInterface
 }
 
 // InstanceServiceAccountsSpec returns service-account spec.
 func (m *MachineScope) InstanceServiceAccountsSpec() *compute.ServiceAccount {
 	serviceAccount := &compute.ServiceAccount{
-		Email: "default",
+		Email: infrav1.ServiceAccountDefaultEmail,
 		Scopes: []string{
 			compute.CloudPlatformScope,
 		},
 	}
 
 	if m.GCPMachine.Spec.ServiceAccount != nil {
-		serviceAccount.Email = m.GCPMachine.Spec.ServiceAccount.Email
-		serviceAccount.Scopes = m.GCPMachine.Spec.ServiceAccount.Scopes
+		if m.GCPMachine.Spec.ServiceAccount.Email != "" {
+			serviceAccount.Email = m.GCPMachine.Spec.ServiceAccount.Email
+		}
+		// The scopes set by the user replace the default scope, even if there are none.
+		serviceAccount.Scopes = m.GCPMachine.Spec.ServiceAccount.ScopeURLs()
 	}
 
 	return serviceAccount
@@ -369,14 +538,29 @@ func (m *MachineScope) InstanceAdditionalMetadataSpec() *compute.Metadata {
 			Value: additionalMetadata.Value,
 		})
 	}
+	if m.GCPMachine.Spec.EnableOSLogin != nil {
+		shared.SetMetadataItem(metadata, shared.EnableOSLoginMetadataKey, strings.ToUpper(strconv.FormatBool(*m.GCPMachine.Spec.EnableOSLogin)))
+	}
+	if m.GCPMachine.Spec.BlockProjectSSHKeys != nil {
+		shared.SetMetadataItem(metadata, shared.BlockProjectSSHKeysMetadataKey, strings.ToUpper(strconv.FormatBool(*m.GCPMachine.Spec.BlockProjectSSHKeys)))
+	}
+	if m.GCPMachine.Spec.EnableSerialPortLogging != nil {
+		shared.SetMetadataItem(metadata, shared.SerialPortLoggingEnableMetadataKey, strings.ToUpper(strconv.FormatBool(*m.GCPMachine.Spec.EnableSerialPortLogging)))
+	}
 
 	return metadata
 }
 
 // InstanceSpec returns instance spec.
-func (m *MachineScope) InstanceSpec(log logr.Logger) *compute.Instance {
+func (m *MachineScope) InstanceSpec(log logr.Logger) (*compute.Instance, error) {
+	instanceName, err := m.InstanceName()
+	if err != nil {
+		return nil, err
+	}
+
 	instance := &compute.Instance{
-		Name:        m.Name(),
+		Name:        instanceName,
+		Hostname:    ptr.Deref(m.GCPMachine.Spec.Hostname, ""),
 		Zone:        m.Zone(),
 		MachineType: path.Join("zones", m.Zone(), "machineTypes", m.GCPMachine.Spec.InstanceType),
 		Tags: &compute.Tags{
@@ -412,8 +596,52 @@ func (m *MachineScope) InstanceSpec(log logr.Logger) *compute.Instance {
 		}
 	}
 
+	if d := m.GCPMachine.Spec.MaxRunDuration; d != nil {
+		instance.Scheduling.MaxRunDuration = &compute.Duration{
+			Seconds: int64(d.Seconds()),
+		}
+		// A maximum run duration requires a termination action, delete the instance by default as a
+		// stopped instance would not run its node again.
+		instance.Scheduling.InstanceTerminationAction = "DELETE"
+	}
+	if a := m.GCPMachine.Spec.InstanceTerminationAction; a != nil {
+		switch *a {
+		case infrav1.InstanceTerminationActionStop:
+			instance.Scheduling.InstanceTerminationAction = "STOP"
+		case infrav1.InstanceTerminationActionDelete:
+			instance.Scheduling.InstanceTerminationAction = "DELETE"
+		default:
+			log.Error(errors.New("Invalid value"), "Unknown InstanceTerminationAction value", "Spec.InstanceTerminationAction", *a)
+		}
+	}
+
+	if features := m.GCPMachine.Spec.AdvancedMachineFeatures; features != nil {
+		instance.AdvancedMachineFeatures = &compute.AdvancedMachineFeatures{
+			EnableNestedVirtualization: features.EnableNestedVirtualization,
+			ThreadsPerCore:             ptr.Deref(features.ThreadsPerCore, 0),
+			VisibleCoreCount:           ptr.Deref(features.VisibleCoreCount, 0),
+		}
+	}
+	if config := m.GCPMachine.Spec.NetworkPerformanceConfig; config != nil {
+		switch config.TotalEgressBandwidthTier {
+		case infrav1.EgressBandwidthTierTier1:
+			instance.NetworkPerformanceConfig = &compute.NetworkPerformanceConfig{TotalEgressBandwidthTier: "TIER_1"}
+		case infrav1.EgressBandwidthTierDefault:
+			instance.NetworkPerformanceConfig = &compute.NetworkPerformanceConfig{TotalEgressBandwidthTier: "DEFAULT"}
+		default:
+			log.Error(errors.New("Invalid value"), "Unknown TotalEgressBandwidthTier value", "Spec.NetworkPerformanceConfig.TotalEgressBandwidthTier", config.TotalEgressBandwidthTier)
+		}
+	}
+	instance.MinCpuPlatform = ptr.Deref(m.GCPMachine.Spec.MinCPUPlatform, "")
+
+	instance.DeletionProtection = m.GCPMachine.Spec.DeletionProtection
+	if m.GCPMachine.Spec.EnableDisplayDevice {
+		instance.DisplayDevice = &compute.DisplayDevice{EnableDisplay: true}
+	}
+
+	// IP forwarding can only be disabled on instances without alias IP ranges.
 	instance.CanIpForward = true
-	if m.GCPMachine.Spec.IPForwarding != nil && *m.GCPMachine.Spec.IPForwarding == infrav1.IPForwardingDisabled {
+	if m.GCPMachine.Spec.IPForwarding != nil && *m.GCPMachine.Spec.IPForwarding == infrav1.IPForwardingDisabled && len(m.GCPMachine.Spec.AliasIPRanges) == 0 {
 		instance.CanIpForward = false
 	}
 	if m.GCPMachine.Spec.ShieldedInstanceConfig != nil {
@@ -444,10 +672,28 @@ func (m *MachineScope) InstanceSpec(log logr.Logger) *compute.Instance {
 
 		instance.Scheduling.OnHostMaintenance = strings.ToUpper(string(*m.GCPMachine.Spec.OnHostMaintenance))
 	}
+	// Instances with GPUs attached cannot be live migrated, so they are terminated on host maintenance and
+	// restarted afterwards, unless they are Spot or preemptible VMs which cannot be restarted automatically.
+	if m.GCPMachine.Spec.HasAccelerators() {
+		instance.Scheduling.OnHostMaintenance = "TERMINATE"
+		if !m.GCPMachine.Spec.IsSpotOrPreemptible() {
+			instance.Scheduling.AutomaticRestart = ptr.To(true)
+		}
+	}
+	if m.GCPMachine.Spec.AutomaticRestart != nil {
+		instance.Scheduling.AutomaticRestart = ptr.To(*m.GCPMachine.Spec.AutomaticRestart && !m.GCPMachine.Spec.IsSpotOrPreemptible())
+	}
 	if m.GCPMachine.Spec.ConfidentialCompute != nil {
-		enabled := *m.GCPMachine.Spec.ConfidentialCompute == infrav1.ConfidentialComputePolicyEnabled
-		instance.ConfidentialInstanceConfig = &compute.ConfidentialInstanceConfig{
-			EnableConfidentialCompute: enabled,
+		switch *m.GCPMachine.Spec.ConfidentialCompute {
+		case infrav1.ConfidentialComputePolicyTDX:
+			instance.ConfidentialInstanceConfig = &compute.ConfidentialInstanceConfig{
+				ConfidentialInstanceType: "TDX",
+			}
+		default:
+			enabled := *m.GCPMachine.Spec.ConfidentialCompute == infrav1.ConfidentialComputePolicyEnabled
+			instance.ConfidentialInstanceConfig = &compute.ConfidentialInstanceConfig{
+				EnableConfidentialCompute: enabled,
+			}
 		}
 	}
 
@@ -456,7 +702,7 @@ func (m *MachineScope) InstanceSpec(log logr.Logger) *compute.Instance {
 	instance.Metadata = m.InstanceAdditionalMetadataSpec()
 	instance.ServiceAccounts = append(instance.ServiceAccounts, m.InstanceServiceAccountsSpec())
 	instance.NetworkInterfaces = append(instance.NetworkInterfaces, m.InstanceNetworkInterfaceSpec())
-	return instance
+	return instance, nil
 }
 
 // ANCHOR_END: MachineInstanceSpec
@@ -481,6 +727,39 @@ func (m *MachineScope) GetBootstrapData() (string, error) {
 	return string(value), nil
 }
 
+// GetStartupScript returns the startup script of the GCPMachine, or an empty string if none is set.
+func (m *MachineScope) GetStartupScript() (string, error) {
+	return m.getScript(m.GCPMachine.Spec.StartupScript)
+}
+
+// GetShutdownScript returns the shutdown script of the GCPMachine, or an empty string if none is set.
+func (m *MachineScope) GetShutdownScript() (string, error) {
+	return m.getScript(m.GCPMachine.Spec.ShutdownScript)
+}
+
+// getScript returns the content of a script, retrieving it from its secret if it is not set inline.
+func (m *MachineScope) getScript(script *infrav1.MachineScript) (string, error) {
+	if script == nil {
+		return "", nil
+	}
+	if script.SecretRef == nil {
+		return ptr.Deref(script.Content, ""), nil
+	}
+
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: m.Namespace(), Name: script.SecretRef.Name}
+	if err := m.client.Get(context.TODO(), key, secret); err != nil {
+		return "", errors.Wrapf(err, "failed to retrieve script secret for GCPMachine %s/%s", m.Namespace(), m.Name())
+	}
+
+	value, ok := secret.Data[script.SecretRef.Key]
+	if !ok {
+		return "", errors.Errorf("error retrieving script: secret %s key %s is missing", script.SecretRef.Name, script.SecretRef.Key)
+	}
+
+	return string(value), nil
+}
+
 // PatchObject persists the cluster configuration and status.
 func (m *MachineScope) PatchObject() error {
 	return m.patchHelper.Patch(context.TODO(), m.GCPMachine)
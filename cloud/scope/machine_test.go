@@ -1,9 +1,17 @@
 package scope
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -49,9 +57,10 @@ func TestMachineLocalSSDDiskType(t *testing.T) {
 
 	// Finally put together the parameters.
 	testScopeParams := MachineScopeParams{
-		Client:     testClient,
-		Machine:    &testMachine,
-		GCPMachine: &testGCPMachine,
+		Client:        testClient,
+		ClusterGetter: &ClusterScope{GCPCluster: &infrav1.GCPCluster{}},
+		Machine:       &testMachine,
+		GCPMachine:    &testGCPMachine,
 	}
 
 	// Create the scope
@@ -72,3 +81,1019 @@ func TestMachineLocalSSDDiskType(t *testing.T) {
 	assert.Equal(t, "NVME", localSSDTest.Interface)
 	assert.Equal(t, int64(375), localSSDTest.InitializeParams.DiskSizeGb)
 }
+
+// This test verifies the precedence used to pick the zone of a GCPMachine:
+// Machine failure domain, then GCPMachine zone, then cluster failure domains.
+func TestMachineZone(t *testing.T) {
+	tests := []struct {
+		name          string
+		failureDomain *string
+		zone          *string
+		providerID    *string
+		wantZone      string
+		wantErr       bool
+	}{
+		{
+			name:     "cluster failure domains",
+			wantZone: "us-central1-b",
+		},
+		{
+			name:       "zone of the provider ID takes precedence over the cluster failure domains",
+			providerID: ptr.To("gce://my-proj/us-central1-a/my-machine"),
+			wantZone:   "us-central1-a",
+		},
+		{
+			name:     "GCPMachine zone takes precedence over the cluster failure domains",
+			zone:     ptr.To("us-central1-c"),
+			wantZone: "us-central1-c",
+		},
+		{
+			name:          "Machine failure domain takes precedence over the GCPMachine zone",
+			failureDomain: ptr.To("us-central1-b"),
+			zone:          ptr.To("us-central1-c"),
+			wantZone:      "us-central1-b",
+		},
+		{
+			name:     "GCPMachine zone outside of the cluster region",
+			zone:     ptr.To("europe-west1-b"),
+			wantZone: "europe-west1-b",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machineScope := &MachineScope{
+				ClusterGetter: &ClusterScope{
+					GCPCluster: &infrav1.GCPCluster{
+						Spec: infrav1.GCPClusterSpec{Region: "us-central1"},
+						Status: infrav1.GCPClusterStatus{
+							FailureDomains: clusterv1.FailureDomains{
+								"us-central1-b": clusterv1.FailureDomainSpec{},
+								"us-central1-a": clusterv1.FailureDomainSpec{},
+							},
+						},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					Spec: clusterv1.MachineSpec{FailureDomain: tt.failureDomain},
+				},
+				GCPMachine: &infrav1.GCPMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-machine"},
+					Spec:       infrav1.GCPMachineSpec{Zone: tt.zone, ProviderID: tt.providerID},
+				},
+			}
+
+			assert.Equal(t, tt.wantZone, machineScope.Zone())
+			if tt.wantErr {
+				assert.Error(t, machineScope.ValidateZone())
+			} else {
+				assert.NoError(t, machineScope.ValidateZone())
+			}
+		})
+	}
+}
+
+// This test verifies that the instance name is rendered from the InstanceNameTemplate of the GCPMachine, and is
+// used consistently by the instance spec and the provider ID, or fails them all if the template cannot be rendered.
+func TestMachineInstanceName(t *testing.T) {
+	tests := []struct {
+		name     string
+		template *string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "no template",
+			want: "my-machine",
+		},
+		{
+			name:     "templated name",
+			template: ptr.To("{{ .project }}-{{ .cluster.name }}-{{ .machine.name }}"),
+			want:     "my-proj-my-cluster-my-machine",
+		},
+		{
+			name:     "templated name longer than 63 characters",
+			template: ptr.To("{{ .project }}-production-environment-{{ .cluster.name }}-{{ .machine.namespace }}-{{ .role }}-{{ .machine.name }}"),
+			want:     "my-proj-production-environment-my-cluster-default-node-d7349a33",
+		},
+		{
+			name:     "template which cannot be rendered",
+			template: ptr.To("{{ .machine.uid }}"),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machineScope := &MachineScope{
+				ClusterGetter: &ClusterScope{
+					Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"}},
+					GCPCluster: &infrav1.GCPCluster{
+						Spec: infrav1.GCPClusterSpec{Project: "my-proj", Region: "us-central1"},
+					},
+				},
+				Machine: &clusterv1.Machine{},
+				GCPMachine: &infrav1.GCPMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-machine", Namespace: "default"},
+					Spec: infrav1.GCPMachineSpec{
+						Zone:                 ptr.To("us-central1-a"),
+						InstanceNameTemplate: tt.template,
+					},
+				},
+			}
+
+			instanceName, err := machineScope.InstanceName()
+			if tt.wantErr {
+				assert.Error(t, err)
+				_, err = machineScope.InstanceSpec(logr.Discard())
+				assert.Error(t, err)
+				assert.Error(t, machineScope.SetProviderID())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, instanceName)
+			assert.LessOrEqual(t, len(instanceName), 63)
+			instance, err := machineScope.InstanceSpec(logr.Discard())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, instance.Name)
+			require.NoError(t, machineScope.SetProviderID())
+			assert.Equal(t, "gce://my-proj/us-central1-a/"+tt.want, machineScope.GetProviderID())
+		})
+	}
+}
+
+// This test verifies that machines without a zone are spread across the
+// failure domains of the cluster, with a stable zone for each machine.
+func TestMachineZoneSelection(t *testing.T) {
+	newMachineScope := func(name string, failureDomains clusterv1.FailureDomains) *MachineScope {
+		return &MachineScope{
+			ClusterGetter: &ClusterScope{
+				GCPCluster: &infrav1.GCPCluster{
+					Status: infrav1.GCPClusterStatus{FailureDomains: failureDomains},
+				},
+			},
+			Machine: &clusterv1.Machine{},
+			GCPMachine: &infrav1.GCPMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+			},
+		}
+	}
+	failureDomains := clusterv1.FailureDomains{
+		"us-central1-a": clusterv1.FailureDomainSpec{},
+		"us-central1-b": clusterv1.FailureDomainSpec{},
+		"us-central1-c": clusterv1.FailureDomainSpec{},
+	}
+
+	zones := map[string]bool{}
+	for i := range 30 {
+		name := fmt.Sprintf("my-machine-%d", i)
+		zone := newMachineScope(name, failureDomains).Zone()
+		for range 10 {
+			// The failure domains are a map, rebuild the scope so that they are iterated in a new order.
+			assert.Equal(t, zone, newMachineScope(name, failureDomains).Zone())
+		}
+		zones[zone] = true
+	}
+	assert.Len(t, zones, 3)
+}
+
+// This test verifies that the API Server instance group names of two clusters with the same name
+// differ when a suffix is set, and are consistent between the cluster and its machines.
+func TestAPIServerInstanceGroupName(t *testing.T) {
+	newScopes := func(suffix *string) (*ClusterScope, *MachineScope) {
+		clusterScope := &ClusterScope{
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+			},
+			GCPCluster: &infrav1.GCPCluster{
+				Spec: infrav1.GCPClusterSpec{
+					LoadBalancer: infrav1.LoadBalancerSpec{
+						APIServerInstanceGroupNameSuffix: suffix,
+					},
+				},
+			},
+		}
+		machineScope := &MachineScope{
+			ClusterGetter: clusterScope,
+			Machine: &clusterv1.Machine{
+				Spec: clusterv1.MachineSpec{FailureDomain: ptr.To("us-central1-a")},
+			},
+			GCPMachine: &infrav1.GCPMachine{},
+		}
+		return clusterScope, machineScope
+	}
+
+	clusterScope, machineScope := newScopes(nil)
+	assert.Equal(t, "my-cluster-apiserver-us-central1-a", clusterScope.InstanceGroupSpec("us-central1-a").Name)
+	assert.Equal(t, clusterScope.InstanceGroupSpec("us-central1-a").Name, machineScope.ControlPlaneGroupName())
+
+	clusterScopeA, machineScopeA := newScopes(ptr.To("a1b2c3"))
+	clusterScopeB, machineScopeB := newScopes(ptr.To("d4e5f6"))
+	assert.Equal(t, "my-cluster-apiserver-us-central1-a-a1b2c3", clusterScopeA.InstanceGroupSpec("us-central1-a").Name)
+	assert.Equal(t, clusterScopeA.InstanceGroupSpec("us-central1-a").Name, machineScopeA.ControlPlaneGroupName())
+	assert.Equal(t, clusterScopeB.InstanceGroupSpec("us-central1-a").Name, machineScopeB.ControlPlaneGroupName())
+	assert.NotEqual(t, clusterScopeA.InstanceGroupSpec("us-central1-a").Name, clusterScopeB.InstanceGroupSpec("us-central1-a").Name)
+}
+
+// This test verifies that the OS Login, project SSH keys and serial port
+// logging toggles are injected in the instance metadata.
+func TestMachineInstanceAdditionalMetadataSpec(t *testing.T) {
+	machineScope := &MachineScope{
+		GCPMachine: &infrav1.GCPMachine{
+			Spec: infrav1.GCPMachineSpec{
+				AdditionalMetadata: []infrav1.MetadataItem{
+					{Key: "foo", Value: ptr.To("bar")},
+					{Key: "enable-oslogin", Value: ptr.To("FALSE")},
+				},
+				EnableOSLogin:           ptr.To(true),
+				BlockProjectSSHKeys:     ptr.To(true),
+				EnableSerialPortLogging: ptr.To(true),
+			},
+		},
+	}
+
+	metadata := machineScope.InstanceAdditionalMetadataSpec()
+	items := make(map[string]string, len(metadata.Items))
+	for _, item := range metadata.Items {
+		items[item.Key] = *item.Value
+	}
+	assert.Len(t, metadata.Items, 4)
+	assert.Equal(t, map[string]string{
+		"foo":                        "bar",
+		"enable-oslogin":             "TRUE",
+		"block-project-ssh-keys":     "TRUE",
+		"serial-port-logging-enable": "TRUE",
+	}, items)
+}
+
+// This test verifies that the resource manager tags of the cluster are applied to the machine, and
+// that the tags of the GCPMachine override the ones of the cluster with the same key.
+func TestMachineResourceManagerTags(t *testing.T) {
+	machineScope := &MachineScope{
+		ClusterGetter: &ClusterScope{
+			GCPCluster: &infrav1.GCPCluster{
+				Spec: infrav1.GCPClusterSpec{
+					ResourceManagerTags: infrav1.ResourceManagerTags{
+						{ParentID: "my-proj", Key: "env", Value: "dev"},
+						{ParentID: "my-proj", Key: "team", Value: "infra"},
+					},
+				},
+			},
+		},
+		GCPMachine: &infrav1.GCPMachine{
+			Spec: infrav1.GCPMachineSpec{
+				ResourceManagerTags: infrav1.ResourceManagerTags{
+					{ParentID: "my-proj", Key: "env", Value: "prod"},
+					{ParentID: "123456", Key: "env", Value: "prod"},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, infrav1.ResourceManagerTags{
+		{ParentID: "my-proj", Key: "env", Value: "prod"},
+		{ParentID: "my-proj", Key: "team", Value: "infra"},
+		{ParentID: "123456", Key: "env", Value: "prod"},
+	}, machineScope.ResourceManagerTags())
+}
+
+// This test verifies the precedence used to pick the boot image of a GCPMachine:
+// explicit image, then image family, then the family derived from the Kubernetes version.
+func TestMachineInstanceImageSpec(t *testing.T) {
+	tests := []struct {
+		name        string
+		image       *string
+		imageFamily *string
+		want        string
+	}{
+		{
+			name: "image derived from the Kubernetes version",
+			want: "projects/my-proj/global/images/family/capi-ubuntu-1804-k8s-v1-30",
+		},
+		{
+			name:        "image family takes precedence over the Kubernetes version",
+			imageFamily: ptr.To("projects/my-images/global/images/family/golden"),
+			want:        "projects/my-images/global/images/family/golden",
+		},
+		{
+			name:        "image takes precedence over the image family",
+			image:       ptr.To("projects/my-images/global/images/golden-v2"),
+			imageFamily: ptr.To("projects/my-images/global/images/family/golden"),
+			want:        "projects/my-images/global/images/golden-v2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machineScope := &MachineScope{
+				ClusterGetter: &ClusterScope{
+					GCPCluster: &infrav1.GCPCluster{
+						Spec: infrav1.GCPClusterSpec{Project: "my-proj"},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					Spec: clusterv1.MachineSpec{
+						FailureDomain: ptr.To("us-central1-a"),
+						Version:       ptr.To("v1.30.2"),
+					},
+				},
+				GCPMachine: &infrav1.GCPMachine{
+					Spec: infrav1.GCPMachineSpec{
+						Image:       tt.image,
+						ImageFamily: tt.imageFamily,
+					},
+				},
+			}
+
+			assert.Equal(t, tt.want, machineScope.InstanceImageSpec().InitializeParams.SourceImage)
+		})
+	}
+}
+
+func TestMachineDiskProvisionedPerformance(t *testing.T) {
+	hyperdiskBalanced := infrav1.DiskType("hyperdisk-balanced")
+	machineScope := &MachineScope{
+		ClusterGetter: &ClusterScope{
+			GCPCluster: &infrav1.GCPCluster{
+				Spec: infrav1.GCPClusterSpec{Project: "my-proj"},
+			},
+		},
+		Machine: &clusterv1.Machine{
+			Spec: clusterv1.MachineSpec{
+				FailureDomain: ptr.To("us-central1-a"),
+				Version:       ptr.To("v1.30.2"),
+			},
+		},
+		GCPMachine: &infrav1.GCPMachine{
+			Spec: infrav1.GCPMachineSpec{
+				RootDeviceType:                  &hyperdiskBalanced,
+				RootDeviceProvisionedIops:       ptr.To[int64](5000),
+				RootDeviceProvisionedThroughput: ptr.To[int64](200),
+				AdditionalDisks: []infrav1.AttachedDiskSpec{
+					{
+						DeviceType:            &hyperdiskBalanced,
+						ProvisionedIops:       ptr.To[int64](3000),
+						ProvisionedThroughput: ptr.To[int64](140),
+					},
+					{
+						DeviceType:      ptr.To(infrav1.PdSsdDiskType),
+						ProvisionedIops: ptr.To[int64](3000),
+					},
+				},
+			},
+		},
+	}
+
+	rootDisk := machineScope.InstanceImageSpec()
+	assert.Equal(t, "zones/us-central1-a/diskTypes/hyperdisk-balanced", rootDisk.InitializeParams.DiskType)
+	assert.Equal(t, int64(5000), rootDisk.InitializeParams.ProvisionedIops)
+	assert.Equal(t, int64(200), rootDisk.InitializeParams.ProvisionedThroughput)
+
+	additionalDisks := machineScope.InstanceAdditionalDiskSpec()
+	assert.Len(t, additionalDisks, 2)
+	assert.Equal(t, int64(3000), additionalDisks[0].InitializeParams.ProvisionedIops)
+	assert.Equal(t, int64(140), additionalDisks[0].InitializeParams.ProvisionedThroughput)
+	// Provisioned performance is not supported by persistent disks and is left unset.
+	assert.Zero(t, additionalDisks[1].InitializeParams.ProvisionedIops)
+	assert.Zero(t, additionalDisks[1].InitializeParams.ProvisionedThroughput)
+}
+
+func TestMachineInstanceAdditionalDiskEncryptionKey(t *testing.T) {
+	pdSsd := infrav1.PdSsdDiskType
+	machineScope := &MachineScope{
+		ClusterGetter: &ClusterScope{
+			GCPCluster: &infrav1.GCPCluster{
+				Spec: infrav1.GCPClusterSpec{Project: "my-proj"},
+			},
+		},
+		Machine: &clusterv1.Machine{
+			Spec: clusterv1.MachineSpec{
+				FailureDomain: ptr.To("us-central1-a"),
+			},
+		},
+		GCPMachine: &infrav1.GCPMachine{
+			Spec: infrav1.GCPMachineSpec{
+				AdditionalDisks: []infrav1.AttachedDiskSpec{
+					{
+						DeviceType: &pdSsd,
+					},
+					{
+						DeviceType: &pdSsd,
+						EncryptionKey: &infrav1.CustomerEncryptionKey{
+							KeyType: infrav1.CustomerManagedKey,
+							ManagedKey: &infrav1.ManagedKey{
+								KMSKeyName: "projects/my-proj/locations/us-central1/keyRings/my-ring/cryptoKeys/data",
+							},
+							KMSKeyServiceAccount: ptr.To("kms@my-proj.iam.gserviceaccount.com"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	disks := machineScope.InstanceAdditionalDiskSpec()
+	assert.Len(t, disks, 2)
+	assert.Nil(t, disks[0].DiskEncryptionKey)
+	assert.Equal(t, &compute.CustomerEncryptionKey{
+		KmsKeyName:           "projects/my-proj/locations/us-central1/keyRings/my-ring/cryptoKeys/data",
+		KmsKeyServiceAccount: "kms@my-proj.iam.gserviceaccount.com",
+	}, disks[1].DiskEncryptionKey)
+}
+
+// This test verifies that the maximum run duration and termination action
+// are set in the scheduling of the instance.
+func TestMachineInstanceSpecScheduling(t *testing.T) {
+	tests := []struct {
+		name                      string
+		maxRunDuration            *metav1.Duration
+		instanceTerminationAction *infrav1.InstanceTerminationAction
+		want                      *compute.Scheduling
+	}{
+		{
+			name: "no maximum run duration",
+			want: &compute.Scheduling{ProvisioningModel: "SPOT"},
+		},
+		{
+			name:           "maximum run duration deletes the instance by default",
+			maxRunDuration: &metav1.Duration{Duration: 4 * time.Hour},
+			want: &compute.Scheduling{
+				ProvisioningModel:         "SPOT",
+				MaxRunDuration:            &compute.Duration{Seconds: 14400},
+				InstanceTerminationAction: "DELETE",
+			},
+		},
+		{
+			name:                      "maximum run duration with the stop termination action",
+			maxRunDuration:            &metav1.Duration{Duration: 90 * time.Minute},
+			instanceTerminationAction: ptr.To(infrav1.InstanceTerminationActionStop),
+			want: &compute.Scheduling{
+				ProvisioningModel:         "SPOT",
+				MaxRunDuration:            &compute.Duration{Seconds: 5400},
+				InstanceTerminationAction: "STOP",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machineScope := &MachineScope{
+				ClusterGetter: &ClusterScope{
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+					},
+					GCPCluster: &infrav1.GCPCluster{
+						Spec: infrav1.GCPClusterSpec{Project: "my-proj"},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					Spec: clusterv1.MachineSpec{
+						FailureDomain: ptr.To("us-central1-a"),
+					},
+				},
+				GCPMachine: &infrav1.GCPMachine{
+					Spec: infrav1.GCPMachineSpec{
+						ProvisioningModel:         ptr.To(infrav1.ProvisioningModelSpot),
+						MaxRunDuration:            tt.maxRunDuration,
+						InstanceTerminationAction: tt.instanceTerminationAction,
+					},
+				},
+			}
+
+			instance, err := machineScope.InstanceSpec(logr.Discard())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, instance.Scheduling)
+		})
+	}
+}
+
+// This test verifies that instances with GPUs attached are terminated on host maintenance, independently of
+// confidential compute, and restarted afterwards unless they are Spot VMs.
+func TestMachineInstanceSpecSchedulingAccelerators(t *testing.T) {
+	tests := []struct {
+		name              string
+		instanceType      string
+		onHostMaintenance *infrav1.HostMaintenancePolicy
+		provisioningModel *infrav1.ProvisioningModel
+		want              *compute.Scheduling
+	}{
+		{
+			name:         "instance without GPUs",
+			instanceType: "n2-standard-4",
+			want:         &compute.Scheduling{},
+		},
+		{
+			name:         "instance with GPUs",
+			instanceType: "a2-highgpu-1g",
+			want:         &compute.Scheduling{OnHostMaintenance: "TERMINATE", AutomaticRestart: ptr.To(true)},
+		},
+		{
+			name:              "instance with GPUs and the migrate policy",
+			instanceType:      "g2-standard-4",
+			onHostMaintenance: ptr.To(infrav1.HostMaintenancePolicyMigrate),
+			want:              &compute.Scheduling{OnHostMaintenance: "TERMINATE", AutomaticRestart: ptr.To(true)},
+		},
+		{
+			name:              "Spot instance with GPUs",
+			instanceType:      "a3-highgpu-8g",
+			provisioningModel: ptr.To(infrav1.ProvisioningModelSpot),
+			want:              &compute.Scheduling{OnHostMaintenance: "TERMINATE", ProvisioningModel: "SPOT"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machineScope := &MachineScope{
+				ClusterGetter: &ClusterScope{
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+					},
+					GCPCluster: &infrav1.GCPCluster{
+						Spec: infrav1.GCPClusterSpec{Project: "my-proj"},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					Spec: clusterv1.MachineSpec{
+						FailureDomain: ptr.To("us-central1-a"),
+					},
+				},
+				GCPMachine: &infrav1.GCPMachine{
+					Spec: infrav1.GCPMachineSpec{
+						InstanceType:      tt.instanceType,
+						OnHostMaintenance: tt.onHostMaintenance,
+						ProvisioningModel: tt.provisioningModel,
+					},
+				},
+			}
+
+			instance, err := machineScope.InstanceSpec(logr.Discard())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, instance.Scheduling)
+		})
+	}
+}
+
+// This test verifies that the automatic restart of the instance follows the GCPMachine, and is never enabled for
+// Spot or preemptible instances.
+func TestMachineInstanceSpecSchedulingAutomaticRestart(t *testing.T) {
+	tests := []struct {
+		name             string
+		automaticRestart *bool
+		preemptible      bool
+		want             *compute.Scheduling
+	}{
+		{
+			name: "automatic restart not specified",
+			want: &compute.Scheduling{},
+		},
+		{
+			name:             "automatic restart enabled",
+			automaticRestart: ptr.To(true),
+			want:             &compute.Scheduling{AutomaticRestart: ptr.To(true)},
+		},
+		{
+			name:             "automatic restart disabled",
+			automaticRestart: ptr.To(false),
+			want:             &compute.Scheduling{AutomaticRestart: ptr.To(false)},
+		},
+		{
+			name:             "automatic restart of a preemptible instance",
+			automaticRestart: ptr.To(true),
+			preemptible:      true,
+			want:             &compute.Scheduling{Preemptible: true, AutomaticRestart: ptr.To(false)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machineScope := &MachineScope{
+				ClusterGetter: &ClusterScope{
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+					},
+					GCPCluster: &infrav1.GCPCluster{
+						Spec: infrav1.GCPClusterSpec{Project: "my-proj"},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					Spec: clusterv1.MachineSpec{
+						FailureDomain: ptr.To("us-central1-a"),
+					},
+				},
+				GCPMachine: &infrav1.GCPMachine{
+					Spec: infrav1.GCPMachineSpec{
+						InstanceType:     "n2-standard-4",
+						AutomaticRestart: tt.automaticRestart,
+						Preemptible:      tt.preemptible,
+					},
+				},
+			}
+
+			instance, err := machineScope.InstanceSpec(logr.Discard())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, instance.Scheduling)
+		})
+	}
+}
+
+// This test verifies that IP forwarding is enabled by default, can be disabled,
+// and is always enabled on instances with alias IP ranges.
+func TestMachineInstanceSpecIPForwarding(t *testing.T) {
+	aliasIPRanges := []infrav1.AliasIPRange{
+		{IPCidrRange: "10.1.2.0/24", SubnetworkRangeName: "pods"},
+	}
+	tests := []struct {
+		name              string
+		ipForwarding      *infrav1.IPForwarding
+		aliasIPRanges     []infrav1.AliasIPRange
+		wantCanIPForward  bool
+		wantAliasIPRanges []*compute.AliasIpRange
+	}{
+		{
+			name:             "IP forwarding enabled by default",
+			wantCanIPForward: true,
+		},
+		{
+			name:             "IP forwarding enabled",
+			ipForwarding:     ptr.To(infrav1.IPForwardingEnabled),
+			wantCanIPForward: true,
+		},
+		{
+			name:             "IP forwarding disabled",
+			ipForwarding:     ptr.To(infrav1.IPForwardingDisabled),
+			wantCanIPForward: false,
+		},
+		{
+			name:              "alias IP ranges with IP forwarding enabled by default",
+			aliasIPRanges:     aliasIPRanges,
+			wantCanIPForward:  true,
+			wantAliasIPRanges: []*compute.AliasIpRange{{IpCidrRange: "10.1.2.0/24", SubnetworkRangeName: "pods"}},
+		},
+		{
+			name:              "alias IP ranges force IP forwarding",
+			ipForwarding:      ptr.To(infrav1.IPForwardingDisabled),
+			aliasIPRanges:     aliasIPRanges,
+			wantCanIPForward:  true,
+			wantAliasIPRanges: []*compute.AliasIpRange{{IpCidrRange: "10.1.2.0/24", SubnetworkRangeName: "pods"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machineScope := &MachineScope{
+				ClusterGetter: &ClusterScope{
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+					},
+					GCPCluster: &infrav1.GCPCluster{
+						Spec: infrav1.GCPClusterSpec{Project: "my-proj"},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					Spec: clusterv1.MachineSpec{
+						FailureDomain: ptr.To("us-central1-a"),
+					},
+				},
+				GCPMachine: &infrav1.GCPMachine{
+					Spec: infrav1.GCPMachineSpec{
+						IPForwarding:  tt.ipForwarding,
+						AliasIPRanges: tt.aliasIPRanges,
+					},
+				},
+			}
+
+			instance, err := machineScope.InstanceSpec(logr.Discard())
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCanIPForward, instance.CanIpForward)
+			assert.Equal(t, tt.wantAliasIPRanges, instance.NetworkInterfaces[0].AliasIpRanges)
+		})
+	}
+}
+
+// This test verifies that the startup and shutdown scripts are read inline
+// or from the referenced secret.
+func TestMachineScripts(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "scripts", Namespace: "default"},
+		Data: map[string][]byte{
+			"shutdown.sh": []byte("echo shutdown"),
+		},
+	}
+	machineScope := &MachineScope{
+		client: fake.NewClientBuilder().WithObjects(secret).Build(),
+		GCPMachine: &infrav1.GCPMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-machine", Namespace: "default"},
+		},
+	}
+
+	script, err := machineScope.GetStartupScript()
+	assert.NoError(t, err)
+	assert.Empty(t, script)
+
+	machineScope.GCPMachine.Spec.StartupScript = &infrav1.MachineScript{Content: ptr.To("echo startup")}
+	machineScope.GCPMachine.Spec.ShutdownScript = &infrav1.MachineScript{
+		SecretRef: &infrav1.SecretKeyReference{Name: "scripts", Key: "shutdown.sh"},
+	}
+	script, err = machineScope.GetStartupScript()
+	assert.NoError(t, err)
+	assert.Equal(t, "echo startup", script)
+	script, err = machineScope.GetShutdownScript()
+	assert.NoError(t, err)
+	assert.Equal(t, "echo shutdown", script)
+
+	machineScope.GCPMachine.Spec.ShutdownScript.SecretRef.Key = "missing.sh"
+	_, err = machineScope.GetShutdownScript()
+	assert.Error(t, err)
+
+	machineScope.GCPMachine.Spec.ShutdownScript.SecretRef.Name = "missing"
+	_, err = machineScope.GetShutdownScript()
+	assert.Error(t, err)
+}
+
+// This test verifies that the custom hostname is set on the instance, while the
+// instance name and the provider ID are still derived from the GCPMachine name.
+func TestMachineInstanceSpecHostname(t *testing.T) {
+	machineScope := &MachineScope{
+		ClusterGetter: &ClusterScope{
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+			},
+			GCPCluster: &infrav1.GCPCluster{
+				Spec: infrav1.GCPClusterSpec{Project: "my-proj"},
+			},
+		},
+		Machine: &clusterv1.Machine{
+			Spec: clusterv1.MachineSpec{
+				FailureDomain: ptr.To("us-central1-a"),
+			},
+		},
+		GCPMachine: &infrav1.GCPMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-machine"},
+		},
+	}
+
+	instance, err := machineScope.InstanceSpec(logr.Discard())
+	require.NoError(t, err)
+	assert.Empty(t, instance.Hostname)
+
+	machineScope.GCPMachine.Spec.Hostname = ptr.To("node-1.example.com")
+	instance, err = machineScope.InstanceSpec(logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, "node-1.example.com", instance.Hostname)
+	assert.Equal(t, "my-machine", instance.Name)
+	machineScope.SetProviderID()
+	assert.Equal(t, ptr.To("gce://my-proj/us-central1-a/my-machine"), machineScope.GCPMachine.Spec.ProviderID)
+}
+
+// This test verifies that the network performance configuration is set on the instance.
+func TestMachineInstanceSpecNetworkPerformanceConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       *infrav1.NetworkPerformanceConfig
+		want         *compute.NetworkPerformanceConfig
+		wantNicType  string
+		wantFeatures []string
+	}{
+		{
+			name:         "no network performance configuration",
+			wantFeatures: []string{},
+		},
+		{
+			name:         "default egress bandwidth tier",
+			config:       &infrav1.NetworkPerformanceConfig{TotalEgressBandwidthTier: infrav1.EgressBandwidthTierDefault},
+			want:         &compute.NetworkPerformanceConfig{TotalEgressBandwidthTier: "DEFAULT"},
+			wantFeatures: []string{},
+		},
+		{
+			name:         "Tier_1 egress bandwidth tier (should use a gVNIC)",
+			config:       &infrav1.NetworkPerformanceConfig{TotalEgressBandwidthTier: infrav1.EgressBandwidthTierTier1},
+			want:         &compute.NetworkPerformanceConfig{TotalEgressBandwidthTier: "TIER_1"},
+			wantNicType:  "GVNIC",
+			wantFeatures: []string{"GVNIC"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machineScope := &MachineScope{
+				ClusterGetter: &ClusterScope{
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+					},
+					GCPCluster: &infrav1.GCPCluster{
+						Spec: infrav1.GCPClusterSpec{Project: "my-proj"},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					Spec: clusterv1.MachineSpec{
+						FailureDomain: ptr.To("us-central1-a"),
+					},
+				},
+				GCPMachine: &infrav1.GCPMachine{
+					Spec: infrav1.GCPMachineSpec{
+						InstanceType:             "n2-standard-32",
+						NetworkPerformanceConfig: tt.config,
+					},
+				},
+			}
+
+			instance, err := machineScope.InstanceSpec(logr.Discard())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, instance.NetworkPerformanceConfig)
+			assert.Equal(t, tt.wantNicType, instance.NetworkInterfaces[0].NicType)
+			assert.Equal(t, tt.wantFeatures, machineScope.RequiredGuestOSFeatures())
+		})
+	}
+}
+
+// This test verifies that the display device is only set on the instance when enabled.
+func TestMachineInstanceSpecDisplayDevice(t *testing.T) {
+	machineScope := &MachineScope{
+		ClusterGetter: &ClusterScope{
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+			},
+			GCPCluster: &infrav1.GCPCluster{
+				Spec: infrav1.GCPClusterSpec{Project: "my-proj"},
+			},
+		},
+		Machine: &clusterv1.Machine{
+			Spec: clusterv1.MachineSpec{
+				FailureDomain: ptr.To("us-central1-a"),
+			},
+		},
+		GCPMachine: &infrav1.GCPMachine{},
+	}
+
+	instance, err := machineScope.InstanceSpec(logr.Discard())
+	require.NoError(t, err)
+	assert.Nil(t, instance.DisplayDevice)
+
+	machineScope.GCPMachine.Spec.EnableDisplayDevice = true
+	instance, err = machineScope.InstanceSpec(logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, &compute.DisplayDevice{EnableDisplay: true}, instance.DisplayDevice)
+}
+
+// This test verifies that the advanced machine features and the minimum
+// CPU platform are set on the instance.
+func TestMachineInstanceSpecAdvancedMachineFeatures(t *testing.T) {
+	machineScope := &MachineScope{
+		ClusterGetter: &ClusterScope{
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"},
+			},
+			GCPCluster: &infrav1.GCPCluster{
+				Spec: infrav1.GCPClusterSpec{Project: "my-proj"},
+			},
+		},
+		Machine: &clusterv1.Machine{
+			Spec: clusterv1.MachineSpec{
+				FailureDomain: ptr.To("us-central1-a"),
+			},
+		},
+		GCPMachine: &infrav1.GCPMachine{
+			Spec: infrav1.GCPMachineSpec{
+				AdvancedMachineFeatures: &infrav1.AdvancedMachineFeatures{
+					EnableNestedVirtualization: true,
+					ThreadsPerCore:             ptr.To[int64](1),
+					VisibleCoreCount:           ptr.To[int64](2),
+				},
+				MinCPUPlatform: ptr.To("Intel Haswell"),
+			},
+		},
+	}
+
+	instance, err := machineScope.InstanceSpec(logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, &compute.AdvancedMachineFeatures{
+		EnableNestedVirtualization: true,
+		ThreadsPerCore:             1,
+		VisibleCoreCount:           2,
+	}, instance.AdvancedMachineFeatures)
+	assert.Equal(t, "Intel Haswell", instance.MinCpuPlatform)
+}
+
+// This test verifies that the scopes of a user provided service account
+// replace the default cloud-platform scope.
+func TestMachineInstanceServiceAccountsSpec(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceAccount *infrav1.ServiceAccount
+		want           *compute.ServiceAccount
+	}{
+		{
+			name: "default service account and scope",
+			want: &compute.ServiceAccount{
+				Email:  "default",
+				Scopes: []string{compute.CloudPlatformScope},
+			},
+		},
+		{
+			name: "explicit scopes replace the default scope",
+			serviceAccount: &infrav1.ServiceAccount{
+				Email:  "nodes@my-proj.iam.gserviceaccount.com",
+				Scopes: []string{"https://www.googleapis.com/auth/devstorage.read_only", "https://www.googleapis.com/auth/logging.write"},
+			},
+			want: &compute.ServiceAccount{
+				Email:  "nodes@my-proj.iam.gserviceaccount.com",
+				Scopes: []string{"https://www.googleapis.com/auth/devstorage.read_only", "https://www.googleapis.com/auth/logging.write"},
+			},
+		},
+		{
+			name: "explicit scopes with the default service account",
+			serviceAccount: &infrav1.ServiceAccount{
+				Scopes: []string{"https://www.googleapis.com/auth/devstorage.read_only"},
+			},
+			want: &compute.ServiceAccount{
+				Email:  "default",
+				Scopes: []string{"https://www.googleapis.com/auth/devstorage.read_only"},
+			},
+		},
+		{
+			name: "scope aliases are resolved to their URL",
+			serviceAccount: &infrav1.ServiceAccount{
+				Scopes: []string{"storage-ro", "https://www.googleapis.com/auth/logging.write"},
+			},
+			want: &compute.ServiceAccount{
+				Email:  "default",
+				Scopes: []string{"https://www.googleapis.com/auth/devstorage.read_only", "https://www.googleapis.com/auth/logging.write"},
+			},
+		},
+		{
+			name: "empty scopes do not fall back to the default scope",
+			serviceAccount: &infrav1.ServiceAccount{
+				Email:  "nodes@my-proj.iam.gserviceaccount.com",
+				Scopes: []string{},
+			},
+			want: &compute.ServiceAccount{
+				Email:  "nodes@my-proj.iam.gserviceaccount.com",
+				Scopes: []string{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machineScope := &MachineScope{
+				GCPMachine: &infrav1.GCPMachine{
+					Spec: infrav1.GCPMachineSpec{
+						ServiceAccount: tt.serviceAccount,
+					},
+				},
+			}
+
+			assert.Equal(t, tt.want, machineScope.InstanceServiceAccountsSpec())
+		})
+	}
+}
+
+// This test verifies that the network interface of an instance references the
+// networking project while the instance itself is created in the compute project.
+func TestMachineInstanceNetworkInterfaceSpec(t *testing.T) {
+	tests := []struct {
+		name        string
+		hostProject *string
+		want        *compute.NetworkInterface
+	}{
+		{
+			name: "network in the compute project",
+			want: &compute.NetworkInterface{
+				Network:    "projects/my-proj/global/networks/my-network",
+				Subnetwork: "projects/my-proj/regions/us-central1/subnetworks/my-subnet",
+			},
+		},
+		{
+			name:        "network in a host project",
+			hostProject: ptr.To("my-host-proj"),
+			want: &compute.NetworkInterface{
+				Network:    "projects/my-host-proj/global/networks/my-network",
+				Subnetwork: "projects/my-host-proj/regions/us-central1/subnetworks/my-subnet",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machineScope := &MachineScope{
+				ClusterGetter: &ClusterScope{
+					GCPCluster: &infrav1.GCPCluster{
+						Spec: infrav1.GCPClusterSpec{
+							Project: "my-proj",
+							Region:  "us-central1",
+							Network: infrav1.NetworkSpec{
+								Name:        ptr.To("my-network"),
+								HostProject: tt.hostProject,
+							},
+						},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					Spec: clusterv1.MachineSpec{FailureDomain: ptr.To("us-central1-a")},
+				},
+				GCPMachine: &infrav1.GCPMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-machine"},
+					Spec: infrav1.GCPMachineSpec{
+						Subnet: ptr.To("my-subnet"),
+					},
+				},
+			}
+
+			assert.Equal(t, tt.want, machineScope.InstanceNetworkInterfaceSpec())
+			assert.Equal(t, "my-proj", machineScope.Project())
+			machineScope.SetProviderID()
+			assert.Equal(t, ptr.To("gce://my-proj/us-central1-a/my-machine"), machineScope.GCPMachine.Spec.ProviderID)
+		})
+	}
+}
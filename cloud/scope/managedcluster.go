@@ -23,6 +23,7 @@ import (
 
 	"github.com/pkg/errors"
 	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/storage/v1"
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
@@ -39,6 +40,8 @@ type ManagedClusterScopeParams struct {
 	Cluster                *clusterv1.Cluster
 	GCPManagedCluster      *infrav1exp.GCPManagedCluster
 	GCPManagedControlPlane *infrav1exp.GCPManagedControlPlane
+	// DryRun makes the changes to GCP resources reported without being applied.
+	DryRun bool
 }
 
 // NewManagedClusterScope creates a new Scope from the supplied parameters.
@@ -72,6 +75,8 @@ func NewManagedClusterScope(ctx context.Context, params ManagedClusterScopeParam
 		GCPManagedControlPlane: params.GCPManagedControlPlane,
 		GCPServices:            params.GCPServices,
 		patchHelper:            helper,
+		dryRun:                 params.DryRun,
+		rateLimiter:            &GCPRateLimiter{},
 	}, nil
 }
 
@@ -79,6 +84,8 @@ func NewManagedClusterScope(ctx context.Context, params ManagedClusterScopeParam
 type ManagedClusterScope struct {
 	client      client.Client
 	patchHelper *patch.Helper
+	dryRun      bool
+	rateLimiter cloud.RateLimiter
 
 	Cluster                *clusterv1.Cluster
 	GCPManagedCluster      *infrav1exp.GCPManagedCluster
@@ -90,12 +97,46 @@ type ManagedClusterScope struct {
 
 // Cloud returns initialized cloud.
 func (s *ManagedClusterScope) Cloud() cloud.Cloud {
-	return newCloud(s.Project(), s.GCPServices)
+	return newCloud(s.Project(), s.GCPServices, s.rateLimiter)
 }
 
 // NetworkCloud returns initialized cloud.
 func (s *ManagedClusterScope) NetworkCloud() cloud.Cloud {
-	return newCloud(s.NetworkProject(), s.GCPServices)
+	return newCloud(s.NetworkProject(), s.GCPServices, s.rateLimiter)
+}
+
+// ComputeService returns the compute API client, for the calls not covered by Cloud.
+func (s *ManagedClusterScope) ComputeService() *compute.Service {
+	return s.Compute
+}
+
+// RateLimiter returns the rate limiter of Cloud, for the calls made with ComputeService.
+func (s *ManagedClusterScope) RateLimiter() cloud.RateLimiter {
+	return s.rateLimiter
+}
+
+// StorageService returns the storage API client, which is only created when first needed.
+func (s *ManagedClusterScope) StorageService(ctx context.Context) (*storage.Service, error) {
+	if s.Storage == nil {
+		storageSvc, err := newStorageService(ctx, s.GCPManagedCluster.Spec.CredentialsRef, s.client, s.GCPManagedCluster.Spec.ServiceEndpoints)
+		if err != nil {
+			return nil, errors.Errorf("failed to create gcp storage client: %v", err)
+		}
+
+		s.Storage = storageSvc
+	}
+
+	return s.Storage, nil
+}
+
+// CredentialsServiceAccount returns the email of the service account of the cluster credentials.
+func (s *ManagedClusterScope) CredentialsServiceAccount(ctx context.Context) (string, error) {
+	return credentialsServiceAccount(ctx, s.GCPManagedCluster.Spec.CredentialsRef, s.client)
+}
+
+// SignBlob signs the payload with the given service account.
+func (s *ManagedClusterScope) SignBlob(ctx context.Context, serviceAccount string, payload []byte) ([]byte, error) {
+	return signBlob(ctx, s.GCPManagedCluster.Spec.CredentialsRef, s.client, s.GCPManagedCluster.Spec.ServiceEndpoints, serviceAccount, payload)
 }
 
 // Project returns the current project name.
@@ -175,6 +216,16 @@ func (s *ManagedClusterScope) FailureDomains() clusterv1.FailureDomains {
 	return s.GCPManagedCluster.Status.FailureDomains
 }
 
+// DryRun returns true if the changes to GCP resources are reported without being applied.
+func (s *ManagedClusterScope) DryRun() bool {
+	return s.dryRun || hasDryRunAnnotation(s.GCPManagedCluster)
+}
+
+// RecordDryRun records a change to a GCP resource which is skipped in dry-run mode.
+func (s *ManagedClusterScope) RecordDryRun(action, kind, name string) {
+	recordDryRun(s.GCPManagedCluster, action, kind, name)
+}
+
 // ANCHOR_END: ClusterGetter
 
 // ANCHOR: ClusterSetter
@@ -28,6 +28,7 @@ import (
 	credentials "cloud.google.com/go/iam/credentials/apiv1"
 	resourcemanager "cloud.google.com/go/resourcemanager/apiv3"
 	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
@@ -49,6 +50,8 @@ type ManagedControlPlaneScopeParams struct {
 	Cluster                *clusterv1.Cluster
 	GCPManagedCluster      *infrav1exp.GCPManagedCluster
 	GCPManagedControlPlane *infrav1exp.GCPManagedControlPlane
+	// DryRun makes the changes to GCP resources reported without being applied.
+	DryRun bool
 }
 
 // NewManagedControlPlaneScope creates a new Scope from the supplied parameters.
@@ -107,6 +110,7 @@ func NewManagedControlPlaneScope(ctx context.Context, params ManagedControlPlane
 		credentialsClient:      params.CredentialsClient,
 		credential:             credential,
 		patchHelper:            helper,
+		dryRun:                 params.DryRun,
 	}, nil
 }
 
@@ -114,6 +118,7 @@ func NewManagedControlPlaneScope(ctx context.Context, params ManagedControlPlane
 type ManagedControlPlaneScope struct {
 	client      client.Client
 	patchHelper *patch.Helper
+	dryRun      bool
 
 	Cluster                *clusterv1.Cluster
 	GCPManagedCluster      *infrav1exp.GCPManagedCluster
@@ -153,6 +158,17 @@ func (s *ManagedControlPlaneScope) ConditionSetter() conditions.Setter {
 	return s.GCPManagedControlPlane
 }
 
+// DryRun returns true if the changes to GCP resources are reported without being applied, which is the case when
+// either the GCPManagedControlPlane or the GCPManagedCluster is in dry-run mode.
+func (s *ManagedControlPlaneScope) DryRun() bool {
+	return s.dryRun || hasDryRunAnnotation(s.GCPManagedControlPlane) || hasDryRunAnnotation(s.GCPManagedCluster)
+}
+
+// RecordDryRun records a change to a GCP resource which is skipped in dry-run mode.
+func (s *ManagedControlPlaneScope) RecordDryRun(action, kind, name string) {
+	recordDryRun(s.GCPManagedControlPlane, action, kind, name)
+}
+
 // Client returns a k8s client.
 func (s *ManagedControlPlaneScope) Client() client.Client {
 	return s.client
@@ -178,7 +194,8 @@ func (s *ManagedControlPlaneScope) GetCredential() *Credential {
 	return s.credential
 }
 
-// GetAllNodePools gets all node pools for the control plane.
+// GetAllNodePools gets all node pools for the control plane. Machine pools that are being deleted are excluded, and
+// each GCPManagedMachinePool is returned at the same index as the MachinePool referencing it.
 func (s *ManagedControlPlaneScope) GetAllNodePools(ctx context.Context) ([]infrav1exp.GCPManagedMachinePool, []clusterv1exp.MachinePool, error) {
 	if len(s.AllManagedMachinePools) == 0 {
 		listOptions := []client.ListOption{
@@ -194,11 +211,31 @@ func (s *ManagedControlPlaneScope) GetAllNodePools(ctx context.Context) ([]infra
 		if err := s.client.List(ctx, managedMachinePoolList, listOptions...); err != nil {
 			return nil, nil, err
 		}
-		if len(machinePoolList.Items) != len(managedMachinePoolList.Items) {
-			return nil, nil, fmt.Errorf("machinePoolList length (%d) != managedMachinePoolList length (%d)", len(machinePoolList.Items), len(managedMachinePoolList.Items))
+
+		managedMachinePoolsByName := make(map[string]infrav1exp.GCPManagedMachinePool, len(managedMachinePoolList.Items))
+		for _, managedMachinePool := range managedMachinePoolList.Items {
+			if managedMachinePool.DeletionTimestamp.IsZero() {
+				managedMachinePoolsByName[managedMachinePool.Name] = managedMachinePool
+			}
+		}
+
+		machinePools := []clusterv1exp.MachinePool{}
+		managedMachinePools := []infrav1exp.GCPManagedMachinePool{}
+		for _, machinePool := range machinePoolList.Items {
+			if !machinePool.DeletionTimestamp.IsZero() {
+				continue
+			}
+			infraRefName := machinePool.Spec.Template.Spec.InfrastructureRef.Name
+			managedMachinePool, ok := managedMachinePoolsByName[infraRefName]
+			if !ok {
+				return nil, nil, fmt.Errorf("GCPManagedMachinePool %s referenced by MachinePool %s not found", infraRefName, machinePool.Name)
+			}
+			machinePools = append(machinePools, machinePool)
+			managedMachinePools = append(managedMachinePools, managedMachinePool)
 		}
-		s.AllMachinePools = machinePoolList.Items
-		s.AllManagedMachinePools = managedMachinePoolList.Items
+
+		s.AllMachinePools = machinePools
+		s.AllManagedMachinePools = managedMachinePools
 	}
 
 	return s.AllManagedMachinePools, s.AllMachinePools, nil
@@ -210,6 +247,11 @@ func (s *ManagedControlPlaneScope) Region() string {
 	return loc.Region
 }
 
+// NetworkProject returns the project name where the network of the GKE cluster exists.
+func (s *ManagedControlPlaneScope) NetworkProject() string {
+	return ptr.Deref(s.GCPManagedCluster.Spec.Network.HostProject, s.GCPManagedControlPlane.Spec.Project)
+}
+
 // ClusterLocation returns the location of the cluster.
 func (s *ManagedControlPlaneScope) ClusterLocation() string {
 	return fmt.Sprintf("projects/%s/locations/%s", s.GCPManagedControlPlane.Spec.Project, s.GCPManagedControlPlane.Spec.Location)
@@ -0,0 +1,120 @@
+package scope
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("GCPManagedControlPlane Scope", func() {
+	var (
+		namespace   = "capg-system"
+		clusterName = "test-cluster"
+		objects     []client.Object
+	)
+
+	newMachinePools := func(name string, deleting bool) []client.Object {
+		objectMeta := metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: clusterName},
+		}
+		if deleting {
+			objectMeta.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			objectMeta.Finalizers = []string{"test"}
+		}
+		machinePool := &clusterv1exp.MachinePool{
+			ObjectMeta: *objectMeta.DeepCopy(),
+			Spec: clusterv1exp.MachinePoolSpec{
+				Replicas: ptr.To[int32](1),
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{
+						InfrastructureRef: corev1.ObjectReference{Name: name + "-mmp"},
+					},
+				},
+			},
+		}
+		objectMeta.Name = name + "-mmp"
+		managedMachinePool := &v1beta1.GCPManagedMachinePool{
+			ObjectMeta: objectMeta,
+		}
+		return []client.Object{machinePool, managedMachinePool}
+	}
+
+	newScope := func() *ManagedControlPlaneScope {
+		scheme := runtime.NewScheme()
+		Expect(clusterv1exp.AddToScheme(scheme)).To(Succeed())
+		Expect(v1beta1.AddToScheme(scheme)).To(Succeed())
+
+		c := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objects...).
+			Build()
+
+		return &ManagedControlPlaneScope{
+			client: c,
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: namespace},
+			},
+			GCPManagedControlPlane: &v1beta1.GCPManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: namespace},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		objects = nil
+	})
+
+	Context("Test GetAllNodePools", func() {
+		It("should pair each managed machine pool with the machine pool referencing it", func() {
+			objects = append(objects, newMachinePools("pool-b", false)...)
+			objects = append(objects, newMachinePools("pool-a", false)...)
+			objects = append(objects, &v1beta1.GCPManagedMachinePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "aaa-unreferenced",
+					Namespace: namespace,
+					Labels:    map[string]string{clusterv1.ClusterNameLabel: clusterName},
+				},
+			})
+
+			managedMachinePools, machinePools, err := newScope().GetAllNodePools(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(managedMachinePools).To(HaveLen(2))
+			Expect(machinePools).To(HaveLen(2))
+			for i := range machinePools {
+				Expect(machinePools[i].Spec.Template.Spec.InfrastructureRef.Name).To(Equal(managedMachinePools[i].Name))
+			}
+		})
+
+		It("should fail when the managed machine pool of a machine pool is missing", func() {
+			objects = append(objects, newMachinePools("pool-a", false)[0])
+
+			_, _, err := newScope().GetAllNodePools(context.TODO())
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should exclude node pools pending deletion", func() {
+			objects = append(objects, newMachinePools("pool-a", false)...)
+			objects = append(objects, newMachinePools("pool-b", true)...)
+
+			managedMachinePools, machinePools, err := newScope().GetAllNodePools(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(managedMachinePools).To(HaveLen(1))
+			Expect(managedMachinePools[0].Name).To(Equal("pool-a-mmp"))
+			Expect(machinePools).To(HaveLen(1))
+			Expect(machinePools[0].Name).To(Equal("pool-a"))
+		})
+	})
+})
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
@@ -39,6 +40,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// maxNodePoolVersionSkew is the maximum number of minor versions the nodes of a node pool can be older than the
+// control plane, as per the GKE version skew policy.
+const maxNodePoolVersionSkew = 2
+
 // ManagedMachinePoolScopeParams defines the input parameters used to create a new Scope.
 type ManagedMachinePoolScopeParams struct {
 	ManagedClusterClient        *container.ClusterManagerClient
@@ -49,6 +54,8 @@ type ManagedMachinePoolScopeParams struct {
 	GCPManagedCluster           *infrav1exp.GCPManagedCluster
 	GCPManagedControlPlane      *infrav1exp.GCPManagedControlPlane
 	GCPManagedMachinePool       *infrav1exp.GCPManagedMachinePool
+	// DryRun makes the changes to GCP resources reported without being applied.
+	DryRun bool
 }
 
 // NewManagedMachinePoolScope creates a new Scope from the supplied parameters.
@@ -94,11 +101,13 @@ func NewManagedMachinePoolScope(ctx context.Context, params ManagedMachinePoolSc
 		client:                 params.Client,
 		Cluster:                params.Cluster,
 		MachinePool:            params.MachinePool,
+		GCPManagedCluster:      params.GCPManagedCluster,
 		GCPManagedControlPlane: params.GCPManagedControlPlane,
 		GCPManagedMachinePool:  params.GCPManagedMachinePool,
 		mcClient:               params.ManagedClusterClient,
 		migClient:              params.InstanceGroupManagersClient,
 		patchHelper:            helper,
+		dryRun:                 params.DryRun,
 	}, nil
 }
 
@@ -106,6 +115,7 @@ func NewManagedMachinePoolScope(ctx context.Context, params ManagedMachinePoolSc
 type ManagedMachinePoolScope struct {
 	client      client.Client
 	patchHelper *patch.Helper
+	dryRun      bool
 
 	Cluster                *clusterv1.Cluster
 	MachinePool            *clusterv1exp.MachinePool
@@ -141,6 +151,17 @@ func (s *ManagedMachinePoolScope) ConditionSetter() conditions.Setter {
 	return s.GCPManagedMachinePool
 }
 
+// DryRun returns true if the changes to GCP resources are reported without being applied, which is the case when
+// either the GCPManagedMachinePool or the GCPManagedCluster is in dry-run mode.
+func (s *ManagedMachinePoolScope) DryRun() bool {
+	return s.dryRun || hasDryRunAnnotation(s.GCPManagedMachinePool) || hasDryRunAnnotation(s.GCPManagedCluster)
+}
+
+// RecordDryRun records a change to a GCP resource which is skipped in dry-run mode.
+func (s *ManagedMachinePoolScope) RecordDryRun(action, kind, name string) {
+	recordDryRun(s.GCPManagedMachinePool, action, kind, name)
+}
+
 // ManagedMachinePoolClient returns a client used to interact with GKE.
 func (s *ManagedMachinePoolScope) ManagedMachinePoolClient() *container.ClusterManagerClient {
 	return s.mcClient
@@ -151,9 +172,44 @@ func (s *ManagedMachinePoolScope) InstanceGroupManagersClient() *compute.Instanc
 	return s.migClient
 }
 
-// NodePoolVersion returns the k8s version of the node pool.
+// NodePoolVersion returns the k8s version of the node pool. The node version of the GCPManagedMachinePool
+// takes precedence over the version of the MachinePool.
 func (s *ManagedMachinePoolScope) NodePoolVersion() *string {
-	return s.MachinePool.Spec.Template.Spec.Version
+	return nodePoolVersion(*s.GCPManagedMachinePool, *s.MachinePool)
+}
+
+func nodePoolVersion(nodePool infrav1exp.GCPManagedMachinePool, machinePool clusterv1exp.MachinePool) *string {
+	if nodePool.Spec.NodeVersion != nil {
+		return nodePool.Spec.NodeVersion
+	}
+	return machinePool.Spec.Template.Spec.Version
+}
+
+// ValidateNodePoolVersion checks that the node version of the GCPManagedMachinePool complies with the GKE version
+// skew policy relative to the current version of the control plane: the nodes can't be newer than the control
+// plane, nor more than two minor versions older. The version of the MachinePool is not checked, as it is
+// expected to be ahead of the control plane during an upgrade.
+func (s *ManagedMachinePoolScope) ValidateNodePoolVersion() error {
+	nodeVersion := s.GCPManagedMachinePool.Spec.NodeVersion
+	if nodeVersion == nil || s.GCPManagedControlPlane.Status.CurrentVersion == "" {
+		return nil
+	}
+	nodeVer, err := version.ParseGeneric(*nodeVersion)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse node pool version %s", *nodeVersion)
+	}
+	controlPlaneVer, err := version.ParseGeneric(s.GCPManagedControlPlane.Status.CurrentVersion)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse control plane version %s", s.GCPManagedControlPlane.Status.CurrentVersion)
+	}
+	if nodeVer.Major() != controlPlaneVer.Major() || nodeVer.Minor() > controlPlaneVer.Minor() {
+		return errors.Errorf("node pool version %s is newer than the control plane version %s", *nodeVersion, s.GCPManagedControlPlane.Status.CurrentVersion)
+	}
+	if controlPlaneVer.Minor()-nodeVer.Minor() > maxNodePoolVersionSkew {
+		return errors.Errorf("node pool version %s is more than %d minor versions older than the control plane version %s",
+			*nodeVersion, maxNodePoolVersionSkew, s.GCPManagedControlPlane.Status.CurrentVersion)
+	}
+	return nil
 }
 
 // NodePoolResourceLabels returns the resource labels of the node pool.
@@ -216,6 +272,12 @@ func ConvertToSdkNodePool(nodePool infrav1exp.GCPManagedMachinePool, machinePool
 	if nodePool.Spec.LinuxNodeConfig != nil {
 		sdkNodePool.Config.LinuxNodeConfig = infrav1exp.ConvertToSdkLinuxNodeConfig(nodePool.Spec.LinuxNodeConfig)
 	}
+	if nodePool.Spec.EphemeralStorageLocalSsdConfig != nil {
+		sdkNodePool.Config.EphemeralStorageLocalSsdConfig = infrav1exp.ConvertToSdkEphemeralStorageLocalSsdConfig(nodePool.Spec.EphemeralStorageLocalSsdConfig)
+	}
+	if nodePool.Spec.GcfsConfig != nil {
+		sdkNodePool.Config.GcfsConfig = infrav1exp.ConvertToSdkGcfsConfig(nodePool.Spec.GcfsConfig)
+	}
 	if nodePool.Spec.Management != nil {
 		sdkNodePool.Management = &containerpb.NodeManagement{
 			AutoRepair:  nodePool.Spec.Management.AutoRepair,
@@ -251,16 +313,13 @@ func ConvertToSdkNodePool(nodePool infrav1exp.GCPManagedMachinePool, machinePool
 	if len(nodePool.Spec.NodeLocations) != 0 {
 		sdkNodePool.Locations = nodePool.Spec.NodeLocations
 	}
-	if nodePool.Spec.MaxPodsPerNode != nil {
-		sdkNodePool.MaxPodsConstraint = &containerpb.MaxPodsConstraint{
-			MaxPodsPerNode: *nodePool.Spec.MaxPodsPerNode,
-		}
-	}
-	if nodePool.Spec.NodeNetwork.CreatePodRange != nil && nodePool.Spec.NodeNetwork.PodRangeName != nil && nodePool.Spec.NodeNetwork.PodRangeCidrBlock != nil {
+	// The pods of the node pool are assigned IPs from the given secondary range, either an existing one
+	// or a new one created along with the node pool.
+	if nodePool.Spec.NodeNetwork.PodRangeName != nil {
 		sdkNodePool.NetworkConfig = &containerpb.NodeNetworkConfig{
-			CreatePodRange:   *nodePool.Spec.NodeNetwork.CreatePodRange,
+			CreatePodRange:   ptr.Deref(nodePool.Spec.NodeNetwork.CreatePodRange, false),
 			PodRange:         *nodePool.Spec.NodeNetwork.PodRangeName,
-			PodIpv4CidrBlock: *nodePool.Spec.NodeNetwork.PodRangeCidrBlock,
+			PodIpv4CidrBlock: ptr.Deref(nodePool.Spec.NodeNetwork.PodRangeCidrBlock, ""),
 		}
 	}
 
@@ -269,8 +328,8 @@ func ConvertToSdkNodePool(nodePool infrav1exp.GCPManagedMachinePool, machinePool
 			Type: containerpb.SandboxConfig_GVISOR,
 		}
 	}
-	if machinePool.Spec.Template.Spec.Version != nil {
-		sdkNodePool.Version = strings.Replace(*machinePool.Spec.Template.Spec.Version, "v", "", 1)
+	if nodeVersion := nodePoolVersion(nodePool, machinePool); nodeVersion != nil {
+		sdkNodePool.Version = strings.Replace(*nodeVersion, "v", "", 1)
 	}
 	return &sdkNodePool
 }
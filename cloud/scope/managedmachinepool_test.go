@@ -5,6 +5,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
 	"sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
@@ -82,6 +83,25 @@ var _ = Describe("GCPManagedMachinePool Scope", func() {
 			}))
 		})
 
+		It("should convert to SDK node pool in a subset of the zones of a regional cluster", func() {
+			replicas := int32(4)
+			nodeLocations := []string{"us-central1-a", "us-central1-b"}
+			TestMP.Spec.Replicas = &replicas
+			TestGCPMMP.Spec.NodeLocations = nodeLocations
+
+			sdkNodePool := ConvertToSdkNodePool(*TestGCPMMP, *TestMP, true, TestClusterName)
+
+			Expect(sdkNodePool).To(Equal(&containerpb.NodePool{
+				Name:             TestGCPMMP.Spec.NodePoolName,
+				InitialNodeCount: replicas / int32(len(nodeLocations)),
+				Locations:        nodeLocations,
+				Config: &containerpb.NodeConfig{
+					ResourceLabels:         NodePoolResourceLabels(nil, TestClusterName),
+					ShieldedInstanceConfig: &containerpb.ShieldedInstanceConfig{},
+				},
+			}))
+		})
+
 		It("should convert to SDK node pool using GCPManagedMachinePool", func() {
 			machineType := "n1-standard-1"
 			diskSizeGb := int32(128)
@@ -136,5 +156,127 @@ var _ = Describe("GCPManagedMachinePool Scope", func() {
 				},
 			}))
 		})
+
+		It("should convert to SDK node pool drawing pod IPs from an existing secondary range", func() {
+			TestGCPMMP.Spec.NodeNetwork.PodRangeName = ptr.To("pods-a")
+
+			sdkNodePool := ConvertToSdkNodePool(*TestGCPMMP, *TestMP, false, TestClusterName)
+
+			Expect(sdkNodePool.GetNetworkConfig()).To(Equal(&containerpb.NodeNetworkConfig{
+				PodRange: "pods-a",
+			}))
+		})
+
+		It("should convert to SDK node pool creating its pod secondary range", func() {
+			TestGCPMMP.Spec.NodeNetwork = v1beta1.NodeNetworkConfig{
+				CreatePodRange:    ptr.To(true),
+				PodRangeName:      ptr.To("pods-b"),
+				PodRangeCidrBlock: ptr.To("10.101.0.0/16"),
+			}
+			TestGCPMMP.Spec.MaxPodsPerNode = ptr.To[int64](32)
+
+			sdkNodePool := ConvertToSdkNodePool(*TestGCPMMP, *TestMP, false, TestClusterName)
+
+			Expect(sdkNodePool.GetNetworkConfig()).To(Equal(&containerpb.NodeNetworkConfig{
+				CreatePodRange:   true,
+				PodRange:         "pods-b",
+				PodIpv4CidrBlock: "10.101.0.0/16",
+			}))
+			Expect(sdkNodePool.GetMaxPodsConstraint().GetMaxPodsPerNode()).To(Equal(int64(32)))
+		})
+
+		It("should convert to SDK node pool with a pool-level max pods constraint", func() {
+			TestGCPMMP.Spec.MaxPodsPerNode = ptr.To[int64](64)
+
+			sdkNodePool := ConvertToSdkNodePool(*TestGCPMMP, *TestMP, false, TestClusterName)
+
+			Expect(sdkNodePool.GetMaxPodsConstraint()).To(Equal(&containerpb.MaxPodsConstraint{MaxPodsPerNode: 64}))
+		})
+
+		It("should convert to SDK node pool with ephemeral storage on local SSDs", func() {
+			TestGCPMMP.Spec.EphemeralStorageLocalSsdConfig = &v1beta1.EphemeralStorageLocalSsdConfig{LocalSsdCount: 2}
+
+			sdkNodePool := ConvertToSdkNodePool(*TestGCPMMP, *TestMP, false, TestClusterName)
+
+			Expect(sdkNodePool.GetConfig().GetEphemeralStorageLocalSsdConfig()).To(Equal(&containerpb.EphemeralStorageLocalSsdConfig{LocalSsdCount: 2}))
+		})
+
+		It("should convert to SDK node pool with image streaming enabled", func() {
+			TestGCPMMP.Spec.GcfsConfig = &v1beta1.GcfsConfig{Enabled: true}
+
+			sdkNodePool := ConvertToSdkNodePool(*TestGCPMMP, *TestMP, false, TestClusterName)
+
+			Expect(sdkNodePool.GetConfig().GetGcfsConfig()).To(Equal(&containerpb.GcfsConfig{Enabled: true}))
+		})
+	})
+
+	Context("Test node pool version", func() {
+		var (
+			gcpmmp           *v1beta1.GCPManagedMachinePool
+			mp               *clusterv1exp.MachinePool
+			machinePoolScope *ManagedMachinePoolScope
+		)
+
+		BeforeEach(func() {
+			// The specs mutate the versions, work on copies of the shared fixtures.
+			gcpmmp = TestGCPMMP.DeepCopy()
+			mp = TestMP.DeepCopy()
+			mp.Spec.Template.Spec.Version = ptr.To("v1.30.5")
+			machinePoolScope = &ManagedMachinePoolScope{
+				MachinePool:           mp,
+				GCPManagedMachinePool: gcpmmp,
+				GCPManagedControlPlane: &v1beta1.GCPManagedControlPlane{
+					Status: v1beta1.GCPManagedControlPlaneStatus{CurrentVersion: "1.30.5"},
+				},
+			}
+		})
+
+		It("should use the version of the MachinePool without a node version", func() {
+			Expect(machinePoolScope.NodePoolVersion()).To(Equal(ptr.To("v1.30.5")))
+			Expect(ConvertToSdkNodePool(*gcpmmp, *mp, false, TestClusterName).GetVersion()).To(Equal("1.30.5"))
+			Expect(machinePoolScope.ValidateNodePoolVersion()).To(Succeed())
+		})
+
+		It("should keep the nodes on the node version during a staged upgrade of the control plane", func() {
+			gcpmmp.Spec.NodeVersion = ptr.To("1.30.5")
+			mp.Spec.Template.Spec.Version = ptr.To("v1.31.1")
+			machinePoolScope.GCPManagedControlPlane.Status.CurrentVersion = "1.31.1"
+
+			Expect(machinePoolScope.NodePoolVersion()).To(Equal(ptr.To("1.30.5")))
+			Expect(ConvertToSdkNodePool(*gcpmmp, *mp, false, TestClusterName).GetVersion()).To(Equal("1.30.5"))
+			Expect(machinePoolScope.ValidateNodePoolVersion()).To(Succeed())
+
+			// Once the control plane is upgraded, the nodes follow.
+			gcpmmp.Spec.NodeVersion = ptr.To("1.31.1")
+			Expect(ConvertToSdkNodePool(*gcpmmp, *mp, false, TestClusterName).GetVersion()).To(Equal("1.31.1"))
+			Expect(machinePoolScope.ValidateNodePoolVersion()).To(Succeed())
+		})
+
+		It("should not validate the version of the MachinePool without a node version", func() {
+			mp.Spec.Template.Spec.Version = ptr.To("v1.31.1")
+
+			Expect(machinePoolScope.ValidateNodePoolVersion()).To(Succeed())
+		})
+
+		It("should reject a node version newer than the control plane", func() {
+			gcpmmp.Spec.NodeVersion = ptr.To("1.31.1")
+
+			Expect(machinePoolScope.ValidateNodePoolVersion()).NotTo(Succeed())
+		})
+
+		It("should reject a node version more than two minor versions older than the control plane", func() {
+			gcpmmp.Spec.NodeVersion = ptr.To("1.28.3")
+			Expect(machinePoolScope.ValidateNodePoolVersion()).To(Succeed())
+
+			gcpmmp.Spec.NodeVersion = ptr.To("1.27.8")
+			Expect(machinePoolScope.ValidateNodePoolVersion()).NotTo(Succeed())
+		})
+
+		It("should not validate the node version before the control plane version is known", func() {
+			gcpmmp.Spec.NodeVersion = ptr.To("1.20.1")
+			machinePoolScope.GCPManagedControlPlane.Status.CurrentVersion = ""
+
+			Expect(machinePoolScope.ValidateNodePoolVersion()).To(Succeed())
+		})
 	})
 })
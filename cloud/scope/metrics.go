@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "capg"
+	metricsSubsystem = "gcp_api"
+
+	// codeOK is the code label value of a successful call.
+	codeOK = "OK"
+)
+
+var (
+	// gcpAPIRequestsTotal counts the calls made to the GCP APIs, partitioned by service, operation and result code.
+	gcpAPIRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "requests_total",
+		Help:      "Total number of calls made to the GCP APIs, partitioned by service, operation and result code.",
+	}, []string{"service", "operation", "code"})
+
+	// gcpAPIRequestDuration observes the latency of the calls made to the GCP APIs.
+	gcpAPIRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "request_duration_seconds",
+		Help:      "Latency of the calls made to the GCP APIs, partitioned by service and operation.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"service", "operation"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(gcpAPIRequestsTotal, gcpAPIRequestDuration)
+}
+
+// observeAPICall records the result code and the latency of a single GCP API call.
+func observeAPICall(service, operation string, start time.Time, code string) {
+	gcpAPIRequestsTotal.WithLabelValues(service, operation, code).Inc()
+	gcpAPIRequestDuration.WithLabelValues(service, operation).Observe(time.Since(start).Seconds())
+}
+
+// errorCode returns the code label value for the result of a gRPC call, the name of its status code.
+func errorCode(err error) string {
+	if err == nil {
+		return codeOK
+	}
+
+	return status.Code(err).String()
+}
+
+// responseCode returns the code label value for the result of a REST call, the HTTP status code of its error
+// response. Requests which got no response are reported like gRPC calls.
+func responseCode(resp *http.Response, err error) string {
+	if err != nil {
+		return errorCode(err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return strconv.Itoa(resp.StatusCode)
+	}
+
+	return codeOK
+}
+
+// metricsUnaryClientInterceptor records metrics for the calls made by the gRPC based GCP clients.
+func metricsUnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	service, operation := splitGRPCMethod(method)
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	observeAPICall(service, operation, start, errorCode(err))
+	return err
+}
+
+// splitGRPCMethod splits a full gRPC method name, e.g. "/google.container.v1.ClusterManager/GetCluster",
+// into its unqualified service name and its operation.
+func splitGRPCMethod(method string) (string, string) {
+	service, operation, found := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	if !found {
+		return "unknown", method
+	}
+
+	if i := strings.LastIndex(service, "."); i >= 0 {
+		service = service[i+1:]
+	}
+
+	return service, operation
+}
+
+// metricsRoundTripper records metrics for the requests made by the REST based GCP clients. Each request is
+// timed on its own, so that concurrent calls of the same operation are all recorded.
+type metricsRoundTripper struct {
+	next http.RoundTripper
+}
+
+// RoundTrip performs the request and records its result code and latency.
+func (rt *metricsRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	service, resource := splitRESTPath(req.URL.EscapedPath())
+	start := time.Now()
+	resp, err := rt.next.RoundTrip(req)
+	observeAPICall(service, strings.TrimSpace(req.Method+" "+resource), start, responseCode(resp, err))
+	return resp, err
+}
+
+// splitRESTPath splits the path of a REST request, e.g.
+// "/compute/v1/projects/my-project/zones/us-central1-a/instances/my-instance/setMetadata", into its API name
+// and the path of its resource relative to the project and location, with the resource names elided, e.g.
+// "instances/{}/setMetadata". Eliding the names keeps the number of operation label values bounded.
+func splitRESTPath(path string) (string, string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	version := slices.IndexFunc(segments, func(segment string) bool {
+		return len(segment) > 1 && segment[0] == 'v' && segment[1] >= '0' && segment[1] <= '9'
+	})
+	if version < 1 {
+		return "unknown", ""
+	}
+	service, resource := segments[version-1], segments[version+1:]
+
+	// Drop the project and the location the resource lives in, unless they are the resource itself.
+	for len(resource) > 1 {
+		if resource[0] == "global" {
+			resource = resource[1:]
+			continue
+		}
+		if len(resource) == 2 || !slices.Contains([]string{"projects", "regions", "zones", "locations"}, resource[0]) {
+			break
+		}
+		resource = resource[2:]
+	}
+
+	// Collections and methods alternate with the resource names, aggregated lists aside.
+	prefix := ""
+	if len(resource) > 0 && resource[0] == "aggregated" {
+		prefix, resource = "aggregated/", resource[1:]
+	}
+	elided := make([]string, len(resource))
+	for i, segment := range resource {
+		if i%2 == 1 {
+			segment = "{}"
+		}
+		elided[i] = segment
+	}
+
+	return service, prefix + strings.Join(elided, "/")
+}
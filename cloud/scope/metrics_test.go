@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// This test verifies that the HTTP client of the REST based clients records
+// each request with its HTTP status code.
+func TestMetricsRoundTripper(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &metricsRoundTripper{next: http.DefaultTransport}}
+	const operation = "GET instances/{}"
+
+	okBefore := testutil.ToFloat64(gcpAPIRequestsTotal.WithLabelValues("compute", operation, codeOK))
+	notFoundBefore := testutil.ToFloat64(gcpAPIRequestsTotal.WithLabelValues("compute", operation, "404"))
+
+	for _, name := range []string{"my-instance", "my-other-instance", "missing"} {
+		resp, err := client.Get(srv.URL + "/compute/v1/projects/my-project/zones/us-central1-a/instances/" + name)
+		assert.Nil(t, err)
+		assert.Nil(t, resp.Body.Close())
+	}
+
+	assert.Equal(t, okBefore+2, testutil.ToFloat64(gcpAPIRequestsTotal.WithLabelValues("compute", operation, codeOK)))
+	assert.Equal(t, notFoundBefore+1, testutil.ToFloat64(gcpAPIRequestsTotal.WithLabelValues("compute", operation, "404")))
+}
+
+// This test verifies that REST request paths are split into their API name and
+// the path of their resource, without the resource names.
+func TestSplitRESTPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		service  string
+		resource string
+	}{
+		{"/compute/v1/projects/my-project/zones/us-central1-a/instances/my-instance", "compute", "instances/{}"},
+		{"/compute/v1/projects/my-project/zones/us-central1-a/instances", "compute", "instances"},
+		{"/compute/v1/projects/my-project/zones/us-central1-a/instances/my-instance/setMetadata", "compute", "instances/{}/setMetadata"},
+		{"/compute/v1/projects/my-project/zones/us-central1-a/operations/my-operation/wait", "compute", "operations/{}/wait"},
+		{"/compute/v1/projects/my-project/global/firewalls", "compute", "firewalls"},
+		{"/compute/v1/projects/my-project/global/firewalls/my-firewall", "compute", "firewalls/{}"},
+		{"/compute/v1/projects/my-project/aggregated/instances", "compute", "aggregated/instances"},
+		{"/compute/v1/projects/my-project/regions/us-central1", "compute", "regions/{}"},
+		{"/dns/v1/projects/my-project/managedZones/my-zone/changes", "dns", "managedZones/{}/changes"},
+		{"/storage/v1/b/my-bucket/o/my-cluster%2Fmy-machine", "storage", "b/{}/o/{}"},
+		{"/upload/storage/v1/b/my-bucket/o", "storage", "b/{}/o"},
+		{"/malformed", "unknown", ""},
+	}
+	for _, tt := range tests {
+		service, resource := splitRESTPath(tt.path)
+		assert.Equal(t, tt.service, service, tt.path)
+		assert.Equal(t, tt.resource, resource, tt.path)
+	}
+}
+
+// This test verifies that the gRPC interceptor records the calls made by the
+// gRPC based clients with their status code.
+func TestMetricsUnaryClientInterceptor(t *testing.T) {
+	const method = "/google.container.v1.ClusterManager/GetCluster"
+
+	okBefore := testutil.ToFloat64(gcpAPIRequestsTotal.WithLabelValues("ClusterManager", "GetCluster", codeOK))
+	notFoundBefore := testutil.ToFloat64(gcpAPIRequestsTotal.WithLabelValues("ClusterManager", "GetCluster", codes.NotFound.String()))
+
+	invoker := func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		return nil
+	}
+	assert.Nil(t, metricsUnaryClientInterceptor(context.TODO(), method, nil, nil, nil, invoker))
+
+	notFound := status.Error(codes.NotFound, "cluster not found")
+	failingInvoker := func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		return notFound
+	}
+	assert.Equal(t, notFound, metricsUnaryClientInterceptor(context.TODO(), method, nil, nil, nil, failingInvoker))
+
+	assert.Equal(t, okBefore+1, testutil.ToFloat64(gcpAPIRequestsTotal.WithLabelValues("ClusterManager", "GetCluster", codeOK)))
+	assert.Equal(t, notFoundBefore+1, testutil.ToFloat64(gcpAPIRequestsTotal.WithLabelValues("ClusterManager", "GetCluster", codes.NotFound.String())))
+}
+
+// This test verifies that full gRPC method names are split into their
+// unqualified service name and operation.
+func TestSplitGRPCMethod(t *testing.T) {
+	service, operation := splitGRPCMethod("/google.iam.credentials.v1.IAMCredentials/GenerateAccessToken")
+	assert.Equal(t, "IAMCredentials", service)
+	assert.Equal(t, "GenerateAccessToken", operation)
+
+	service, operation = splitGRPCMethod("malformed")
+	assert.Equal(t, "unknown", service)
+	assert.Equal(t, "malformed", operation)
+}
@@ -18,10 +18,17 @@ package firewalls
 
 import (
 	"context"
+	"regexp"
+	"strings"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -33,21 +40,19 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 	log.Info("Reconciling firewall resources")
-	for _, spec := range s.scope.FirewallRulesSpec() {
-		log.V(2).Info("Looking firewall", "name", spec.Name)
-		firewallKey := meta.GlobalKey(spec.Name)
-		if _, err := s.firewalls.Get(ctx, firewallKey); err != nil {
-			if !gcperrors.IsNotFound(err) {
-				return err
-			}
-
-			log.V(2).Info("Creating firewall", "name", spec.Name)
-			if err := s.firewalls.Insert(ctx, firewallKey, spec); err != nil {
-				return err
-			}
+	additionalFirewallRules := s.scope.AdditionalFirewallRulesSpec()
+	firewallRules := append(s.scope.FirewallRulesSpec(), additionalFirewallRules...)
+	for _, spec := range firewallRules {
+		if err := s.createOrUpdateFirewall(ctx, spec); err != nil {
+			return err
 		}
 	}
 
+	// The NodePort firewall, when disabled, is removed along with the user-defined firewalls.
+	if err := s.deleteRemovedFirewalls(ctx, firewallRules); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -60,13 +65,151 @@ func (s *Service) Delete(ctx context.Context) error {
 	}
 	log.Info("Deleting firewall resources")
 	for _, spec := range s.scope.FirewallRulesSpec() {
-		log.V(2).Info("Deleting firewall", "name", spec.Name)
-		firewallKey := meta.GlobalKey(spec.Name)
-		if err := s.firewalls.Delete(ctx, firewallKey); err != nil {
-			if !gcperrors.IsNotFound(err) {
-				log.Error(err, "Error deleting firewall", "name", spec.Name)
-				return err
-			}
+		if err := s.deleteFirewall(ctx, spec.Name); err != nil {
+			return err
+		}
+	}
+
+	if err := s.deleteRemovedFirewalls(ctx, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// createOrUpdateFirewall creates the firewall if it does not exist, and updates it when its logging
+// configuration, or the rule of a user-defined firewall created by CAPG, drifted from the spec.
+func (s *Service) createOrUpdateFirewall(ctx context.Context, spec *compute.Firewall) error {
+	log := log.FromContext(ctx)
+	log.V(2).Info("Looking firewall", "name", spec.Name)
+	firewallKey := meta.GlobalKey(spec.Name)
+	firewall, err := s.firewalls.Get(ctx, firewallKey)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			return err
+		}
+
+		if shared.SkipDryRun(ctx, s.scope, "create", "firewall", spec.Name) {
+			return nil
+		}
+
+		log.V(2).Info("Creating firewall", "name", spec.Name, "direction", spec.Direction)
+		return s.firewalls.Insert(ctx, firewallKey, spec)
+	}
+
+	// The logging configuration is only reconciled when set, so logging enabled out of band is
+	// left untouched.
+	logConfigDrifted := spec.LogConfig != nil && !logConfigEqual(firewall.LogConfig, spec.LogConfig)
+	ruleDrifted := spec.Description != "" && firewall.Description == spec.Description && !ruleEqual(firewall, spec)
+	if !logConfigDrifted && !ruleDrifted {
+		return nil
+	}
+
+	if shared.SkipDryRun(ctx, s.scope, "update", "firewall", spec.Name) {
+		return nil
+	}
+
+	log.V(2).Info("Updating firewall", "name", spec.Name, "logConfigDrifted", logConfigDrifted, "ruleDrifted", ruleDrifted)
+	if err := s.firewalls.Update(ctx, firewallKey, spec); err != nil {
+		log.Error(err, "Error updating firewall", "name", spec.Name)
+		return err
+	}
+
+	return nil
+}
+
+// ruleEqual reports whether the traffic matched by the firewall, and the action taken on it, match the
+// desired ones. The direction is not compared as it cannot be changed once the firewall is created.
+func ruleEqual(current, desired *compute.Firewall) bool {
+	if current.Priority != desired.Priority {
+		return false
+	}
+
+	allowed := func(rules []*compute.FirewallAllowed) sets.Set[string] {
+		protocols := sets.New[string]()
+		for _, rule := range rules {
+			protocols.Insert(protocolPorts(rule.IPProtocol, rule.Ports))
+		}
+		return protocols
+	}
+	denied := func(rules []*compute.FirewallDenied) sets.Set[string] {
+		protocols := sets.New[string]()
+		for _, rule := range rules {
+			protocols.Insert(protocolPorts(rule.IPProtocol, rule.Ports))
+		}
+		return protocols
+	}
+
+	return allowed(current.Allowed).Equal(allowed(desired.Allowed)) &&
+		denied(current.Denied).Equal(denied(desired.Denied)) &&
+		sets.New(current.SourceRanges...).Equal(sets.New(desired.SourceRanges...)) &&
+		sets.New(current.DestinationRanges...).Equal(sets.New(desired.DestinationRanges...)) &&
+		sets.New(current.TargetTags...).Equal(sets.New(desired.TargetTags...))
+}
+
+// protocolPorts returns a comparable representation of a protocol and its ports. GCP returns the
+// protocols in lower case, whatever the case they were created with.
+func protocolPorts(protocol string, ports []string) string {
+	return strings.ToLower(protocol) + "/" + strings.Join(sets.List(sets.New(ports...)), ",")
+}
+
+// logConfigEqual reports whether the logging configuration of the firewall matches the desired one.
+func logConfigEqual(current, desired *compute.FirewallLogConfig) bool {
+	if current == nil || !current.Enable {
+		return !desired.Enable
+	}
+
+	return desired.Enable && current.Metadata == desired.Metadata
+}
+
+// deleteRemovedFirewalls deletes the firewalls created by CAPG for the cluster, the user-defined ones and
+// the NodePort one, which are not part of the given specs anymore. Firewalls which were not created by
+// CAPG are never removed.
+func (s *Service) deleteRemovedFirewalls(ctx context.Context, specs []*compute.Firewall) error {
+	log := log.FromContext(ctx)
+	names := sets.New[string]()
+	for _, spec := range specs {
+		names.Insert(spec.Name)
+	}
+
+	// The user-defined firewalls are prefixed with the cluster name, and the NodePort one with "allow-" and
+	// the cluster name.
+	firewalls, err := s.firewalls.List(ctx, filter.Regexp("name", "^(allow-)?"+regexp.QuoteMeta(s.scope.Name())+"-.+"))
+	if err != nil {
+		log.Error(err, "Error listing firewalls")
+		return err
+	}
+
+	for _, firewall := range firewalls {
+		if names.Has(firewall.Name) || firewall.Description != infrav1.ClusterTagKey(s.scope.Name()) {
+			continue
+		}
+
+		if err := s.deleteFirewall(ctx, firewall.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) deleteFirewall(ctx context.Context, name string) error {
+	log := log.FromContext(ctx)
+	firewallKey := meta.GlobalKey(name)
+	if s.scope.DryRun() {
+		// Only report the deletion of a firewall which exists.
+		if _, err := s.firewalls.Get(ctx, firewallKey); err != nil {
+			return gcperrors.IgnoreNotFound(err)
+		}
+		shared.SkipDryRun(ctx, s.scope, "delete", "firewall", name)
+		return nil
+	}
+
+	log.V(2).Info("Deleting firewall", "name", name)
+	if err := s.firewalls.Delete(ctx, firewallKey); err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error deleting firewall", "name", name)
+			return err
 		}
 	}
 
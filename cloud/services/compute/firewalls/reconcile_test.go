@@ -24,6 +24,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/googleapi"
@@ -109,6 +110,81 @@ var fakeGCPClusterSharedVPC = &infrav1.GCPCluster{
 	},
 }
 
+var fakeGCPClusterNodePortFirewall = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+		Network: infrav1.NetworkSpec{
+			Name: ptr.To("my-network"),
+			NodePortFirewall: &infrav1.NodePortFirewallSpec{
+				Enabled:      true,
+				SourceRanges: []string{"10.0.0.0/8"},
+			},
+		},
+	},
+}
+
+var fakeGCPClusterEgressFirewall = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+		Network: infrav1.NetworkSpec{
+			Name: ptr.To("my-network"),
+			AdditionalFirewallRules: []infrav1.FirewallRule{
+				{
+					Name:              "deny-egress",
+					Direction:         infrav1.FirewallRuleDirectionEgress,
+					Action:            infrav1.FirewallRuleActionDeny,
+					Protocols:         []infrav1.FirewallRuleProtocol{{Protocol: "all"}},
+					DestinationRanges: []string{"0.0.0.0/0"},
+				},
+			},
+		},
+	},
+}
+
+var fakeGCPClusterFirewallLogging = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+		Network: infrav1.NetworkSpec{
+			Name: ptr.To("my-network"),
+			FirewallLogConfig: &infrav1.FirewallLogConfig{
+				Enabled: true,
+			},
+			AdditionalFirewallRules: []infrav1.FirewallRule{
+				{
+					Name:              "deny-egress",
+					Direction:         infrav1.FirewallRuleDirectionEgress,
+					Action:            infrav1.FirewallRuleActionDeny,
+					Protocols:         []infrav1.FirewallRuleProtocol{{Protocol: "all"}},
+					DestinationRanges: []string{"0.0.0.0/0"},
+					LogConfig: &infrav1.FirewallLogConfig{
+						Enabled:  true,
+						Metadata: ptr.To(infrav1.FirewallLogMetadataExcludeAll),
+					},
+				},
+			},
+		},
+	},
+}
+
+var egressFirewallKey = meta.GlobalKey("my-cluster-deny-egress")
+
+var nodePortFirewallKey = meta.GlobalKey(fmt.Sprintf("allow-%s-nodeports", fakeGCPCluster.ObjectMeta.Name))
+
 type testCase struct {
 	name          string
 	scope         func() Scope
@@ -146,6 +222,42 @@ func TestService_Reconcile(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	clusterScopeNodePortFirewall, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPClusterNodePortFirewall,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clusterScopeEgressFirewall, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPClusterEgressFirewall,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clusterScopeFirewallLogging, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPClusterFirewallLogging,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	tests := []testCase{
 		{
 			name:  "firewall rule does not exist successful create",
@@ -201,6 +313,298 @@ func TestService_Reconcile(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:  "nodeport firewall rule enabled (should create rule for the node tag and NodePort range)",
+			scope: func() Scope { return clusterScopeNodePortFirewall },
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockFirewallsObj{},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				fwRule, err := t.mockFirewalls.Get(ctx, nodePortFirewallKey)
+				if err != nil {
+					return err
+				}
+
+				want := &compute.Firewall{
+					Name:        "allow-my-cluster-nodeports",
+					Description: infrav1.ClusterTagKey("my-cluster"),
+					Network:     "projects/my-proj/global/networks/my-network",
+					Priority:    1000,
+					Allowed: []*compute.FirewallAllowed{
+						{IPProtocol: "TCP", Ports: []string{"30000-32767"}},
+						{IPProtocol: "UDP", Ports: []string{"30000-32767"}},
+					},
+					Direction:    "INGRESS",
+					SourceRanges: []string{"10.0.0.0/8"},
+					TargetTags:   []string{"my-cluster-node"},
+					SelfLink:     fwRule.SelfLink,
+				}
+				if d := cmp.Diff(want, fwRule); d != "" {
+					return fmt.Errorf("nodeport firewall rule mismatch (-want +got):\n%s", d)
+				}
+				return nil
+			},
+		},
+		{
+			name:  "nodeport firewall rule source ranges drifted (should update the existing rule)",
+			scope: func() Scope { return clusterScopeNodePortFirewall },
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockFirewallsObj{
+					*nodePortFirewallKey: {Obj: &compute.Firewall{
+						Name:        nodePortFirewallKey.Name,
+						Description: infrav1.ClusterTagKey("my-cluster"),
+						Priority:    1000,
+						Allowed: []*compute.FirewallAllowed{
+							{IPProtocol: "tcp", Ports: []string{"30000-32767"}},
+							{IPProtocol: "udp", Ports: []string{"30000-32767"}},
+						},
+						Direction:    "INGRESS",
+						SourceRanges: []string{"0.0.0.0/0"},
+						TargetTags:   []string{"my-cluster-node"},
+					}},
+				},
+				UpdateHook: func(_ context.Context, key *meta.Key, obj *compute.Firewall, m *cloud.MockFirewalls, _ ...cloud.Option) error {
+					m.Objects[*key].Obj = obj
+					return nil
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				fwRule, err := t.mockFirewalls.Get(ctx, nodePortFirewallKey)
+				if err != nil {
+					return err
+				}
+				if d := cmp.Diff([]string{"10.0.0.0/8"}, fwRule.SourceRanges); d != "" {
+					return fmt.Errorf("nodeport firewall rule source ranges mismatch (-want +got):\n%s", d)
+				}
+				return nil
+			},
+		},
+		{
+			name:  "nodeport firewall rule up to date (should not update the existing rule)",
+			scope: func() Scope { return clusterScopeNodePortFirewall },
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockFirewallsObj{
+					*nodePortFirewallKey: {Obj: &compute.Firewall{
+						Name:        nodePortFirewallKey.Name,
+						Description: infrav1.ClusterTagKey("my-cluster"),
+						Priority:    1000,
+						Allowed: []*compute.FirewallAllowed{
+							{IPProtocol: "tcp", Ports: []string{"30000-32767"}},
+							{IPProtocol: "udp", Ports: []string{"30000-32767"}},
+						},
+						Direction:    "INGRESS",
+						SourceRanges: []string{"10.0.0.0/8"},
+						TargetTags:   []string{"my-cluster-node"},
+					}},
+				},
+				UpdateHook: func(_ context.Context, _ *meta.Key, _ *compute.Firewall, _ *cloud.MockFirewalls, _ ...cloud.Option) error {
+					return errors.New("unexpected update")
+				},
+			},
+		},
+		{
+			name:  "nodeport firewall rule disabled (should delete existing rule)",
+			scope: func() Scope { return clusterScope },
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockFirewallsObj{
+					*nodePortFirewallKey: {Obj: &compute.Firewall{
+						Name:        nodePortFirewallKey.Name,
+						Description: infrav1.ClusterTagKey("my-cluster"),
+					}},
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				if _, err := t.mockFirewalls.Get(ctx, nodePortFirewallKey); err == nil {
+					return errors.New("nodeport firewall rule was not deleted")
+				}
+				return nil
+			},
+		},
+		{
+			name:  "nodeport firewall rule disabled and not created by CAPG (should keep existing rule)",
+			scope: func() Scope { return clusterScope },
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockFirewallsObj{
+					*nodePortFirewallKey: {Obj: &compute.Firewall{
+						Name: nodePortFirewallKey.Name,
+					}},
+				},
+				DeleteHook: func(_ context.Context, _ *meta.Key, _ *cloud.MockFirewalls, _ ...cloud.Option) (bool, error) {
+					return true, errors.New("unexpected delete")
+				},
+			},
+		},
+		{
+			name:  "egress deny firewall rule (should create rule with direction and destination ranges)",
+			scope: func() Scope { return clusterScopeEgressFirewall },
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockFirewallsObj{},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				fwRule, err := t.mockFirewalls.Get(ctx, egressFirewallKey)
+				if err != nil {
+					return err
+				}
+
+				want := &compute.Firewall{
+					Name:        "my-cluster-deny-egress",
+					Description: infrav1.ClusterTagKey("my-cluster"),
+					Network:     "projects/my-proj/global/networks/my-network",
+					Denied: []*compute.FirewallDenied{
+						{IPProtocol: "all"},
+					},
+					Direction:         "EGRESS",
+					Priority:          1000,
+					DestinationRanges: []string{"0.0.0.0/0"},
+					SelfLink:          fwRule.SelfLink,
+				}
+				if d := cmp.Diff(want, fwRule); d != "" {
+					return fmt.Errorf("egress firewall rule mismatch (-want +got):\n%s", d)
+				}
+				return nil
+			},
+		},
+		{
+			name:  "egress firewall rule drifted (should update the existing rule)",
+			scope: func() Scope { return clusterScopeEgressFirewall },
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockFirewallsObj{
+					*egressFirewallKey: {Obj: &compute.Firewall{
+						Name:        "my-cluster-deny-egress",
+						Description: infrav1.ClusterTagKey("my-cluster"),
+						Denied: []*compute.FirewallDenied{
+							{IPProtocol: "all"},
+						},
+						Direction:         "EGRESS",
+						Priority:          1000,
+						DestinationRanges: []string{"10.0.0.0/8"},
+					}},
+				},
+				UpdateHook: func(_ context.Context, key *meta.Key, obj *compute.Firewall, m *cloud.MockFirewalls, _ ...cloud.Option) error {
+					m.Objects[*key].Obj = obj
+					return nil
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				fwRule, err := t.mockFirewalls.Get(ctx, egressFirewallKey)
+				if err != nil {
+					return err
+				}
+				if d := cmp.Diff([]string{"0.0.0.0/0"}, fwRule.DestinationRanges); d != "" {
+					return fmt.Errorf("egress firewall rule destination ranges mismatch (-want +got):\n%s", d)
+				}
+				return nil
+			},
+		},
+		{
+			name:  "egress firewall rule up to date (should not update the existing rule)",
+			scope: func() Scope { return clusterScopeEgressFirewall },
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockFirewallsObj{
+					*egressFirewallKey: {Obj: &compute.Firewall{
+						Name:        "my-cluster-deny-egress",
+						Description: infrav1.ClusterTagKey("my-cluster"),
+						Denied: []*compute.FirewallDenied{
+							{IPProtocol: "ALL"},
+						},
+						Direction:         "EGRESS",
+						Priority:          1000,
+						DestinationRanges: []string{"0.0.0.0/0"},
+					}},
+				},
+				UpdateHook: func(_ context.Context, _ *meta.Key, _ *compute.Firewall, _ *cloud.MockFirewalls, _ ...cloud.Option) error {
+					return errors.New("unexpected update")
+				},
+			},
+		},
+		{
+			name:  "additional firewall rule removed from the spec (should delete the rules owned by the cluster only)",
+			scope: func() Scope { return clusterScope },
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockFirewallsObj{
+					*egressFirewallKey: {Obj: &compute.Firewall{
+						Name:        "my-cluster-deny-egress",
+						Description: infrav1.ClusterTagKey("my-cluster"),
+					}},
+					*meta.GlobalKey("my-cluster-user-rule"): {Obj: &compute.Firewall{
+						Name: "my-cluster-user-rule",
+					}},
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				if _, err := t.mockFirewalls.Get(ctx, egressFirewallKey); err == nil {
+					return errors.New("removed egress firewall rule was not deleted")
+				}
+				if _, err := t.mockFirewalls.Get(ctx, meta.GlobalKey("my-cluster-user-rule")); err != nil {
+					return errors.New("firewall rule not created by CAPG was deleted")
+				}
+				return nil
+			},
+		},
+		{
+			name:  "firewall logging enabled (should create rules with logging enabled)",
+			scope: func() Scope { return clusterScopeFirewallLogging },
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockFirewallsObj{},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				fwRule, err := t.mockFirewalls.Get(ctx, meta.GlobalKey("allow-my-cluster-healthchecks"))
+				if err != nil {
+					return err
+				}
+				want := &compute.FirewallLogConfig{Enable: true, Metadata: "INCLUDE_ALL_METADATA"}
+				if d := cmp.Diff(want, fwRule.LogConfig); d != "" {
+					return fmt.Errorf("healthchecks firewall rule log config mismatch (-want +got):\n%s", d)
+				}
+
+				fwRule, err = t.mockFirewalls.Get(ctx, egressFirewallKey)
+				if err != nil {
+					return err
+				}
+				want = &compute.FirewallLogConfig{Enable: true, Metadata: "EXCLUDE_ALL_METADATA"}
+				if d := cmp.Diff(want, fwRule.LogConfig); d != "" {
+					return fmt.Errorf("egress firewall rule log config mismatch (-want +got):\n%s", d)
+				}
+				return nil
+			},
+		},
+		{
+			name:  "firewall logging drifted (should update the existing rule)",
+			scope: func() Scope { return clusterScopeFirewallLogging },
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockFirewallsObj{
+					*meta.GlobalKey("allow-my-cluster-healthchecks"): {Obj: &compute.Firewall{
+						Name:      "allow-my-cluster-healthchecks",
+						LogConfig: &compute.FirewallLogConfig{Enable: false},
+					}},
+				},
+				UpdateHook: func(_ context.Context, key *meta.Key, obj *compute.Firewall, m *cloud.MockFirewalls, _ ...cloud.Option) error {
+					m.Objects[*key].Obj = obj
+					return nil
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				fwRule, err := t.mockFirewalls.Get(ctx, meta.GlobalKey("allow-my-cluster-healthchecks"))
+				if err != nil {
+					return err
+				}
+				if fwRule.LogConfig == nil || !fwRule.LogConfig.Enable {
+					return errors.New("firewall rule logging was not enabled")
+				}
+				return nil
+			},
+		},
 		{
 			name:  "firewall return no error using shared vpc",
 			scope: func() Scope { return clusterScopeSharedVpc },
@@ -262,6 +666,30 @@ func TestService_Delete(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	clusterScopeNodePortFirewall, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPClusterNodePortFirewall,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clusterScopeEgressFirewall, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPClusterEgressFirewall,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	tests := []testCase{
 		{
 			name:  "firewall rule does not exist, should do nothing",
@@ -284,6 +712,59 @@ func TestService_Delete(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:  "nodeport firewall rule deletion",
+			scope: func() Scope { return clusterScopeNodePortFirewall },
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockFirewallsObj{
+					*nodePortFirewallKey: {},
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				if _, err := t.mockFirewalls.Get(ctx, nodePortFirewallKey); err == nil {
+					return errors.New("nodeport firewall rule was not deleted")
+				}
+				return nil
+			},
+		},
+		{
+			name:  "egress firewall rule owned by the cluster, should be deleted",
+			scope: func() Scope { return clusterScopeEgressFirewall },
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockFirewallsObj{
+					*egressFirewallKey: {Obj: &compute.Firewall{
+						Name:        "my-cluster-deny-egress",
+						Description: infrav1.ClusterTagKey("my-cluster"),
+					}},
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				if _, err := t.mockFirewalls.Get(ctx, egressFirewallKey); err == nil {
+					return errors.New("egress firewall rule was not deleted")
+				}
+				return nil
+			},
+		},
+		{
+			name:  "egress firewall rule not created by CAPG, should be kept",
+			scope: func() Scope { return clusterScopeEgressFirewall },
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockFirewallsObj{
+					*egressFirewallKey: {Obj: &compute.Firewall{
+						Name: "my-cluster-deny-egress",
+					}},
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				if _, err := t.mockFirewalls.Get(ctx, egressFirewallKey); err != nil {
+					return errors.New("egress firewall rule not created by CAPG was deleted")
+				}
+				return nil
+			},
+		},
 		{
 			name:  "firewall rule deletion with shared vpc",
 			scope: func() Scope { return clusterScopeSharedVpc },
@@ -305,6 +786,75 @@ func TestService_Delete(t *testing.T) {
 				t.Errorf("Service.Delete() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.assert != nil {
+				if err := tt.assert(ctx, tt); err != nil {
+					t.Errorf("firewall rule was not deleted as expected: %v", err)
+				}
+			}
 		})
 	}
 }
+
+func TestService_DryRun(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	gcpCluster := fakeGCPClusterFirewallLogging.DeepCopy()
+	gcpCluster.Annotations = map[string]string{infrav1.DryRunAnnotation: "true"}
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: gcpCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var calls []string
+	mockFirewalls := &cloud.MockFirewalls{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+		Objects: map[meta.Key]*cloud.MockFirewallsObj{
+			// The logging of the existing rule drifted, and the disabled NodePort rule still exists.
+			*meta.GlobalKey("allow-my-cluster-healthchecks"): {Obj: &compute.Firewall{
+				Name:      "allow-my-cluster-healthchecks",
+				LogConfig: &compute.FirewallLogConfig{Enable: false},
+			}},
+			*nodePortFirewallKey: {Obj: &compute.Firewall{
+				Name:        nodePortFirewallKey.Name,
+				Description: infrav1.ClusterTagKey("my-cluster"),
+			}},
+		},
+		InsertHook: func(_ context.Context, key *meta.Key, _ *compute.Firewall, _ *cloud.MockFirewalls, _ ...cloud.Option) (bool, error) {
+			calls = append(calls, "insert "+key.Name)
+			return true, nil
+		},
+		UpdateHook: func(_ context.Context, key *meta.Key, _ *compute.Firewall, _ *cloud.MockFirewalls, _ ...cloud.Option) error {
+			calls = append(calls, "update "+key.Name)
+			return nil
+		},
+		DeleteHook: func(_ context.Context, key *meta.Key, _ *cloud.MockFirewalls, _ ...cloud.Option) (bool, error) {
+			calls = append(calls, "delete "+key.Name)
+			return true, nil
+		},
+	}
+
+	ctx := context.TODO()
+	s := New(clusterScope)
+	s.firewalls = mockFirewalls
+	if err := s.Reconcile(ctx); err != nil {
+		t.Fatalf("Service.Reconcile() error = %v", err)
+	}
+	if err := s.Delete(ctx); err != nil {
+		t.Fatalf("Service.Delete() error = %v", err)
+	}
+	if len(calls) > 0 {
+		t.Errorf("Service made changes in dry-run mode: %v", calls)
+	}
+	if _, err := mockFirewalls.Get(ctx, egressFirewallKey); err == nil {
+		t.Errorf("Service created firewall %s in dry-run mode", egressFirewallKey.Name)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 
 	k8scloud "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"google.golang.org/api/compute/v1"
 
@@ -28,6 +29,7 @@ import (
 
 type firewallsInterface interface {
 	Get(ctx context.Context, key *meta.Key, options ...k8scloud.Option) (*compute.Firewall, error)
+	List(ctx context.Context, fl *filter.F, options ...k8scloud.Option) ([]*compute.Firewall, error)
 	Insert(ctx context.Context, key *meta.Key, obj *compute.Firewall, options ...k8scloud.Option) error
 	Update(ctx context.Context, key *meta.Key, obj *compute.Firewall, options ...k8scloud.Option) error
 	Delete(ctx context.Context, key *meta.Key, options ...k8scloud.Option) error
@@ -37,6 +39,7 @@ type firewallsInterface interface {
 type Scope interface {
 	cloud.ClusterGetter
 	FirewallRulesSpec() []*compute.Firewall
+	AdditionalFirewallRulesSpec() []*compute.Firewall
 }
 
 // Service implements firewalls reconciler.
@@ -0,0 +1,233 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instances
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/storage/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
+)
+
+const (
+	// defaultBootstrapDataThreshold is the size limit of an instance metadata value.
+	defaultBootstrapDataThreshold int64 = 256 * 1024
+
+	// bootstrapDataURLExpiry is how long the signed URL of the bootstrap data object is valid. It only has to be
+	// fetched by cloud-init on the first boot of the instance.
+	bootstrapDataURLExpiry = 24 * time.Hour
+
+	// bootstrapDataIncludePrefix starts the user-data making cloud-init fetch the bootstrap data from a URL.
+	bootstrapDataIncludePrefix = "#include\n"
+
+	// defaultStorageHost is the host of the GCS XML API, which signed URLs are served from.
+	defaultStorageHost = "storage.googleapis.com"
+)
+
+// bootstrapDataClient stores the bootstrap data of instances in GCS, as the instance metadata cannot hold
+// large bootstrap data.
+type bootstrapDataClient struct {
+	scope Scope
+}
+
+// Upload creates or replaces the object holding the bootstrap data. Unless the bucket uses uniform bucket-level
+// access, the object is given a private ACL as it holds the credentials joining the node to the cluster.
+func (c *bootstrapDataClient) Upload(ctx context.Context, bucket, object string, data []byte) error {
+	storageSvc, err := c.scope.StorageService(ctx)
+	if err != nil {
+		return err
+	}
+
+	b, err := storageSvc.Buckets.Get(bucket).Fields("iamConfiguration").Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	call := storageSvc.Objects.Insert(bucket, &storage.Object{Name: object}).
+		Media(bytes.NewReader(data)).
+		Context(ctx)
+	if b.IamConfiguration == nil || b.IamConfiguration.UniformBucketLevelAccess == nil || !b.IamConfiguration.UniformBucketLevelAccess.Enabled {
+		call = call.PredefinedAcl("private")
+	}
+	_, err = call.Do()
+	return err
+}
+
+// SignedURL returns a URL through which the object can be read without credentials until it expires.
+func (c *bootstrapDataClient) SignedURL(ctx context.Context, bucket, object string, expiry time.Duration) (string, error) {
+	storageSvc, err := c.scope.StorageService(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	host := defaultStorageHost
+	if u, err := url.Parse(storageSvc.BasePath); err == nil && u.Host != "" {
+		host = u.Host
+	}
+
+	serviceAccount, err := c.scope.CredentialsServiceAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return signURL(host, bucket, object, serviceAccount, time.Now(), expiry, func(payload []byte) ([]byte, error) {
+		return c.scope.SignBlob(ctx, serviceAccount, payload)
+	})
+}
+
+// Delete deletes the object holding the bootstrap data.
+func (c *bootstrapDataClient) Delete(ctx context.Context, bucket, object string) error {
+	storageSvc, err := c.scope.StorageService(ctx)
+	if err != nil {
+		return err
+	}
+
+	return storageSvc.Objects.Delete(bucket, object).Context(ctx).Do()
+}
+
+// signURL returns a V4 signed URL to get the object, see https://cloud.google.com/storage/docs/access-control/signing-urls-manually.
+func signURL(host, bucket, object, serviceAccount string, now time.Time, expiry time.Duration, sign func([]byte) ([]byte, error)) (string, error) {
+	now = now.UTC()
+	timestamp := now.Format("20060102T150405Z")
+	credentialScope := now.Format("20060102") + "/auto/storage/goog4_request"
+
+	segments := strings.Split(object, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	canonicalURI := "/" + bucket + "/" + strings.Join(segments, "/")
+
+	query := url.Values{
+		"X-Goog-Algorithm":     {"GOOG4-RSA-SHA256"},
+		"X-Goog-Credential":    {serviceAccount + "/" + credentialScope},
+		"X-Goog-Date":          {timestamp},
+		"X-Goog-Expires":       {strconv.FormatInt(int64(expiry.Seconds()), 10)},
+		"X-Goog-SignedHeaders": {"host"},
+	}
+	canonicalQuery := strings.ReplaceAll(query.Encode(), "+", "%20")
+
+	canonicalRequest := strings.Join([]string{
+		"GET",
+		canonicalURI,
+		canonicalQuery,
+		"host:" + host + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"GOOG4-RSA-SHA256",
+		timestamp,
+		credentialScope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	signature, err := sign([]byte(stringToSign))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("https://%s%s?%s&X-Goog-Signature=%s", host, canonicalURI, canonicalQuery, hex.EncodeToString(signature)), nil
+}
+
+// bootstrapDataObject returns the name of the object holding the bootstrap data of the machine.
+func (s *Service) bootstrapDataObject() string {
+	return path.Join("bootstrap-data", s.scope.ClusterName(), s.scope.Namespace(), s.scope.Name())
+}
+
+// setBootstrapData adds the bootstrap data to the user-data metadata of the instance. Bootstrap data exceeding the
+// threshold of the BootstrapDataStorage is not added, and true is returned as it has to be uploaded with
+// uploadBootstrapData before the instance is created.
+func (s *Service) setBootstrapData(metadata *compute.Metadata, bootstrapData string) bool {
+	dataStorage := s.scope.BootstrapDataStorage()
+	if dataStorage == nil || int64(len(bootstrapData)) <= ptr.Deref(dataStorage.Threshold, defaultBootstrapDataThreshold) {
+		shared.SetMetadataItem(metadata, "user-data", bootstrapData)
+		return false
+	}
+
+	return true
+}
+
+// uploadBootstrapData stores the bootstrap data in the bucket of the BootstrapDataStorage, and sets the user-data
+// metadata of the instance to make cloud-init include the object through a signed URL.
+func (s *Service) uploadBootstrapData(ctx context.Context, metadata *compute.Metadata, bootstrapData string) error {
+	log := log.FromContext(ctx)
+	bucket, object := s.scope.BootstrapDataStorage().Bucket, s.bootstrapDataObject()
+	log.V(2).Info("Uploading bootstrap data", "bucket", bucket, "object", object)
+	if err := s.bootstrapData.Upload(ctx, bucket, object, []byte(bootstrapData)); err != nil {
+		log.Error(err, "Error uploading bootstrap data", "bucket", bucket, "object", object)
+		return err
+	}
+
+	signedURL, err := s.bootstrapData.SignedURL(ctx, bucket, object, bootstrapDataURLExpiry)
+	if err != nil {
+		log.Error(err, "Error signing bootstrap data URL", "bucket", bucket, "object", object)
+		return err
+	}
+
+	shared.SetMetadataItem(metadata, "user-data", bootstrapDataIncludePrefix+signedURL+"\n")
+	return nil
+}
+
+// hasBootstrapDataObject returns true if the user-data of the instance includes bootstrap data stored in GCS.
+func hasBootstrapDataObject(instance *compute.Instance) bool {
+	if instance.Metadata == nil {
+		return false
+	}
+	for _, item := range instance.Metadata.Items {
+		if item.Key == "user-data" {
+			return strings.HasPrefix(ptr.Deref(item.Value, ""), bootstrapDataIncludePrefix)
+		}
+	}
+	return false
+}
+
+// deleteBootstrapData deletes the bootstrap data stored in the bucket of the BootstrapDataStorage, if any.
+func (s *Service) deleteBootstrapData(ctx context.Context) error {
+	dataStorage := s.scope.BootstrapDataStorage()
+	if dataStorage == nil {
+		return nil
+	}
+
+	log := log.FromContext(ctx)
+	object := s.bootstrapDataObject()
+	if shared.SkipDryRun(ctx, s.scope, "delete", "bootstrap data object", object) {
+		return nil
+	}
+
+	log.V(2).Info("Deleting bootstrap data", "bucket", dataStorage.Bucket, "object", object)
+	if err := gcperrors.IgnoreNotFound(s.bootstrapData.Delete(ctx, dataStorage.Bucket, object)); err != nil {
+		log.Error(err, "Error deleting bootstrap data", "bucket", dataStorage.Bucket, "object", object)
+		return err
+	}
+
+	return nil
+}
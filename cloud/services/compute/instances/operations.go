@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instances
+
+import (
+	"context"
+
+	k8scloud "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
+)
+
+// instanceOperationsClient updates running instances through the compute API, for the operations the
+// k8s-cloud-provider Instances interface does not expose.
+type instanceOperationsClient struct {
+	compute     *compute.Service
+	rateLimiter k8scloud.RateLimiter
+	project     string
+}
+
+// do performs the instance operation through the rate limiter and waits for it to complete.
+func (c *instanceOperationsClient) do(ctx context.Context, operation string, call func() (*compute.Operation, error)) error {
+	key := &k8scloud.RateLimitKey{
+		ProjectID: c.project,
+		Operation: operation,
+		Version:   meta.VersionGA,
+		Service:   "Instances",
+	}
+
+	return shared.DoOperation(ctx, c.rateLimiter, key, shared.ZoneOperationWaiter(c.compute, c.project), call)
+}
+
+// SetDeletionProtection sets the deletion protection of the instance.
+func (c *instanceOperationsClient) SetDeletionProtection(ctx context.Context, key *meta.Key, protected bool) error {
+	return c.do(ctx, "SetDeletionProtection", func() (*compute.Operation, error) {
+		return c.compute.Instances.SetDeletionProtection(c.project, key.Zone, key.Name).
+			DeletionProtection(protected).
+			Context(ctx).
+			Do()
+	})
+}
+
+// SetTags sets the network tags of the instance. The tags must carry the fingerprint of the current tags of
+// the instance.
+func (c *instanceOperationsClient) SetTags(ctx context.Context, key *meta.Key, tags *compute.Tags) error {
+	return c.do(ctx, "SetTags", func() (*compute.Operation, error) {
+		return c.compute.Instances.SetTags(c.project, key.Zone, key.Name, tags).Context(ctx).Do()
+	})
+}
+
+// SetLabels sets the labels of the instance. The request must carry the fingerprint of the current labels of
+// the instance.
+func (c *instanceOperationsClient) SetLabels(ctx context.Context, key *meta.Key, req *compute.InstancesSetLabelsRequest) error {
+	return c.do(ctx, "SetLabels", func() (*compute.Operation, error) {
+		return c.compute.Instances.SetLabels(c.project, key.Zone, key.Name, req).Context(ctx).Do()
+	})
+}
+
+// SetMetadata sets the metadata of the instance. The metadata must carry the fingerprint of the current
+// metadata of the instance.
+func (c *instanceOperationsClient) SetMetadata(ctx context.Context, key *meta.Key, metadata *compute.Metadata) error {
+	return c.do(ctx, "SetMetadata", func() (*compute.Operation, error) {
+		return c.compute.Instances.SetMetadata(c.project, key.Zone, key.Name, metadata).Context(ctx).Do()
+	})
+}
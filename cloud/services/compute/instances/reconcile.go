@@ -19,9 +19,13 @@ package instances
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/pkg/errors"
 
+	k8scloud "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"google.golang.org/api/compute/v1"
@@ -32,9 +36,24 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+// CreateError is returned when the instance could not be created, so that the errors which retrying won't resolve
+// can be told apart from the errors reconciling an existing instance.
+type CreateError struct {
+	Err error
+}
+
+func (e *CreateError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CreateError) Unwrap() error {
+	return e.Err
+}
+
 // Reconcile reconcile machine instance.
 func (s *Service) Reconcile(ctx context.Context) error {
 	log := log.FromContext(ctx)
@@ -44,6 +63,20 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return err
 	}
 
+	if err := s.resizeRootDisk(ctx, instance); err != nil {
+		return err
+	}
+
+	// The bootstrap data stored in GCS holds the credentials joining the node to the cluster, it is deleted once the
+	// node joined rather than once the instance is running, as cloud-init might not have fetched it yet. The
+	// instance metadata keeps pointing to the object, so the deletion is recorded to only happen once.
+	if s.scope.HasNodeRef() && !s.scope.BootstrapDataDeleted() && hasBootstrapDataObject(instance) {
+		if err := s.deleteBootstrapData(ctx); err != nil {
+			return err
+		}
+		s.scope.SetBootstrapDataDeleted()
+	}
+
 	addresses := make([]corev1.NodeAddress, 0, len(instance.NetworkInterfaces))
 	for _, iface := range instance.NetworkInterfaces {
 		addresses = append(addresses, corev1.NodeAddress{
@@ -59,7 +92,10 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		}
 	}
 
-	machineName := s.scope.Name()
+	machineName, err := s.scope.InstanceName()
+	if err != nil {
+		return err
+	}
 	zone := s.scope.Zone()
 	project := s.scope.Project()
 
@@ -84,7 +120,9 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		Address: machineName,
 	})
 
-	s.scope.SetProviderID()
+	if err := s.scope.SetProviderID(); err != nil {
+		return err
+	}
 	s.scope.SetAddresses(addresses)
 	s.scope.SetInstanceStatus(infrav1.InstanceStatus(instance.Status))
 
@@ -101,7 +139,10 @@ func (s *Service) Reconcile(ctx context.Context) error {
 func (s *Service) Delete(ctx context.Context) error {
 	log := log.FromContext(ctx)
 	log.Info("Deleting instance resources")
-	instanceSpec := s.scope.InstanceSpec(log)
+	instanceSpec, err := s.scope.InstanceSpec(log)
+	if err != nil {
+		return err
+	}
 	instanceName := instanceSpec.Name
 	instanceKey := meta.ZonalKey(instanceName, s.scope.Zone())
 	log.V(2).Info("Looking for instance before deleting", "name", instanceName, "zone", s.scope.Zone())
@@ -112,7 +153,7 @@ func (s *Service) Delete(ctx context.Context) error {
 			return err
 		}
 
-		return nil
+		return s.deleteBootstrapData(ctx)
 	}
 
 	if s.scope.IsControlPlane() {
@@ -121,8 +162,24 @@ func (s *Service) Delete(ctx context.Context) error {
 		}
 	}
 
+	if shared.SkipDryRun(ctx, s.scope, "delete", "instance", instanceName) {
+		return nil
+	}
+
+	if instance.DeletionProtection {
+		log.V(2).Info("Disabling deletion protection of instance", "name", instanceName, "zone", s.scope.Zone())
+		if err := s.deletionProtection.SetDeletionProtection(ctx, instanceKey, false); err != nil {
+			log.Error(err, "Error disabling deletion protection of instance", "name", instanceName)
+			return gcperrors.IgnoreNotFound(err)
+		}
+	}
+
 	log.V(2).Info("Deleting instance", "name", instanceName, "zone", s.scope.Zone())
-	return gcperrors.IgnoreNotFound(s.instances.Delete(ctx, instanceKey))
+	if err := gcperrors.IgnoreNotFound(s.instances.Delete(ctx, instanceKey)); err != nil {
+		return err
+	}
+
+	return s.deleteBootstrapData(ctx)
 }
 
 func (s *Service) createOrGetInstance(ctx context.Context) (*compute.Instance, error) {
@@ -134,13 +191,31 @@ func (s *Service) createOrGetInstance(ctx context.Context) (*compute.Instance, e
 		return nil, errors.Wrap(err, "failed to retrieve bootstrap data")
 	}
 
-	instanceSpec := s.scope.InstanceSpec(log)
+	instanceSpec, err := s.scope.InstanceSpec(log)
+	if err != nil {
+		return nil, err
+	}
 	instanceName := instanceSpec.Name
 	instanceKey := meta.ZonalKey(instanceName, s.scope.Zone())
-	instanceSpec.Metadata.Items = append(instanceSpec.Metadata.Items, &compute.MetadataItems{
-		Key:   "user-data",
-		Value: ptr.To[string](bootstrapData),
-	})
+	uploadBootstrapData := s.setBootstrapData(instanceSpec.Metadata, bootstrapData)
+
+	startupScript, err := s.scope.GetStartupScript()
+	if err != nil {
+		log.Error(err, "Error getting startup script for machine")
+		return nil, errors.Wrap(err, "failed to retrieve startup script")
+	}
+	if startupScript != "" {
+		shared.SetMetadataItem(instanceSpec.Metadata, "startup-script", startupScript)
+	}
+
+	shutdownScript, err := s.scope.GetShutdownScript()
+	if err != nil {
+		log.Error(err, "Error getting shutdown script for machine")
+		return nil, errors.Wrap(err, "failed to retrieve shutdown script")
+	}
+	if shutdownScript != "" {
+		shared.SetMetadataItem(instanceSpec.Metadata, "shutdown-script", shutdownScript)
+	}
 
 	log.V(2).Info("Looking for instance", "name", instanceName, "zone", s.scope.Zone())
 	instance, err := s.instances.Get(ctx, instanceKey)
@@ -150,10 +225,36 @@ func (s *Service) createOrGetInstance(ctx context.Context) (*compute.Instance, e
 			return nil, err
 		}
 
+		if err := s.validateSourceImage(ctx, instanceSpec); err != nil {
+			return nil, err
+		}
+
+		if err := s.validateDiskResourcePolicies(ctx, instanceSpec); err != nil {
+			return nil, err
+		}
+
+		if err := s.configureNetworkInterfaceStack(ctx, instanceSpec); err != nil {
+			return nil, err
+		}
+
+		if shared.SkipDryRun(ctx, s.scope, "create", "instance", instanceName) {
+			return nil, shared.ErrDryRun
+		}
+
+		if uploadBootstrapData {
+			if err := s.uploadBootstrapData(ctx, instanceSpec.Metadata, bootstrapData); err != nil {
+				return nil, err
+			}
+		}
+
 		log.V(2).Info("Creating an instance", "name", instanceName, "zone", s.scope.Zone())
 		if err := s.instances.Insert(ctx, instanceKey, instanceSpec); err != nil {
 			log.Error(err, "Error creating an instance", "name", instanceName, "zone", s.scope.Zone())
-			return nil, err
+			if uploadBootstrapData {
+				// The bootstrap data is uploaded again by the next attempt, deleteBootstrapData logs its own errors.
+				_ = s.deleteBootstrapData(ctx)
+			}
+			return nil, &CreateError{Err: err}
 		}
 
 		instance, err = s.instances.Get(ctx, instanceKey)
@@ -162,9 +263,371 @@ func (s *Service) createOrGetInstance(ctx context.Context) (*compute.Instance, e
 		}
 	}
 
+	if err := s.updateNetworkTags(ctx, instanceKey, instance, instanceSpec.Tags); err != nil {
+		return nil, err
+	}
+
+	if err := s.updateLabels(ctx, instanceKey, instance, instanceSpec.Labels); err != nil {
+		return nil, err
+	}
+
+	if err := s.updateMetadata(ctx, instanceKey, instance, instanceSpec.Metadata); err != nil {
+		return nil, err
+	}
+
 	return instance, nil
 }
 
+// updateNetworkTags sets the network tags of an existing instance when they differ from the desired ones, so
+// that changes to the additional network tags attach or detach the instance from firewall rules.
+func (s *Service) updateNetworkTags(ctx context.Context, instanceKey *meta.Key, instance *compute.Instance, desired *compute.Tags) error {
+	log := log.FromContext(ctx)
+	current := &compute.Tags{}
+	if instance.Tags != nil {
+		current = instance.Tags
+	}
+	if sets.New(current.Items...).Equal(sets.New(desired.Items...)) {
+		return nil
+	}
+
+	if shared.SkipDryRun(ctx, s.scope, "update network tags of", "instance", instanceKey.Name) {
+		return nil
+	}
+
+	log.Info("Updating instance network tags", "name", instanceKey.Name, "current", current.Items, "desired", desired.Items)
+	tags := &compute.Tags{
+		Items:       desired.Items,
+		Fingerprint: current.Fingerprint,
+	}
+	if err := s.networkTags.SetTags(ctx, instanceKey, tags); err != nil {
+		log.Error(err, "Error updating instance network tags", "name", instanceKey.Name)
+		return err
+	}
+
+	return nil
+}
+
+// resizeRootDisk grows the boot disk of an existing instance when the desired root device size is larger than the
+// current disk. Disks cannot shrink, so smaller sizes are ignored. The root filesystem is not grown by the resize:
+// most images grow it on the next boot, otherwise it has to be grown from the guest, for example with growpart and
+// resize2fs.
+func (s *Service) resizeRootDisk(ctx context.Context, instance *compute.Instance) error {
+	log := log.FromContext(ctx)
+	desiredSize := s.scope.InstanceImageSpec().InitializeParams.DiskSizeGb
+	if desiredSize == 0 {
+		return nil
+	}
+
+	for _, attachedDisk := range instance.Disks {
+		if !attachedDisk.Boot {
+			continue
+		}
+
+		diskKey, err := diskKeyFromLink(attachedDisk.Source)
+		if err != nil {
+			return err
+		}
+
+		disk, err := s.disks.Get(ctx, diskKey)
+		if err != nil {
+			log.Error(err, "Error looking for root disk", "name", diskKey.Name, "zone", diskKey.Zone)
+			return err
+		}
+
+		if disk.SizeGb >= desiredSize {
+			return nil
+		}
+
+		if shared.SkipDryRun(ctx, s.scope, "resize", "disk", diskKey.Name) {
+			return nil
+		}
+
+		log.Info("Resizing root disk, the root filesystem is grown by the image on the next boot or must be grown from the guest",
+			"name", diskKey.Name, "zone", diskKey.Zone, "currentSizeGb", disk.SizeGb, "desiredSizeGb", desiredSize)
+		if err := s.disks.Resize(ctx, diskKey, &compute.DisksResizeRequest{SizeGb: desiredSize}); err != nil {
+			log.Error(err, "Error resizing root disk", "name", diskKey.Name, "zone", diskKey.Zone)
+			return err
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// diskKeyFromLink returns the key of the zonal disk referenced by link.
+func diskKeyFromLink(link string) (*meta.Key, error) {
+	parts := strings.Split(link, "/")
+	for i := 0; i+3 < len(parts); i++ {
+		if parts[i] == "zones" && parts[i+2] == "disks" {
+			return meta.ZonalKey(parts[i+3], parts[i+1]), nil
+		}
+	}
+
+	return nil, errors.Errorf("invalid disk link %q", link)
+}
+
+// updateLabels sets the labels of an existing instance when they differ from the desired ones, so that changes to
+// the additional labels are propagated. CAPG-managed labels of the instance that are no longer desired are kept, as
+// they may have been set by an earlier version of the provider.
+func (s *Service) updateLabels(ctx context.Context, instanceKey *meta.Key, instance *compute.Instance, desired map[string]string) error {
+	log := log.FromContext(ctx)
+	labels := make(map[string]string, len(desired))
+	for key, value := range instance.Labels {
+		if strings.HasPrefix(key, infrav1.NameGCPProviderPrefix) {
+			labels[key] = value
+		}
+	}
+	for key, value := range desired {
+		labels[key] = value
+	}
+	if maps.Equal(instance.Labels, labels) {
+		return nil
+	}
+
+	if shared.SkipDryRun(ctx, s.scope, "update labels of", "instance", instanceKey.Name) {
+		return nil
+	}
+
+	log.Info("Updating instance labels", "name", instanceKey.Name, "current", instance.Labels, "desired", labels)
+	req := &compute.InstancesSetLabelsRequest{
+		Labels:           labels,
+		LabelFingerprint: instance.LabelFingerprint,
+	}
+	if err := s.labels.SetLabels(ctx, instanceKey, req); err != nil {
+		log.Error(err, "Error updating instance labels", "name", instanceKey.Name)
+		return err
+	}
+
+	return nil
+}
+
+// updateMetadata merges the desired metadata items into the metadata of an existing instance, so that changes to the
+// additional metadata reach the running instance. The items owned by the controller that are not desired anymore
+// are removed, other items of the instance are kept, and the user-data item is never replaced, as the instance was
+// bootstrapped with it.
+func (s *Service) updateMetadata(ctx context.Context, instanceKey *meta.Key, instance *compute.Instance, desired *compute.Metadata) error {
+	log := log.FromContext(ctx)
+	metadata := &compute.Metadata{}
+	var changed []string
+	if instance.Metadata != nil {
+		metadata.Fingerprint = instance.Metadata.Fingerprint
+		for _, item := range instance.Metadata.Items {
+			if slices.Contains(shared.ManagedMetadataKeys, item.Key) && metadataItemValue(desired, item.Key) == nil {
+				changed = append(changed, item.Key)
+				continue
+			}
+			metadata.Items = append(metadata.Items, item)
+		}
+	}
+
+	for _, item := range desired.Items {
+		if item.Key == "user-data" {
+			continue
+		}
+		if current := metadataItemValue(metadata, item.Key); current != nil && *current == ptr.Deref(item.Value, "") {
+			continue
+		}
+		shared.SetMetadataItem(metadata, item.Key, ptr.Deref(item.Value, ""))
+		changed = append(changed, item.Key)
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	if shared.SkipDryRun(ctx, s.scope, "update metadata of", "instance", instanceKey.Name) {
+		return nil
+	}
+
+	log.Info("Updating instance metadata", "name", instanceKey.Name, "keys", changed)
+	if err := s.metadata.SetMetadata(ctx, instanceKey, metadata); err != nil {
+		log.Error(err, "Error updating instance metadata", "name", instanceKey.Name)
+		return err
+	}
+
+	return nil
+}
+
+// metadataItemValue returns the value of the metadata item with the given key, or nil if there is none.
+func metadataItemValue(metadata *compute.Metadata, key string) *string {
+	for _, item := range metadata.Items {
+		if item.Key == key {
+			return ptr.To(ptr.Deref(item.Value, ""))
+		}
+	}
+	return nil
+}
+
+// configureNetworkInterfaceStack inspects the subnet of a single-NIC instance and, when the subnet is
+// dual-stack, configures the network interface stack type and IPv6 access accordingly.
+func (s *Service) configureNetworkInterfaceStack(ctx context.Context, instance *compute.Instance) error {
+	log := log.FromContext(ctx)
+	if len(instance.NetworkInterfaces) != 1 || instance.NetworkInterfaces[0].Subnetwork == "" {
+		return nil
+	}
+
+	networkInterface := instance.NetworkInterfaces[0]
+	subnetKey, err := subnetKeyFromLink(networkInterface.Subnetwork)
+	if err != nil {
+		return err
+	}
+
+	log.V(2).Info("Looking for subnet stack type", "subnet", subnetKey.Name, "region", subnetKey.Region)
+	subnet, err := s.subnets.Get(ctx, subnetKey)
+	if err != nil {
+		log.Error(err, "Error looking for subnet", "subnet", subnetKey.Name, "region", subnetKey.Region)
+		return err
+	}
+
+	if subnet.StackType != "IPV4_IPV6" {
+		return nil
+	}
+	if subnet.Ipv6CidrRange == "" && subnet.ExternalIpv6Prefix == "" && subnet.InternalIpv6Prefix == "" {
+		return errors.Errorf("dual-stack subnet %q has no IPv6 range", subnetKey.Name)
+	}
+
+	networkInterface.StackType = subnet.StackType
+	if subnet.Ipv6AccessType == "EXTERNAL" {
+		networkInterface.Ipv6AccessConfigs = []*compute.AccessConfig{
+			{
+				Type:        "DIRECT_IPV6",
+				Name:        "External IPv6",
+				NetworkTier: "PREMIUM",
+			},
+		}
+	}
+
+	return nil
+}
+
+// subnetKeyFromLink returns the regional key of a subnetwork from its (partial) URL in the form
+// projects/[PROJECT]/regions/[REGION]/subnetworks/[NAME].
+func subnetKeyFromLink(link string) (*meta.Key, error) {
+	parts := strings.Split(link, "/")
+	for i := 0; i+3 < len(parts); i++ {
+		if parts[i] == "regions" && parts[i+2] == "subnetworks" {
+			return meta.RegionalKey(parts[i+3], parts[i+1]), nil
+		}
+	}
+
+	return nil, errors.Errorf("invalid subnetwork link %q", link)
+}
+
+// validateSourceImage checks that the image of the boot disk exists when it is referenced explicitly, and that it
+// declares the required guest OS features, so that a misconfigured image is reported before creating the instance.
+// Image families are only resolved to their latest image when guest OS features are required, and the check is
+// skipped when the image cannot be read, for example without permissions on the image project.
+func (s *Service) validateSourceImage(ctx context.Context, instance *compute.Instance) error {
+	log := log.FromContext(ctx)
+	requiredFeatures := s.scope.RequiredGuestOSFeatures()
+	for _, disk := range instance.Disks {
+		if !disk.Boot || disk.InitializeParams == nil {
+			continue
+		}
+		sourceImage := disk.InitializeParams.SourceImage
+
+		var image *compute.Image
+		var err error
+		if project, name, ok := imageFromLink(sourceImage); ok {
+			log.V(2).Info("Looking for source image", "image", sourceImage)
+			image, err = s.images.Get(ctx, meta.GlobalKey(name), k8scloud.ForceProjectID(project))
+		} else if project, family, ok := imageFamilyFromLink(sourceImage); ok && len(requiredFeatures) > 0 {
+			log.V(2).Info("Looking for source image family", "imageFamily", sourceImage)
+			image, err = s.images.GetFromFamily(ctx, meta.GlobalKey(family), k8scloud.ForceProjectID(project))
+		} else {
+			continue
+		}
+		if err != nil {
+			if gcperrors.IsNotFound(err) {
+				return errors.Errorf("source image %q not found", sourceImage)
+			}
+			log.V(2).Info("Unable to validate source image", "image", sourceImage, "error", err.Error())
+			continue
+		}
+
+		if missing := missingGuestOSFeatures(image, requiredFeatures); len(missing) > 0 {
+			return errors.Errorf("source image %q does not declare the required guest OS features %s", sourceImage, strings.Join(missing, ", "))
+		}
+	}
+
+	return nil
+}
+
+// missingGuestOSFeatures returns the required guest OS features that are not declared by the image.
+func missingGuestOSFeatures(image *compute.Image, required []string) []string {
+	declared := make(map[string]struct{}, len(image.GuestOsFeatures))
+	for _, feature := range image.GuestOsFeatures {
+		declared[feature.Type] = struct{}{}
+	}
+
+	var missing []string
+	for _, feature := range required {
+		if _, ok := declared[feature]; !ok {
+			missing = append(missing, feature)
+		}
+	}
+	return missing
+}
+
+// validateDiskResourcePolicies returns an error if a resource policy attached to the boot disk of the instance
+// does not exist. Other errors are ignored and left to the instance creation.
+func (s *Service) validateDiskResourcePolicies(ctx context.Context, instance *compute.Instance) error {
+	log := log.FromContext(ctx)
+	for _, disk := range instance.Disks {
+		if !disk.Boot || disk.InitializeParams == nil {
+			continue
+		}
+		for _, link := range disk.InitializeParams.ResourcePolicies {
+			region, name, ok := resourcePolicyFromLink(link)
+			if !ok {
+				continue
+			}
+
+			log.V(2).Info("Looking for disk resource policy", "resourcePolicy", link)
+			if _, err := s.resourcePolicies.Get(ctx, meta.RegionalKey(name, region)); err != nil {
+				if gcperrors.IsNotFound(err) {
+					return errors.Errorf("resource policy %q not found in region %s", name, region)
+				}
+				log.V(2).Info("Unable to validate disk resource policy", "resourcePolicy", link, "error", err.Error())
+			}
+		}
+	}
+
+	return nil
+}
+
+// resourcePolicyFromLink returns the region and name of a resource policy link such as
+// projects/<project>/regions/<region>/resourcePolicies/<name>.
+func resourcePolicyFromLink(link string) (string, string, bool) {
+	parts := strings.Split(link, "/")
+	if n := len(parts); n >= 4 && parts[n-4] == "regions" && parts[n-2] == "resourcePolicies" {
+		return parts[n-3], parts[n-1], true
+	}
+	return "", "", false
+}
+
+// imageFromLink returns the project and name of an image link such as projects/<project>/global/images/<image>.
+// Links to image families are not matched.
+func imageFromLink(link string) (string, string, bool) {
+	parts := strings.Split(link, "/")
+	if n := len(parts); n >= 5 && parts[n-5] == "projects" && parts[n-3] == "global" && parts[n-2] == "images" {
+		return parts[n-4], parts[n-1], true
+	}
+
+	return "", "", false
+}
+
+// imageFamilyFromLink returns the project and name of an image family link such as
+// projects/<project>/global/images/family/<family>.
+func imageFamilyFromLink(link string) (string, string, bool) {
+	parts := strings.Split(link, "/")
+	if n := len(parts); n >= 6 && parts[n-6] == "projects" && parts[n-4] == "global" && parts[n-3] == "images" && parts[n-2] == "family" {
+		return parts[n-5], parts[n-1], true
+	}
+
+	return "", "", false
+}
+
 func (s *Service) registerControlPlaneInstance(ctx context.Context, instance *compute.Instance) error {
 	log := log.FromContext(ctx)
 	instancegroupName := s.scope.ControlPlaneGroupName()
@@ -184,7 +647,7 @@ func (s *Service) registerControlPlaneInstance(ctx context.Context, instance *co
 		instanceSets.Insert(i.Instance)
 	}
 
-	if !instanceSets.Has(instance.SelfLink) && instance.Status == string(infrav1.InstanceStatusRunning) {
+	if !instanceSets.Has(instance.SelfLink) && instance.Status == string(infrav1.InstanceStatusRunning) && !shared.SkipDryRun(ctx, s.scope, "register instance in", "instancegroup", instancegroupName) {
 		log.V(2).Info("Registering instance in the instancegroup", "name", instance.Name, "instancegroup", instancegroupName)
 		if err := s.instancegroups.AddInstances(ctx, instancegroupKey, &compute.InstanceGroupsAddInstancesRequest{
 			Instances: []*compute.InstanceReference{
@@ -218,7 +681,7 @@ func (s *Service) deregisterControlPlaneInstance(ctx context.Context, instance *
 		instanceSets.Insert(i.Instance)
 	}
 
-	if len(instanceSets.List()) > 0 && instanceSets.Has(instance.SelfLink) {
+	if len(instanceSets.List()) > 0 && instanceSets.Has(instance.SelfLink) && !shared.SkipDryRun(ctx, s.scope, "deregister instance from", "instancegroup", instancegroupName) {
 		log.V(2).Info("Deregistering instance in the instancegroup", "name", instance.Name, "instancegroup", instancegroupName)
 		if err := s.instancegroups.RemoveInstances(ctx, instancegroupKey, &compute.InstanceGroupsRemoveInstancesRequest{
 			Instances: []*compute.InstanceReference{
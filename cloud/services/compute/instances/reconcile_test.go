@@ -18,9 +18,15 @@ package instances
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
@@ -35,6 +41,7 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -54,6 +61,16 @@ var fakeBootstrapSecret = &corev1.Secret{
 	},
 }
 
+var fakeScriptSecret = &corev1.Secret{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-machine-scripts",
+		Namespace: "default",
+	},
+	Data: map[string][]byte{
+		"shutdown.sh": []byte("#!/bin/bash\necho shutdown"),
+	},
+}
+
 var fakeCluster = &clusterv1.Cluster{
 	ObjectMeta: metav1.ObjectMeta{
 		Name:      "my-cluster",
@@ -135,10 +152,19 @@ func getFakeGCPMachine() *infrav1.GCPMachine {
 
 var fakeGCPMachine = getFakeGCPMachine()
 
+type fakeResourcePolicies map[meta.Key]*compute.ResourcePolicy
+
+func (f fakeResourcePolicies) Get(_ context.Context, key *meta.Key) (*compute.ResourcePolicy, error) {
+	if policy, ok := f[*key]; ok {
+		return policy, nil
+	}
+	return nil, &googleapi.Error{Code: http.StatusNotFound}
+}
+
 func TestService_createOrGetInstance(t *testing.T) {
 	fakec := fake.NewClientBuilder().
 		WithScheme(scheme.Scheme).
-		WithObjects(fakeBootstrapSecret).
+		WithObjects(fakeBootstrapSecret, fakeScriptSecret).
 		Build()
 
 	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
@@ -186,11 +212,14 @@ func TestService_createOrGetInstance(t *testing.T) {
 	}
 
 	tests := []struct {
-		name         string
-		scope        func() Scope
-		mockInstance *cloud.MockInstances
-		want         *compute.Instance
-		wantErr      bool
+		name                 string
+		scope                func() Scope
+		mockInstance         *cloud.MockInstances
+		mockSubnetworks      *cloud.MockSubnetworks
+		mockImages           *cloud.MockImages
+		mockResourcePolicies fakeResourcePolicies
+		want                 *compute.Instance
+		wantErr              bool
 	}{
 		{
 			name:  "instance already exist (should return existing instance)",
@@ -350,6 +379,104 @@ func TestService_createOrGetInstance(t *testing.T) {
 				Zone: "us-central1-c",
 			},
 		},
+		{
+			name: "instance does not exist (should create instance) with startup and shutdown scripts",
+			scope: func() Scope {
+				machineScope.GCPMachine = getFakeGCPMachine()
+				machineScope.GCPMachine.Spec.AdditionalMetadata = []infrav1.MetadataItem{
+					{Key: "startup-script", Value: ptr.To("echo overridden")},
+				}
+				machineScope.GCPMachine.Spec.StartupScript = &infrav1.MachineScript{
+					Content: ptr.To("#!/bin/bash\necho startup"),
+				}
+				machineScope.GCPMachine.Spec.ShutdownScript = &infrav1.MachineScript{
+					SecretRef: &infrav1.SecretKeyReference{Name: "my-machine-scripts", Key: "shutdown.sh"},
+				}
+				return machineScope
+			},
+			mockInstance: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			want: &compute.Instance{
+				Name:         "my-machine",
+				CanIpForward: true,
+				Disks: []*compute.AttachedDisk{
+					{
+						AutoDelete: true,
+						Boot:       true,
+						InitializeParams: &compute.AttachedDiskInitializeParams{
+							DiskType:            "zones/us-central1-c/diskTypes/pd-standard",
+							SourceImage:         "projects/my-proj/global/images/family/capi-ubuntu-1804-k8s-v1-19",
+							ResourceManagerTags: map[string]string{},
+							Labels: map[string]string{
+								"foo": "bar",
+							},
+						},
+					},
+				},
+				Labels: map[string]string{
+					"capg-role":               "node",
+					"capg-cluster-my-cluster": "owned",
+					"foo":                     "bar",
+				},
+				MachineType: "zones/us-central1-c/machineTypes",
+				Metadata: &compute.Metadata{
+					Items: []*compute.MetadataItems{
+						{
+							Key:   "startup-script",
+							Value: ptr.To[string]("#!/bin/bash\necho startup"),
+						},
+						{
+							Key:   "user-data",
+							Value: ptr.To[string]("Zm9vCg=="),
+						},
+						{
+							Key:   "shutdown-script",
+							Value: ptr.To[string]("#!/bin/bash\necho shutdown"),
+						},
+					},
+				},
+				NetworkInterfaces: []*compute.NetworkInterface{
+					{
+						Network: "projects/my-proj/global/networks/default",
+					},
+				},
+				Params: &compute.InstanceParams{
+					ResourceManagerTags: map[string]string{},
+				},
+				SelfLink:   "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/instances/my-machine",
+				Scheduling: &compute.Scheduling{},
+				ServiceAccounts: []*compute.ServiceAccount{
+					{
+						Email:  "default",
+						Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+					},
+				},
+				Tags: &compute.Tags{
+					Items: []string{
+						"my-cluster-node",
+						"my-cluster",
+					},
+				},
+				Zone: "us-central1-c",
+			},
+		},
+		{
+			name: "instance does not exist and script secret does not exist (should return an error)",
+			scope: func() Scope {
+				machineScope.GCPMachine = getFakeGCPMachine()
+				machineScope.GCPMachine.Spec.StartupScript = &infrav1.MachineScript{
+					SecretRef: &infrav1.SecretKeyReference{Name: "missing", Key: "startup.sh"},
+				}
+				return machineScope
+			},
+			mockInstance: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			wantErr: true,
+		},
 		{
 			name: "instance does not exist (should create instance) and SecureBoot enabled",
 			scope: func() Scope {
@@ -495,6 +622,81 @@ func TestService_createOrGetInstance(t *testing.T) {
 				Zone: "us-central1-c",
 			},
 		},
+		{
+			name: "instance does not exist (should create instance) with confidential compute TDX and TERMINATE OnHostMaintenance",
+			scope: func() Scope {
+				machineScope.GCPMachine = getFakeGCPMachine()
+				confidentialComputePolicyTDX := infrav1.ConfidentialComputePolicyTDX
+				machineScope.GCPMachine.Spec.ConfidentialCompute = &confidentialComputePolicyTDX
+				hostMaintenancePolicyTerminate := infrav1.HostMaintenancePolicyTerminate
+				machineScope.GCPMachine.Spec.OnHostMaintenance = &hostMaintenancePolicyTerminate
+				return machineScope
+			},
+			mockInstance: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			want: &compute.Instance{
+				Name:         "my-machine",
+				CanIpForward: true,
+				Disks: []*compute.AttachedDisk{
+					{
+						AutoDelete: true,
+						Boot:       true,
+						InitializeParams: &compute.AttachedDiskInitializeParams{
+							DiskType:            "zones/us-central1-c/diskTypes/pd-standard",
+							SourceImage:         "projects/my-proj/global/images/family/capi-ubuntu-1804-k8s-v1-19",
+							ResourceManagerTags: map[string]string{},
+							Labels: map[string]string{
+								"foo": "bar",
+							},
+						},
+					},
+				},
+				Labels: map[string]string{
+					"capg-role":               "node",
+					"capg-cluster-my-cluster": "owned",
+					"foo":                     "bar",
+				},
+				MachineType: "zones/us-central1-c/machineTypes",
+				Metadata: &compute.Metadata{
+					Items: []*compute.MetadataItems{
+						{
+							Key:   "user-data",
+							Value: ptr.To[string]("Zm9vCg=="),
+						},
+					},
+				},
+				NetworkInterfaces: []*compute.NetworkInterface{
+					{
+						Network: "projects/my-proj/global/networks/default",
+					},
+				},
+				Params: &compute.InstanceParams{
+					ResourceManagerTags: map[string]string{},
+				},
+				SelfLink: "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/instances/my-machine",
+				ConfidentialInstanceConfig: &compute.ConfidentialInstanceConfig{
+					ConfidentialInstanceType: "TDX",
+				},
+				Scheduling: &compute.Scheduling{
+					OnHostMaintenance: strings.ToUpper(string(infrav1.HostMaintenancePolicyTerminate)),
+				},
+				ServiceAccounts: []*compute.ServiceAccount{
+					{
+						Email:  "default",
+						Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+					},
+				},
+				Tags: &compute.Tags{
+					Items: []string{
+						"my-cluster-node",
+						"my-cluster",
+					},
+				},
+				Zone: "us-central1-c",
+			},
+		},
 		{
 			name: "instance does not exist (should create instance) with MIGRATE OnHostMaintenance",
 			scope: func() Scope {
@@ -857,21 +1059,1510 @@ func TestService_createOrGetInstance(t *testing.T) {
 				Zone: "us-central1-c",
 			},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctx := context.TODO()
-			s := New(tt.scope())
-			s.instances = tt.mockInstance
-			got, err := s.createOrGetInstance(ctx)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("Service.createOrGetInstance() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-
-			if d := cmp.Diff(tt.want, got); d != "" {
-				t.Errorf("Service.createOrGetInstance() mismatch (-want +got):\n%s", d)
-			}
-		})
+		{
+			name: "instance does not exist (should create instance) with custom machine type",
+			scope: func() Scope {
+				machineScope.GCPMachine = getFakeGCPMachine()
+				machineScope.GCPMachine.Spec.InstanceType = "e2-custom-4-8192"
+				return machineScope
+			},
+			mockInstance: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			want: &compute.Instance{
+				Name:         "my-machine",
+				CanIpForward: true,
+				Disks: []*compute.AttachedDisk{
+					{
+						AutoDelete: true,
+						Boot:       true,
+						InitializeParams: &compute.AttachedDiskInitializeParams{
+							DiskType:            "zones/us-central1-c/diskTypes/pd-standard",
+							SourceImage:         "projects/my-proj/global/images/family/capi-ubuntu-1804-k8s-v1-19",
+							ResourceManagerTags: map[string]string{},
+							Labels: map[string]string{
+								"foo": "bar",
+							},
+						},
+					},
+				},
+				Labels: map[string]string{
+					"capg-role":               "node",
+					"capg-cluster-my-cluster": "owned",
+					"foo":                     "bar",
+				},
+				MachineType: "zones/us-central1-c/machineTypes/e2-custom-4-8192",
+				Metadata: &compute.Metadata{
+					Items: []*compute.MetadataItems{
+						{
+							Key:   "user-data",
+							Value: ptr.To[string]("Zm9vCg=="),
+						},
+					},
+				},
+				NetworkInterfaces: []*compute.NetworkInterface{
+					{
+						Network: "projects/my-proj/global/networks/default",
+					},
+				},
+				Params: &compute.InstanceParams{
+					ResourceManagerTags: map[string]string{},
+				},
+				SelfLink:   "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/instances/my-machine",
+				Scheduling: &compute.Scheduling{},
+				ServiceAccounts: []*compute.ServiceAccount{
+					{
+						Email:  "default",
+						Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+					},
+				},
+				Tags: &compute.Tags{
+					Items: []string{
+						"my-cluster-node",
+						"my-cluster",
+					},
+				},
+				Zone: "us-central1-c",
+			},
+		},
+		{
+			name: "instance does not exist (should create instance) with existing source image",
+			scope: func() Scope {
+				machineScope.GCPMachine = getFakeGCPMachine()
+				machineScope.GCPMachine.Spec.Image = ptr.To[string]("projects/my-images/global/images/golden")
+				return machineScope
+			},
+			mockInstance: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			mockImages: &cloud.MockImages{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-images"},
+				Objects: map[meta.Key]*cloud.MockImagesObj{
+					*meta.GlobalKey("golden"): {Obj: &compute.Image{Name: "golden"}},
+				},
+			},
+			want: &compute.Instance{
+				Name:         "my-machine",
+				CanIpForward: true,
+				Disks: []*compute.AttachedDisk{
+					{
+						AutoDelete: true,
+						Boot:       true,
+						InitializeParams: &compute.AttachedDiskInitializeParams{
+							DiskType:            "zones/us-central1-c/diskTypes/pd-standard",
+							SourceImage:         "projects/my-images/global/images/golden",
+							ResourceManagerTags: map[string]string{},
+							Labels: map[string]string{
+								"foo": "bar",
+							},
+						},
+					},
+				},
+				Labels: map[string]string{
+					"capg-role":               "node",
+					"capg-cluster-my-cluster": "owned",
+					"foo":                     "bar",
+				},
+				MachineType: "zones/us-central1-c/machineTypes",
+				Metadata: &compute.Metadata{
+					Items: []*compute.MetadataItems{
+						{
+							Key:   "user-data",
+							Value: ptr.To[string]("Zm9vCg=="),
+						},
+					},
+				},
+				NetworkInterfaces: []*compute.NetworkInterface{
+					{
+						Network: "projects/my-proj/global/networks/default",
+					},
+				},
+				Params: &compute.InstanceParams{
+					ResourceManagerTags: map[string]string{},
+				},
+				SelfLink:   "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/instances/my-machine",
+				Scheduling: &compute.Scheduling{},
+				ServiceAccounts: []*compute.ServiceAccount{
+					{
+						Email:  "default",
+						Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+					},
+				},
+				Tags: &compute.Tags{
+					Items: []string{
+						"my-cluster-node",
+						"my-cluster",
+					},
+				},
+				Zone: "us-central1-c",
+			},
+		},
+		{
+			name: "instance does not exist (should create instance) with existing source image declaring the required guest OS features",
+			scope: func() Scope {
+				machineScope.GCPMachine = getFakeGCPMachine()
+				machineScope.GCPMachine.Spec.Image = ptr.To[string]("projects/my-images/global/images/golden")
+				machineScope.GCPMachine.Spec.RequiredGuestOSFeatures = []infrav1.GuestOSFeature{infrav1.GuestOSFeatureUEFICompatible, infrav1.GuestOSFeatureGVNIC}
+				return machineScope
+			},
+			mockInstance: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			mockImages: &cloud.MockImages{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-images"},
+				Objects: map[meta.Key]*cloud.MockImagesObj{
+					*meta.GlobalKey("golden"): {Obj: &compute.Image{
+						Name:            "golden",
+						GuestOsFeatures: []*compute.GuestOsFeature{{Type: "UEFI_COMPATIBLE"}, {Type: "GVNIC"}, {Type: "VIRTIO_SCSI_MULTIQUEUE"}},
+					}},
+				},
+			},
+			want: &compute.Instance{
+				Name:         "my-machine",
+				CanIpForward: true,
+				Disks: []*compute.AttachedDisk{
+					{
+						AutoDelete: true,
+						Boot:       true,
+						InitializeParams: &compute.AttachedDiskInitializeParams{
+							DiskType:            "zones/us-central1-c/diskTypes/pd-standard",
+							SourceImage:         "projects/my-images/global/images/golden",
+							ResourceManagerTags: map[string]string{},
+							Labels: map[string]string{
+								"foo": "bar",
+							},
+						},
+					},
+				},
+				Labels: map[string]string{
+					"capg-role":               "node",
+					"capg-cluster-my-cluster": "owned",
+					"foo":                     "bar",
+				},
+				MachineType: "zones/us-central1-c/machineTypes",
+				Metadata: &compute.Metadata{
+					Items: []*compute.MetadataItems{
+						{
+							Key:   "user-data",
+							Value: ptr.To[string]("Zm9vCg=="),
+						},
+					},
+				},
+				NetworkInterfaces: []*compute.NetworkInterface{
+					{
+						Network: "projects/my-proj/global/networks/default",
+					},
+				},
+				Params: &compute.InstanceParams{
+					ResourceManagerTags: map[string]string{},
+				},
+				SelfLink:   "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/instances/my-machine",
+				Scheduling: &compute.Scheduling{},
+				ServiceAccounts: []*compute.ServiceAccount{
+					{
+						Email:  "default",
+						Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+					},
+				},
+				Tags: &compute.Tags{
+					Items: []string{
+						"my-cluster-node",
+						"my-cluster",
+					},
+				},
+				Zone: "us-central1-c",
+			},
+		},
+		{
+			name: "instance does not exist and source image does not declare the required guest OS features (should return an error)",
+			scope: func() Scope {
+				machineScope.GCPMachine = getFakeGCPMachine()
+				machineScope.GCPMachine.Spec.Image = ptr.To[string]("projects/my-images/global/images/golden")
+				machineScope.GCPMachine.Spec.RequiredGuestOSFeatures = []infrav1.GuestOSFeature{infrav1.GuestOSFeatureSEVCapable}
+				return machineScope
+			},
+			mockInstance: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			mockImages: &cloud.MockImages{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-images"},
+				Objects: map[meta.Key]*cloud.MockImagesObj{
+					*meta.GlobalKey("golden"): {Obj: &compute.Image{
+						Name:            "golden",
+						GuestOsFeatures: []*compute.GuestOsFeature{{Type: "UEFI_COMPATIBLE"}},
+					}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "instance does not exist and latest image of the family does not declare the required guest OS features (should return an error)",
+			scope: func() Scope {
+				machineScope.GCPMachine = getFakeGCPMachine()
+				machineScope.GCPMachine.Spec.ImageFamily = ptr.To[string]("projects/my-images/global/images/family/golden")
+				machineScope.GCPMachine.Spec.RequiredGuestOSFeatures = []infrav1.GuestOSFeature{infrav1.GuestOSFeatureTDXCapable}
+				return machineScope
+			},
+			mockInstance: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			mockImages: &cloud.MockImages{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-images"},
+				GetFromFamilyHook: func(_ context.Context, key *meta.Key, _ *cloud.MockImages, _ ...cloud.Option) (*compute.Image, error) {
+					if key.Name != "golden" {
+						return nil, &googleapi.Error{Code: http.StatusNotFound}
+					}
+					return &compute.Image{
+						Name:            "golden-v2",
+						GuestOsFeatures: []*compute.GuestOsFeature{{Type: "UEFI_COMPATIBLE"}, {Type: "SEV_CAPABLE"}},
+					}, nil
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "instance does not exist and source image does not exist (should return an error)",
+			scope: func() Scope {
+				machineScope.GCPMachine = getFakeGCPMachine()
+				machineScope.GCPMachine.Spec.Image = ptr.To[string]("projects/my-images/global/images/missing")
+				return machineScope
+			},
+			mockInstance: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			mockImages: &cloud.MockImages{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-images"},
+				Objects:       map[meta.Key]*cloud.MockImagesObj{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "instance does not exist (should create instance) with disk resource policies",
+			scope: func() Scope {
+				machineScope.GCPMachine = getFakeGCPMachine()
+				machineScope.GCPMachine.Spec.DiskResourcePolicies = []string{"daily-snapshots"}
+				return machineScope
+			},
+			mockInstance: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			mockResourcePolicies: fakeResourcePolicies{
+				*meta.RegionalKey("daily-snapshots", "us-central1"): {Name: "daily-snapshots"},
+			},
+			want: &compute.Instance{
+				Name:         "my-machine",
+				CanIpForward: true,
+				Disks: []*compute.AttachedDisk{
+					{
+						AutoDelete: true,
+						Boot:       true,
+						InitializeParams: &compute.AttachedDiskInitializeParams{
+							DiskType:            "zones/us-central1-c/diskTypes/pd-standard",
+							SourceImage:         "projects/my-proj/global/images/family/capi-ubuntu-1804-k8s-v1-19",
+							ResourceManagerTags: map[string]string{},
+							ResourcePolicies:    []string{"projects/my-proj/regions/us-central1/resourcePolicies/daily-snapshots"},
+							Labels: map[string]string{
+								"foo": "bar",
+							},
+						},
+					},
+				},
+				Labels: map[string]string{
+					"capg-role":               "node",
+					"capg-cluster-my-cluster": "owned",
+					"foo":                     "bar",
+				},
+				MachineType: "zones/us-central1-c/machineTypes",
+				Metadata: &compute.Metadata{
+					Items: []*compute.MetadataItems{
+						{
+							Key:   "user-data",
+							Value: ptr.To[string]("Zm9vCg=="),
+						},
+					},
+				},
+				NetworkInterfaces: []*compute.NetworkInterface{
+					{
+						Network: "projects/my-proj/global/networks/default",
+					},
+				},
+				Params: &compute.InstanceParams{
+					ResourceManagerTags: map[string]string{},
+				},
+				SelfLink:   "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/instances/my-machine",
+				Scheduling: &compute.Scheduling{},
+				ServiceAccounts: []*compute.ServiceAccount{
+					{
+						Email:  "default",
+						Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+					},
+				},
+				Tags: &compute.Tags{
+					Items: []string{
+						"my-cluster-node",
+						"my-cluster",
+					},
+				},
+				Zone: "us-central1-c",
+			},
+		},
+		{
+			name: "instance does not exist and disk resource policy does not exist (should return an error)",
+			scope: func() Scope {
+				machineScope.GCPMachine = getFakeGCPMachine()
+				machineScope.GCPMachine.Spec.DiskResourcePolicies = []string{"missing"}
+				return machineScope
+			},
+			mockInstance: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			mockResourcePolicies: fakeResourcePolicies{},
+			wantErr:              true,
+		},
+		{
+			name: "instance does not exist and IPV4_IPV6 subnet has no IPv6 range (should return an error)",
+			scope: func() Scope {
+				machineScope.GCPMachine = getFakeGCPMachine()
+				machineScope.GCPMachine.Spec.Subnet = ptr.To[string]("my-subnet")
+				return machineScope
+			},
+			mockInstance: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			mockSubnetworks: &cloud.MockSubnetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects: map[meta.Key]*cloud.MockSubnetworksObj{
+					*meta.RegionalKey("my-subnet", "us-central1"): {
+						Obj: &compute.Subnetwork{
+							Name:           "my-subnet",
+							Region:         "us-central1",
+							StackType:      "IPV4_IPV6",
+							Ipv6AccessType: "EXTERNAL",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "instance does not exist (should create instance) with IPV4_IPV6 subnet",
+			scope: func() Scope {
+				machineScope.GCPMachine = getFakeGCPMachine()
+				machineScope.GCPMachine.Spec.Subnet = ptr.To[string]("my-subnet")
+				return machineScope
+			},
+			mockInstance: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			mockSubnetworks: &cloud.MockSubnetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects: map[meta.Key]*cloud.MockSubnetworksObj{
+					*meta.RegionalKey("my-subnet", "us-central1"): {
+						Obj: &compute.Subnetwork{
+							Name:               "my-subnet",
+							Region:             "us-central1",
+							StackType:          "IPV4_IPV6",
+							Ipv6AccessType:     "EXTERNAL",
+							ExternalIpv6Prefix: "2600:1900:4000:1::/64",
+						},
+					},
+				},
+			},
+			want: &compute.Instance{
+				Name:         "my-machine",
+				CanIpForward: true,
+				Disks: []*compute.AttachedDisk{
+					{
+						AutoDelete: true,
+						Boot:       true,
+						InitializeParams: &compute.AttachedDiskInitializeParams{
+							DiskType:            "zones/us-central1-c/diskTypes/pd-standard",
+							SourceImage:         "projects/my-proj/global/images/family/capi-ubuntu-1804-k8s-v1-19",
+							ResourceManagerTags: map[string]string{},
+							Labels: map[string]string{
+								"foo": "bar",
+							},
+						},
+					},
+				},
+				Labels: map[string]string{
+					"capg-role":               "node",
+					"capg-cluster-my-cluster": "owned",
+					"foo":                     "bar",
+				},
+				MachineType: "zones/us-central1-c/machineTypes",
+				Metadata: &compute.Metadata{
+					Items: []*compute.MetadataItems{
+						{
+							Key:   "user-data",
+							Value: ptr.To[string]("Zm9vCg=="),
+						},
+					},
+				},
+				NetworkInterfaces: []*compute.NetworkInterface{
+					{
+						Network:    "projects/my-proj/global/networks/default",
+						Subnetwork: "projects/my-proj/regions/us-central1/subnetworks/my-subnet",
+						StackType:  "IPV4_IPV6",
+						Ipv6AccessConfigs: []*compute.AccessConfig{
+							{
+								Type:        "DIRECT_IPV6",
+								Name:        "External IPv6",
+								NetworkTier: "PREMIUM",
+							},
+						},
+					},
+				},
+				Params: &compute.InstanceParams{
+					ResourceManagerTags: map[string]string{},
+				},
+				SelfLink:   "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/instances/my-machine",
+				Scheduling: &compute.Scheduling{},
+				ServiceAccounts: []*compute.ServiceAccount{
+					{
+						Email:  "default",
+						Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+					},
+				},
+				Tags: &compute.Tags{
+					Items: []string{
+						"my-cluster-node",
+						"my-cluster",
+					},
+				},
+				Zone: "us-central1-c",
+			},
+		},
+		{
+			name: "instance does not exist (should create instance) with IPV4_ONLY subnet",
+			scope: func() Scope {
+				machineScope.GCPMachine = getFakeGCPMachine()
+				machineScope.GCPMachine.Spec.Subnet = ptr.To[string]("my-subnet")
+				return machineScope
+			},
+			mockInstance: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			mockSubnetworks: &cloud.MockSubnetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects: map[meta.Key]*cloud.MockSubnetworksObj{
+					*meta.RegionalKey("my-subnet", "us-central1"): {
+						Obj: &compute.Subnetwork{
+							Name:      "my-subnet",
+							Region:    "us-central1",
+							StackType: "IPV4_ONLY",
+						},
+					},
+				},
+			},
+			want: &compute.Instance{
+				Name:         "my-machine",
+				CanIpForward: true,
+				Disks: []*compute.AttachedDisk{
+					{
+						AutoDelete: true,
+						Boot:       true,
+						InitializeParams: &compute.AttachedDiskInitializeParams{
+							DiskType:            "zones/us-central1-c/diskTypes/pd-standard",
+							SourceImage:         "projects/my-proj/global/images/family/capi-ubuntu-1804-k8s-v1-19",
+							ResourceManagerTags: map[string]string{},
+							Labels: map[string]string{
+								"foo": "bar",
+							},
+						},
+					},
+				},
+				Labels: map[string]string{
+					"capg-role":               "node",
+					"capg-cluster-my-cluster": "owned",
+					"foo":                     "bar",
+				},
+				MachineType: "zones/us-central1-c/machineTypes",
+				Metadata: &compute.Metadata{
+					Items: []*compute.MetadataItems{
+						{
+							Key:   "user-data",
+							Value: ptr.To[string]("Zm9vCg=="),
+						},
+					},
+				},
+				NetworkInterfaces: []*compute.NetworkInterface{
+					{
+						Network:    "projects/my-proj/global/networks/default",
+						Subnetwork: "projects/my-proj/regions/us-central1/subnetworks/my-subnet",
+					},
+				},
+				Params: &compute.InstanceParams{
+					ResourceManagerTags: map[string]string{},
+				},
+				SelfLink:   "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/instances/my-machine",
+				Scheduling: &compute.Scheduling{},
+				ServiceAccounts: []*compute.ServiceAccount{
+					{
+						Email:  "default",
+						Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+					},
+				},
+				Tags: &compute.Tags{
+					Items: []string{
+						"my-cluster-node",
+						"my-cluster",
+					},
+				},
+				Zone: "us-central1-c",
+			},
+		},
+		{
+			name: "instance does not exist and subnet lookup fails (should return an error)",
+			scope: func() Scope {
+				machineScope.GCPMachine = getFakeGCPMachine()
+				machineScope.GCPMachine.Spec.Subnet = ptr.To[string]("my-subnet")
+				return machineScope
+			},
+			mockInstance: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			mockSubnetworks: &cloud.MockSubnetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockSubnetworksObj{},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			s := New(tt.scope())
+			s.instances = tt.mockInstance
+			if tt.mockSubnetworks != nil {
+				s.subnets = tt.mockSubnetworks
+			}
+			if tt.mockImages != nil {
+				s.images = tt.mockImages
+			}
+			if tt.mockResourcePolicies != nil {
+				s.resourcePolicies = tt.mockResourcePolicies
+			}
+			s.networkTags = &fakeNetworkTags{calls: &[]string{}}
+			s.labels = &fakeLabels{calls: &[]string{}}
+			s.metadata = &fakeMetadata{}
+			got, err := s.createOrGetInstance(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.createOrGetInstance() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("Service.createOrGetInstance() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestImageFromLink(t *testing.T) {
+	tests := []struct {
+		link        string
+		wantProject string
+		wantImage   string
+		wantOK      bool
+	}{
+		{
+			link:        "projects/my-images/global/images/golden",
+			wantProject: "my-images",
+			wantImage:   "golden",
+			wantOK:      true,
+		},
+		{
+			link:        "https://www.googleapis.com/compute/v1/projects/my-images/global/images/golden",
+			wantProject: "my-images",
+			wantImage:   "golden",
+			wantOK:      true,
+		},
+		{
+			link: "projects/my-images/global/images/family/golden",
+		},
+		{
+			link: "global/images/golden",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.link, func(t *testing.T) {
+			project, image, ok := imageFromLink(tt.link)
+			if project != tt.wantProject || image != tt.wantImage || ok != tt.wantOK {
+				t.Errorf("imageFromLink() = (%q, %q, %v), want (%q, %q, %v)", project, image, ok, tt.wantProject, tt.wantImage, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestImageFamilyFromLink(t *testing.T) {
+	tests := []struct {
+		link        string
+		wantProject string
+		wantFamily  string
+		wantOK      bool
+	}{
+		{
+			link:        "projects/my-images/global/images/family/golden",
+			wantProject: "my-images",
+			wantFamily:  "golden",
+			wantOK:      true,
+		},
+		{
+			link:        "https://www.googleapis.com/compute/v1/projects/my-images/global/images/family/golden",
+			wantProject: "my-images",
+			wantFamily:  "golden",
+			wantOK:      true,
+		},
+		{
+			link: "projects/my-images/global/images/golden",
+		},
+		{
+			link: "global/images/family/golden",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.link, func(t *testing.T) {
+			project, family, ok := imageFamilyFromLink(tt.link)
+			if project != tt.wantProject || family != tt.wantFamily || ok != tt.wantOK {
+				t.Errorf("imageFamilyFromLink() = (%q, %q, %v), want (%q, %q, %v)", project, family, ok, tt.wantProject, tt.wantFamily, tt.wantOK)
+			}
+		})
+	}
+}
+
+type fakeDeletionProtection struct {
+	calls *[]string
+	err   error
+}
+
+func (f *fakeDeletionProtection) SetDeletionProtection(_ context.Context, key *meta.Key, protected bool) error {
+	*f.calls = append(*f.calls, "setDeletionProtection:"+key.Name+":"+strconv.FormatBool(protected))
+	return f.err
+}
+
+type fakeNetworkTags struct {
+	calls *[]string
+}
+
+func (f *fakeNetworkTags) SetTags(_ context.Context, key *meta.Key, tags *compute.Tags) error {
+	*f.calls = append(*f.calls, "setTags:"+key.Name+":"+strings.Join(tags.Items, ",")+":"+tags.Fingerprint)
+	return nil
+}
+
+func TestService_updateNetworkTags(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(fakeBootstrapSecret).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:        fakec,
+		Machine:       fakeMachine,
+		GCPMachine:    fakeGCPMachine,
+		ClusterGetter: clusterScope,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	instanceKey := meta.ZonalKey("my-machine", "us-central1-c")
+	tests := []struct {
+		name      string
+		current   *compute.Tags
+		desired   []string
+		wantCalls []string
+	}{
+		{
+			name:    "tags unchanged (should not set tags)",
+			current: &compute.Tags{Items: []string{"my-cluster", "my-cluster-node"}, Fingerprint: "abc"},
+			desired: []string{"my-cluster-node", "my-cluster"},
+		},
+		{
+			name:      "additional tag added (should set tags with the current fingerprint)",
+			current:   &compute.Tags{Items: []string{"my-cluster-node", "my-cluster"}, Fingerprint: "abc"},
+			desired:   []string{"allow-https", "my-cluster-node", "my-cluster"},
+			wantCalls: []string{"setTags:my-machine:allow-https,my-cluster-node,my-cluster:abc"},
+		},
+		{
+			name:      "additional tag removed (should set tags with the current fingerprint)",
+			current:   &compute.Tags{Items: []string{"allow-https", "my-cluster-node", "my-cluster"}, Fingerprint: "def"},
+			desired:   []string{"my-cluster-node", "my-cluster"},
+			wantCalls: []string{"setTags:my-machine:my-cluster-node,my-cluster:def"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			var calls []string
+			s := New(machineScope)
+			s.networkTags = &fakeNetworkTags{calls: &calls}
+
+			instance := &compute.Instance{Name: "my-machine", Tags: tt.current}
+			if err := s.updateNetworkTags(ctx, instanceKey, instance, &compute.Tags{Items: tt.desired}); err != nil {
+				t.Fatalf("Service.updateNetworkTags() error = %v", err)
+			}
+			if d := cmp.Diff(tt.wantCalls, calls); d != "" {
+				t.Errorf("Service.updateNetworkTags() calls mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+type fakeLabels struct {
+	calls *[]string
+}
+
+func (f *fakeLabels) SetLabels(_ context.Context, key *meta.Key, req *compute.InstancesSetLabelsRequest) error {
+	labels := make([]string, 0, len(req.Labels))
+	for k, v := range req.Labels {
+		labels = append(labels, k+"="+v)
+	}
+	sort.Strings(labels)
+	*f.calls = append(*f.calls, "setLabels:"+key.Name+":"+strings.Join(labels, ",")+":"+req.LabelFingerprint)
+	return nil
+}
+
+func TestService_updateLabels(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(fakeBootstrapSecret).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:        fakec,
+		Machine:       fakeMachine,
+		GCPMachine:    fakeGCPMachine,
+		ClusterGetter: clusterScope,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	instanceKey := meta.ZonalKey("my-machine", "us-central1-c")
+	tests := []struct {
+		name      string
+		current   map[string]string
+		desired   map[string]string
+		wantCalls []string
+	}{
+		{
+			name:    "labels unchanged (should not set labels)",
+			current: map[string]string{"capg-role": "node", "team": "a"},
+			desired: map[string]string{"capg-role": "node", "team": "a"},
+		},
+		{
+			name:      "user label changed (should set labels with the current fingerprint)",
+			current:   map[string]string{"capg-role": "node", "team": "a"},
+			desired:   map[string]string{"capg-role": "node", "team": "b"},
+			wantCalls: []string{"setLabels:my-machine:capg-role=node,team=b:abc"},
+		},
+		{
+			name:      "user label removed (should keep the CAPG-managed labels)",
+			current:   map[string]string{"capg-cluster-old": "owned", "capg-role": "node", "team": "a"},
+			desired:   map[string]string{"capg-role": "node"},
+			wantCalls: []string{"setLabels:my-machine:capg-cluster-old=owned,capg-role=node:abc"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			var calls []string
+			s := New(machineScope)
+			s.labels = &fakeLabels{calls: &calls}
+
+			instance := &compute.Instance{Name: "my-machine", Labels: tt.current, LabelFingerprint: "abc"}
+			if err := s.updateLabels(ctx, instanceKey, instance, tt.desired); err != nil {
+				t.Fatalf("Service.updateLabels() error = %v", err)
+			}
+			if d := cmp.Diff(tt.wantCalls, calls); d != "" {
+				t.Errorf("Service.updateLabels() calls mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+type fakeMetadata struct {
+	calls []*compute.Metadata
+}
+
+func (f *fakeMetadata) SetMetadata(_ context.Context, _ *meta.Key, metadata *compute.Metadata) error {
+	f.calls = append(f.calls, metadata)
+	return nil
+}
+
+func TestService_updateMetadata(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(fakeBootstrapSecret).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:        fakec,
+		Machine:       fakeMachine,
+		GCPMachine:    fakeGCPMachine,
+		ClusterGetter: clusterScope,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	instanceKey := meta.ZonalKey("my-machine", "us-central1-c")
+	current := &compute.Metadata{
+		Fingerprint: "abc",
+		Items: []*compute.MetadataItems{
+			{Key: "user-data", Value: ptr.To("bootstrap")},
+			{Key: "enable-oslogin", Value: ptr.To("FALSE")},
+			{Key: "set-out-of-band", Value: ptr.To("value")},
+		},
+	}
+	tests := []struct {
+		name      string
+		desired   []*compute.MetadataItems
+		wantCalls []*compute.Metadata
+	}{
+		{
+			name: "metadata unchanged (should not set metadata)",
+			desired: []*compute.MetadataItems{
+				{Key: "user-data", Value: ptr.To("bootstrap")},
+				{Key: "enable-oslogin", Value: ptr.To("FALSE")},
+			},
+		},
+		{
+			name: "metadata item changed (should set metadata without dropping user-data)",
+			desired: []*compute.MetadataItems{
+				{Key: "user-data", Value: ptr.To("rotated-bootstrap")},
+				{Key: "enable-oslogin", Value: ptr.To("TRUE")},
+				{Key: "team", Value: ptr.To("a")},
+			},
+			wantCalls: []*compute.Metadata{
+				{
+					Fingerprint: "abc",
+					Items: []*compute.MetadataItems{
+						{Key: "user-data", Value: ptr.To("bootstrap")},
+						{Key: "enable-oslogin", Value: ptr.To("TRUE")},
+						{Key: "set-out-of-band", Value: ptr.To("value")},
+						{Key: "team", Value: ptr.To("a")},
+					},
+				},
+			},
+		},
+		{
+			name: "setting turned off (should remove its metadata item and keep the others)",
+			desired: []*compute.MetadataItems{
+				{Key: "user-data", Value: ptr.To("bootstrap")},
+			},
+			wantCalls: []*compute.Metadata{
+				{
+					Fingerprint: "abc",
+					Items: []*compute.MetadataItems{
+						{Key: "user-data", Value: ptr.To("bootstrap")},
+						{Key: "set-out-of-band", Value: ptr.To("value")},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			fake := &fakeMetadata{}
+			s := New(machineScope)
+			s.metadata = fake
+
+			instance := &compute.Instance{Name: "my-machine", Metadata: current}
+			if err := s.updateMetadata(ctx, instanceKey, instance, &compute.Metadata{Items: tt.desired}); err != nil {
+				t.Fatalf("Service.updateMetadata() error = %v", err)
+			}
+			if d := cmp.Diff(tt.wantCalls, fake.calls); d != "" {
+				t.Errorf("Service.updateMetadata() calls mismatch (-want +got):\n%s", d)
+			}
+			if value := ptr.Deref(current.Items[1].Value, ""); value != "FALSE" {
+				t.Errorf("Service.updateMetadata() modified the metadata of the instance: enable-oslogin = %q", value)
+			}
+		})
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(fakeBootstrapSecret).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:        fakec,
+		Machine:       fakeMachine,
+		GCPMachine:    fakeGCPMachine,
+		ClusterGetter: clusterScope,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                  string
+		instance              *compute.Instance
+		deletionProtectionErr error
+		wantCalls             []string
+		wantErr               bool
+	}{
+		{
+			name:      "instance does not exist (should do nothing)",
+			wantCalls: nil,
+		},
+		{
+			name:      "instance without deletion protection (should delete instance)",
+			instance:  &compute.Instance{Name: "my-machine"},
+			wantCalls: []string{"delete:my-machine"},
+		},
+		{
+			name:     "instance with deletion protection (should disable protection before deleting instance)",
+			instance: &compute.Instance{Name: "my-machine", DeletionProtection: true},
+			wantCalls: []string{
+				"setDeletionProtection:my-machine:false",
+				"delete:my-machine",
+			},
+		},
+		{
+			name:                  "error disabling deletion protection (should not delete instance)",
+			instance:              &compute.Instance{Name: "my-machine", DeletionProtection: true},
+			deletionProtectionErr: &googleapi.Error{Code: http.StatusBadRequest},
+			wantCalls:             []string{"setDeletionProtection:my-machine:false"},
+			wantErr:               true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			var calls []string
+			objects := map[meta.Key]*cloud.MockInstancesObj{}
+			if tt.instance != nil {
+				objects[meta.Key{Name: "my-machine", Zone: "us-central1-c"}] = &cloud.MockInstancesObj{Obj: tt.instance}
+			}
+			s := New(machineScope)
+			s.instances = &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       objects,
+				DeleteHook: func(_ context.Context, key *meta.Key, _ *cloud.MockInstances, _ ...cloud.Option) (bool, error) {
+					calls = append(calls, "delete:"+key.Name)
+					return true, nil
+				},
+			}
+			s.deletionProtection = &fakeDeletionProtection{calls: &calls, err: tt.deletionProtectionErr}
+
+			err := s.Delete(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Delete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if d := cmp.Diff(tt.wantCalls, calls); d != "" {
+				t.Errorf("Service.Delete() calls mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestService_DryRun(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(fakeBootstrapSecret).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+		DryRun: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:        fakec,
+		Machine:       fakeMachine,
+		GCPMachine:    fakeGCPMachine,
+		ClusterGetter: clusterScope,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		instance *compute.Instance
+		run      func(ctx context.Context, s *Service) error
+		wantErr  error
+	}{
+		{
+			name: "instance does not exist (should not create instance)",
+			run: func(ctx context.Context, s *Service) error {
+				return s.Reconcile(ctx)
+			},
+			wantErr: shared.ErrDryRun,
+		},
+		{
+			name:     "instance drifted from the spec (should not update instance)",
+			instance: &compute.Instance{Name: "my-machine", Status: "RUNNING"},
+			run: func(ctx context.Context, s *Service) error {
+				_, err := s.createOrGetInstance(ctx)
+				return err
+			},
+		},
+		{
+			name:     "instance with deletion protection (should not delete instance)",
+			instance: &compute.Instance{Name: "my-machine", DeletionProtection: true},
+			run: func(ctx context.Context, s *Service) error {
+				return s.Delete(ctx)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			var calls []string
+			objects := map[meta.Key]*cloud.MockInstancesObj{}
+			if tt.instance != nil {
+				objects[meta.Key{Name: "my-machine", Zone: "us-central1-c"}] = &cloud.MockInstancesObj{Obj: tt.instance}
+			}
+			metadata := &fakeMetadata{}
+			s := New(machineScope)
+			s.instances = &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       objects,
+				InsertHook: func(_ context.Context, key *meta.Key, _ *compute.Instance, _ *cloud.MockInstances, _ ...cloud.Option) (bool, error) {
+					calls = append(calls, "insert:"+key.Name)
+					return true, nil
+				},
+				DeleteHook: func(_ context.Context, key *meta.Key, _ *cloud.MockInstances, _ ...cloud.Option) (bool, error) {
+					calls = append(calls, "delete:"+key.Name)
+					return true, nil
+				},
+			}
+			s.deletionProtection = &fakeDeletionProtection{calls: &calls}
+			s.networkTags = &fakeNetworkTags{calls: &calls}
+			s.labels = &fakeLabels{calls: &calls}
+			s.metadata = metadata
+
+			if err := tt.run(ctx, s); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Service error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(calls) > 0 || len(metadata.calls) > 0 {
+				t.Errorf("Service made changes in dry-run mode: %v %v", calls, metadata.calls)
+			}
+		})
+	}
+}
+
+func TestService_resizeRootDisk(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(fakeBootstrapSecret).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diskKey := meta.ZonalKey("my-machine", "us-central1-c")
+	instance := &compute.Instance{
+		Name: "my-machine",
+		Disks: []*compute.AttachedDisk{
+			{
+				Boot:   true,
+				Source: "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-c/disks/my-machine",
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		rootDeviceSize int64
+		wantResizes    []int64
+	}{
+		{
+			name:           "root device size not set (should not resize the disk)",
+			rootDeviceSize: 0,
+		},
+		{
+			name:           "root device size equal to the disk size (should not resize the disk)",
+			rootDeviceSize: 30,
+		},
+		{
+			name:           "root device size smaller than the disk size (should not shrink the disk)",
+			rootDeviceSize: 20,
+		},
+		{
+			name:           "root device size larger than the disk size (should resize the disk)",
+			rootDeviceSize: 50,
+			wantResizes:    []int64{50},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			gcpMachine := getFakeGCPMachine()
+			gcpMachine.Spec.RootDeviceSize = tt.rootDeviceSize
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:        fakec,
+				Machine:       fakeMachine,
+				GCPMachine:    gcpMachine,
+				ClusterGetter: clusterScope,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var resizes []int64
+			s := New(machineScope)
+			s.disks = &cloud.MockDisks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockDisksObj{
+					*diskKey: {Obj: &compute.Disk{Name: "my-machine", SizeGb: 30}},
+				},
+				ResizeHook: func(_ context.Context, key *meta.Key, req *compute.DisksResizeRequest, _ *cloud.MockDisks, _ ...cloud.Option) error {
+					if *key != *diskKey {
+						t.Errorf("unexpected disk resized: %v", key)
+					}
+					resizes = append(resizes, req.SizeGb)
+					return nil
+				},
+			}
+
+			if err := s.resizeRootDisk(ctx, instance); err != nil {
+				t.Fatalf("Service.resizeRootDisk() error = %v", err)
+			}
+			if d := cmp.Diff(tt.wantResizes, resizes); d != "" {
+				t.Errorf("Service.resizeRootDisk() resizes mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+type fakeBootstrapData struct {
+	calls []string
+}
+
+func (f *fakeBootstrapData) Upload(_ context.Context, bucket, object string, data []byte) error {
+	f.calls = append(f.calls, "upload:"+bucket+"/"+object+":"+string(data))
+	return nil
+}
+
+func (f *fakeBootstrapData) SignedURL(_ context.Context, bucket, object string, expiry time.Duration) (string, error) {
+	f.calls = append(f.calls, "sign:"+bucket+"/"+object+":"+expiry.String())
+	return "https://storage.googleapis.com/" + bucket + "/" + object + "?X-Goog-Signature=abc", nil
+}
+
+func (f *fakeBootstrapData) Delete(_ context.Context, bucket, object string) error {
+	f.calls = append(f.calls, "delete:"+bucket+"/"+object)
+	return &googleapi.Error{Code: http.StatusNotFound}
+}
+
+func TestService_bootstrapDataStorage(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(fakeBootstrapSecret).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		storage      *infrav1.BootstrapDataStorage
+		insertErr    error
+		wantUserData string
+		wantCalls    []string
+	}{
+		{
+			name:         "no bootstrap data storage (should pass the bootstrap data in the user-data)",
+			wantUserData: "Zm9vCg==",
+		},
+		{
+			name:         "bootstrap data below the threshold (should pass the bootstrap data in the user-data)",
+			storage:      &infrav1.BootstrapDataStorage{Bucket: "my-bucket"},
+			wantUserData: "Zm9vCg==",
+			wantCalls:    []string{"delete:my-bucket/bootstrap-data/my-cluster/default/my-machine"},
+		},
+		{
+			name:         "bootstrap data above the threshold (should upload the bootstrap data and make cloud-init include it)",
+			storage:      &infrav1.BootstrapDataStorage{Bucket: "my-bucket", Threshold: ptr.To[int64](4)},
+			wantUserData: "#include\nhttps://storage.googleapis.com/my-bucket/bootstrap-data/my-cluster/default/my-machine?X-Goog-Signature=abc\n",
+			wantCalls: []string{
+				"upload:my-bucket/bootstrap-data/my-cluster/default/my-machine:Zm9vCg==",
+				"sign:my-bucket/bootstrap-data/my-cluster/default/my-machine:24h0m0s",
+				"delete:my-bucket/bootstrap-data/my-cluster/default/my-machine",
+			},
+		},
+		{
+			name:         "instance creation fails (should delete the uploaded bootstrap data)",
+			storage:      &infrav1.BootstrapDataStorage{Bucket: "my-bucket", Threshold: ptr.To[int64](4)},
+			insertErr:    &googleapi.Error{Code: http.StatusBadRequest},
+			wantUserData: "#include\nhttps://storage.googleapis.com/my-bucket/bootstrap-data/my-cluster/default/my-machine?X-Goog-Signature=abc\n",
+			wantCalls: []string{
+				"upload:my-bucket/bootstrap-data/my-cluster/default/my-machine:Zm9vCg==",
+				"sign:my-bucket/bootstrap-data/my-cluster/default/my-machine:24h0m0s",
+				"delete:my-bucket/bootstrap-data/my-cluster/default/my-machine",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			gcpMachine := getFakeGCPMachine()
+			gcpMachine.Spec.BootstrapDataStorage = tt.storage
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:        fakec,
+				Machine:       fakeMachine,
+				GCPMachine:    gcpMachine,
+				ClusterGetter: clusterScope,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var inserted *compute.Instance
+			s := New(machineScope)
+			s.instances = &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+				InsertHook: func(_ context.Context, _ *meta.Key, obj *compute.Instance, _ *cloud.MockInstances, _ ...cloud.Option) (bool, error) {
+					inserted = obj
+					return tt.insertErr != nil, tt.insertErr
+				},
+			}
+			s.networkTags = &fakeNetworkTags{calls: &[]string{}}
+			s.labels = &fakeLabels{calls: &[]string{}}
+			s.metadata = &fakeMetadata{}
+			bootstrapData := &fakeBootstrapData{}
+			s.bootstrapData = bootstrapData
+
+			_, err = s.createOrGetInstance(ctx)
+			if !errors.Is(err, tt.insertErr) {
+				t.Fatalf("Service.createOrGetInstance() error = %v, wantErr %v", err, tt.insertErr)
+			}
+			if d := cmp.Diff(ptr.To(tt.wantUserData), metadataItemValue(inserted.Metadata, "user-data")); d != "" {
+				t.Errorf("Service.createOrGetInstance() user-data mismatch (-want +got):\n%s", d)
+			}
+
+			if tt.insertErr == nil {
+				if err := s.Delete(ctx); err != nil {
+					t.Fatalf("Service.Delete() error = %v", err)
+				}
+			}
+			if d := cmp.Diff(tt.wantCalls, bootstrapData.calls); d != "" {
+				t.Errorf("bootstrap data calls mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestService_bootstrapDataStorageCleanup(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(fakeBootstrapSecret).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	includeUserData := "#include\nhttps://storage.googleapis.com/my-bucket/bootstrap-data/my-cluster/default/my-machine?X-Goog-Signature=abc\n"
+	tests := []struct {
+		name                     string
+		userData                 string
+		nodeRef                  *corev1.ObjectReference
+		bootstrapDataDeleted     bool
+		wantCalls                []string
+		wantBootstrapDataDeleted bool
+	}{
+		{
+			name:     "node did not join yet (should keep the bootstrap data)",
+			userData: includeUserData,
+		},
+		{
+			name:                     "node joined (should delete the bootstrap data)",
+			userData:                 includeUserData,
+			nodeRef:                  &corev1.ObjectReference{Name: "my-node"},
+			wantCalls:                []string{"delete:my-bucket/bootstrap-data/my-cluster/default/my-machine"},
+			wantBootstrapDataDeleted: true,
+		},
+		{
+			name:                     "bootstrap data already deleted (should not delete it again)",
+			userData:                 includeUserData,
+			nodeRef:                  &corev1.ObjectReference{Name: "my-node"},
+			bootstrapDataDeleted:     true,
+			wantBootstrapDataDeleted: true,
+		},
+		{
+			name:     "bootstrap data passed in the user-data (should not delete anything)",
+			userData: "Zm9vCg==",
+			nodeRef:  &corev1.ObjectReference{Name: "my-node"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			machine := fakeMachine.DeepCopy()
+			machine.Status.NodeRef = tt.nodeRef
+			gcpMachine := getFakeGCPMachine()
+			gcpMachine.Spec.BootstrapDataStorage = &infrav1.BootstrapDataStorage{Bucket: "my-bucket", Threshold: ptr.To[int64](4)}
+			gcpMachine.Status.BootstrapDataDeleted = tt.bootstrapDataDeleted
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:        fakec,
+				Machine:       machine,
+				GCPMachine:    gcpMachine,
+				ClusterGetter: clusterScope,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s := New(machineScope)
+			s.instances = &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects: map[meta.Key]*cloud.MockInstancesObj{
+					{Name: "my-machine", Zone: "us-central1-c"}: {Obj: &compute.Instance{
+						Name:   "my-machine",
+						Status: "RUNNING",
+						Metadata: &compute.Metadata{
+							Items: []*compute.MetadataItems{{Key: "user-data", Value: ptr.To(tt.userData)}},
+						},
+					}},
+				},
+			}
+			s.networkTags = &fakeNetworkTags{calls: &[]string{}}
+			s.labels = &fakeLabels{calls: &[]string{}}
+			s.metadata = &fakeMetadata{}
+			bootstrapData := &fakeBootstrapData{}
+			s.bootstrapData = bootstrapData
+
+			if err := s.Reconcile(ctx); err != nil {
+				t.Fatalf("Service.Reconcile() error = %v", err)
+			}
+			if d := cmp.Diff(tt.wantCalls, bootstrapData.calls); d != "" {
+				t.Errorf("bootstrap data calls mismatch (-want +got):\n%s", d)
+			}
+			if got := gcpMachine.Status.BootstrapDataDeleted; got != tt.wantBootstrapDataDeleted {
+				t.Errorf("Status.BootstrapDataDeleted = %v, want %v", got, tt.wantBootstrapDataDeleted)
+			}
+		})
+	}
+}
+
+func TestSignURL(t *testing.T) {
+	var stringToSign string
+	got, err := signURL("storage.googleapis.com", "my-bucket", "bootstrap-data/my-cluster/default/my machine", "sa@proj.iam.gserviceaccount.com",
+		time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), time.Hour, func(payload []byte) ([]byte, error) {
+			stringToSign = string(payload)
+			return []byte{0xab, 0xcd}, nil
+		})
+	if err != nil {
+		t.Fatalf("signURL() error = %v", err)
+	}
+
+	wantQuery := "X-Goog-Algorithm=GOOG4-RSA-SHA256" +
+		"&X-Goog-Credential=sa%40proj.iam.gserviceaccount.com%2F20250102%2Fauto%2Fstorage%2Fgoog4_request" +
+		"&X-Goog-Date=20250102T030405Z&X-Goog-Expires=3600&X-Goog-SignedHeaders=host"
+	wantURL := "https://storage.googleapis.com/my-bucket/bootstrap-data/my-cluster/default/my%20machine?" + wantQuery + "&X-Goog-Signature=abcd"
+	if d := cmp.Diff(wantURL, got); d != "" {
+		t.Errorf("signURL() mismatch (-want +got):\n%s", d)
+	}
+
+	canonicalRequest := "GET\n/my-bucket/bootstrap-data/my-cluster/default/my%20machine\n" + wantQuery + "\nhost:storage.googleapis.com\n\nhost\nUNSIGNED-PAYLOAD"
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	wantStringToSign := "GOOG4-RSA-SHA256\n20250102T030405Z\n20250102/auto/storage/goog4_request\n" + hex.EncodeToString(hash[:])
+	if d := cmp.Diff(wantStringToSign, stringToSign); d != "" {
+		t.Errorf("signURL() string to sign mismatch (-want +got):\n%s", d)
 	}
 }
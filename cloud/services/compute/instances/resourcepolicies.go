@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instances
+
+import (
+	"context"
+
+	k8scloud "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+)
+
+// resourcePoliciesClient reads resource policies through the compute API, as the k8s-cloud-provider
+// does not expose them.
+type resourcePoliciesClient struct {
+	compute     *compute.Service
+	rateLimiter k8scloud.RateLimiter
+	project     string
+}
+
+// Get returns the regional resource policy of the given key. The call goes through the rate limiter like
+// the calls of the k8s-cloud-provider wrappers.
+func (c *resourcePoliciesClient) Get(ctx context.Context, key *meta.Key) (*compute.ResourcePolicy, error) {
+	rlKey := &k8scloud.RateLimitKey{
+		ProjectID: c.project,
+		Operation: "Get",
+		Version:   meta.VersionGA,
+		Service:   "ResourcePolicies",
+	}
+	if err := c.rateLimiter.Accept(ctx, rlKey); err != nil {
+		return nil, err
+	}
+
+	policy, err := c.compute.ResourcePolicies.Get(c.project, key.Region, key.Name).Context(ctx).Do()
+	c.rateLimiter.Observe(ctx, err, rlKey)
+
+	return policy, err
+}
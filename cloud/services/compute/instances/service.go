@@ -18,6 +18,7 @@ package instances
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 
@@ -25,7 +26,9 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/storage/v1"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
 )
 
@@ -35,6 +38,46 @@ type instancesInterface interface {
 	Delete(ctx context.Context, key *meta.Key, options ...k8scloud.Option) error
 }
 
+type disksInterface interface {
+	Get(ctx context.Context, key *meta.Key, options ...k8scloud.Option) (*compute.Disk, error)
+	Resize(ctx context.Context, key *meta.Key, req *compute.DisksResizeRequest, options ...k8scloud.Option) error
+}
+
+type deletionProtectionInterface interface {
+	SetDeletionProtection(ctx context.Context, key *meta.Key, protected bool) error
+}
+
+type networkTagsInterface interface {
+	SetTags(ctx context.Context, key *meta.Key, tags *compute.Tags) error
+}
+
+type labelsInterface interface {
+	SetLabels(ctx context.Context, key *meta.Key, req *compute.InstancesSetLabelsRequest) error
+}
+
+type metadataInterface interface {
+	SetMetadata(ctx context.Context, key *meta.Key, metadata *compute.Metadata) error
+}
+
+type bootstrapDataInterface interface {
+	Upload(ctx context.Context, bucket, object string, data []byte) error
+	SignedURL(ctx context.Context, bucket, object string, expiry time.Duration) (string, error)
+	Delete(ctx context.Context, bucket, object string) error
+}
+
+type resourcePoliciesInterface interface {
+	Get(ctx context.Context, key *meta.Key) (*compute.ResourcePolicy, error)
+}
+
+type imagesInterface interface {
+	Get(ctx context.Context, key *meta.Key, options ...k8scloud.Option) (*compute.Image, error)
+	GetFromFamily(ctx context.Context, key *meta.Key, options ...k8scloud.Option) (*compute.Image, error)
+}
+
+type subnetsInterface interface {
+	Get(ctx context.Context, key *meta.Key, options ...k8scloud.Option) (*compute.Subnetwork, error)
+}
+
 type instancegroupsInterface interface {
 	AddInstances(ctx context.Context, key *meta.Key, req *compute.InstanceGroupsAddInstancesRequest, options ...k8scloud.Option) error
 	ListInstances(ctx context.Context, key *meta.Key, req *compute.InstanceGroupsListInstancesRequest, fl *filter.F, options ...k8scloud.Option) ([]*compute.InstanceWithNamedPorts, error)
@@ -44,25 +87,66 @@ type instancegroupsInterface interface {
 // Scope is an interfaces that hold used methods.
 type Scope interface {
 	cloud.Machine
-	InstanceSpec(log logr.Logger) *compute.Instance
+	InstanceSpec(log logr.Logger) (*compute.Instance, error)
 	InstanceImageSpec() *compute.AttachedDisk
 	InstanceAdditionalDiskSpec() []*compute.AttachedDisk
+	RequiredGuestOSFeatures() []string
+	ComputeService() *compute.Service
+	RateLimiter() cloud.RateLimiter
+	StorageService(ctx context.Context) (*storage.Service, error)
+	CredentialsServiceAccount(ctx context.Context) (string, error)
+	SignBlob(ctx context.Context, serviceAccount string, payload []byte) ([]byte, error)
+	BootstrapDataStorage() *infrav1.BootstrapDataStorage
+	ClusterName() string
+	HasNodeRef() bool
+	BootstrapDataDeleted() bool
+	SetBootstrapDataDeleted()
 }
 
 // Service implements instances reconciler.
 type Service struct {
-	scope          Scope
-	instances      instancesInterface
-	instancegroups instancegroupsInterface
+	scope              Scope
+	instances          instancesInterface
+	instancegroups     instancegroupsInterface
+	subnets            subnetsInterface
+	images             imagesInterface
+	disks              disksInterface
+	deletionProtection deletionProtectionInterface
+	resourcePolicies   resourcePoliciesInterface
+	networkTags        networkTagsInterface
+	labels             labelsInterface
+	metadata           metadataInterface
+	bootstrapData      bootstrapDataInterface
 }
 
 var _ cloud.Reconciler = &Service{}
 
 // New returns Service from given scope.
 func New(scope Scope) *Service {
+	instanceOperations := &instanceOperationsClient{
+		compute:     scope.ComputeService(),
+		rateLimiter: scope.RateLimiter(),
+		project:     scope.Project(),
+	}
+
 	return &Service{
-		scope:          scope,
-		instances:      scope.Cloud().Instances(),
-		instancegroups: scope.Cloud().InstanceGroups(),
+		scope:              scope,
+		instances:          scope.Cloud().Instances(),
+		instancegroups:     scope.Cloud().InstanceGroups(),
+		subnets:            scope.NetworkCloud().Subnetworks(),
+		images:             scope.Cloud().Images(),
+		disks:              scope.Cloud().Disks(),
+		deletionProtection: instanceOperations,
+		resourcePolicies: &resourcePoliciesClient{
+			compute:     scope.ComputeService(),
+			rateLimiter: scope.RateLimiter(),
+			project:     scope.Project(),
+		},
+		networkTags: instanceOperations,
+		labels:      instanceOperations,
+		metadata:    instanceOperations,
+		bootstrapData: &bootstrapDataClient{
+			scope: scope,
+		},
 	}
 }
@@ -0,0 +1,50 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"context"
+
+	k8scloud "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
+)
+
+// proxyHeaderClient sets the proxy header of target TCP proxies through the compute API, as the
+// k8s-cloud-provider TargetTcpProxies interface does not expose it.
+type proxyHeaderClient struct {
+	compute     *compute.Service
+	rateLimiter k8scloud.RateLimiter
+	project     string
+}
+
+// SetProxyHeader sets the proxy header of the target TCP proxy and waits for the operation to complete.
+func (c *proxyHeaderClient) SetProxyHeader(ctx context.Context, key *meta.Key, proxyHeader string) error {
+	rateLimitKey := &k8scloud.RateLimitKey{
+		ProjectID: c.project,
+		Operation: "SetProxyHeader",
+		Version:   meta.VersionGA,
+		Service:   "TargetTcpProxies",
+	}
+	req := &compute.TargetTcpProxiesSetProxyHeaderRequest{ProxyHeader: proxyHeader}
+
+	return shared.DoOperation(ctx, c.rateLimiter, rateLimitKey, shared.GlobalOperationWaiter(c.compute, c.project), func() (*compute.Operation, error) {
+		return c.compute.TargetTcpProxies.SetProxyHeader(c.project, key.Name, req).Context(ctx).Do()
+	})
+}
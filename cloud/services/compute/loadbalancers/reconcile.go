@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
@@ -27,6 +28,7 @@ import (
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -77,7 +79,8 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		}
 	}
 
-	return nil
+	// Instance groups can only be deleted once no backend service references them.
+	return s.deleteOrphanedInstanceGroups(ctx)
 }
 
 // Delete deletes cluster control-plane loadbalancer components.
@@ -276,6 +279,10 @@ func (s *Service) createOrGetInstanceGroups(ctx context.Context) ([]*compute.Ins
 				return groups, err
 			}
 
+			if shared.SkipDryRun(ctx, s.scope, "create", "instancegroup", instancegroupSpec.Name) {
+				return groups, shared.ErrDryRun
+			}
+
 			log.V(2).Info("Creating instancegroup in zone", "zone", zone, "name", instancegroupSpec.Name)
 			if err := s.instancegroups.Insert(ctx, meta.ZonalKey(instancegroupSpec.Name, zone), instancegroupSpec); err != nil {
 				log.Error(err, "Error creating instancegroup", "name", instancegroupSpec.Name)
@@ -308,6 +315,10 @@ func (s *Service) createOrGetHealthCheck(ctx context.Context, lbname string) (*c
 			return nil, err
 		}
 
+		if shared.SkipDryRun(ctx, s.scope, "create", "healthcheck", healthcheckSpec.Name) {
+			return nil, shared.ErrDryRun
+		}
+
 		log.V(2).Info("Creating a healthcheck", "name", healthcheckSpec.Name)
 		if err := s.healthchecks.Insert(ctx, key, healthcheckSpec); err != nil {
 			log.Error(err, "Error creating a healthcheck", "name", healthcheckSpec.Name)
@@ -327,6 +338,9 @@ func (s *Service) createOrGetRegionalHealthCheck(ctx context.Context, lbname str
 	log := log.FromContext(ctx)
 	healthcheckSpec := s.scope.HealthCheckSpec(lbname)
 	healthcheckSpec.Region = s.scope.Region()
+	if lbSpec := s.scope.LoadBalancer(); lbSpec.InternalLoadBalancer != nil {
+		setHealthCheckTimings(healthcheckSpec, lbSpec.InternalLoadBalancer.HealthCheck)
+	}
 	log.V(2).Info("Looking for regional healthcheck", "name", healthcheckSpec.Name)
 	key := meta.RegionalKey(healthcheckSpec.Name, s.scope.Region())
 	healthcheck, err := s.regionalhealthchecks.Get(ctx, key)
@@ -336,6 +350,10 @@ func (s *Service) createOrGetRegionalHealthCheck(ctx context.Context, lbname str
 			return nil, err
 		}
 
+		if shared.SkipDryRun(ctx, s.scope, "create", "regional healthcheck", healthcheckSpec.Name) {
+			return nil, shared.ErrDryRun
+		}
+
 		log.V(2).Info("Creating a regional healthcheck", "name", healthcheckSpec.Name)
 		if err := s.regionalhealthchecks.Insert(ctx, key, healthcheckSpec); err != nil {
 			log.Error(err, "Error creating a regional healthcheck", "name", healthcheckSpec.Name)
@@ -351,6 +369,17 @@ func (s *Service) createOrGetRegionalHealthCheck(ctx context.Context, lbname str
 	return healthcheck, nil
 }
 
+// setHealthCheckTimings overrides the default timings of the health check spec with the configured ones.
+func setHealthCheckTimings(spec *compute.HealthCheck, cfg *infrav1.HealthCheck) {
+	if cfg == nil {
+		return
+	}
+	spec.CheckIntervalSec = ptr.Deref(cfg.CheckIntervalSec, spec.CheckIntervalSec)
+	spec.TimeoutSec = ptr.Deref(cfg.TimeoutSec, spec.TimeoutSec)
+	spec.HealthyThreshold = ptr.Deref(cfg.HealthyThreshold, spec.HealthyThreshold)
+	spec.UnhealthyThreshold = ptr.Deref(cfg.UnhealthyThreshold, spec.UnhealthyThreshold)
+}
+
 func (s *Service) createOrGetBackendService(ctx context.Context, lbname string, mode loadBalancingMode, instancegroups []*compute.InstanceGroup, healthcheck *compute.HealthCheck) (*compute.BackendService, error) {
 	log := log.FromContext(ctx)
 	backends := make([]*compute.Backend, 0, len(instancegroups))
@@ -379,6 +408,10 @@ func (s *Service) createOrGetBackendService(ctx context.Context, lbname string,
 			return nil, err
 		}
 
+		if shared.SkipDryRun(ctx, s.scope, "create", "backendservice", backendsvcSpec.Name) {
+			return nil, shared.ErrDryRun
+		}
+
 		log.V(2).Info("Creating a backendservice", "name", backendsvcSpec.Name)
 		if err := s.backendservices.Insert(ctx, key, backendsvcSpec); err != nil {
 			log.Error(err, "Error creating a backendservice", "name", backendsvcSpec.Name)
@@ -391,7 +424,7 @@ func (s *Service) createOrGetBackendService(ctx context.Context, lbname string,
 		}
 	}
 
-	if len(backendsvc.Backends) != len(backendsvcSpec.Backends) {
+	if backendsChanged(backendsvc.Backends, backendsvcSpec.Backends) && !shared.SkipDryRun(ctx, s.scope, "update", "backendservice", backendsvcSpec.Name) {
 		log.V(2).Info("Updating a backendservice", "name", backendsvcSpec.Name)
 		backendsvc.Backends = backendsvcSpec.Backends
 		if err := s.backendservices.Update(ctx, key, backendsvc); err != nil {
@@ -435,6 +468,10 @@ func (s *Service) createOrGetRegionalBackendService(ctx context.Context, lbname
 			return nil, err
 		}
 
+		if shared.SkipDryRun(ctx, s.scope, "create", "regional backendservice", backendsvcSpec.Name) {
+			return nil, shared.ErrDryRun
+		}
+
 		log.V(2).Info("Creating a regional backendservice", "name", backendsvcSpec.Name)
 		if err := s.regionalbackendservices.Insert(ctx, key, backendsvcSpec); err != nil {
 			log.Error(err, "Error creating a regional backendservice", "name", backendsvcSpec.Name)
@@ -447,7 +484,7 @@ func (s *Service) createOrGetRegionalBackendService(ctx context.Context, lbname
 		}
 	}
 
-	if len(backendsvc.Backends) != len(backendsvcSpec.Backends) {
+	if backendsChanged(backendsvc.Backends, backendsvcSpec.Backends) && !shared.SkipDryRun(ctx, s.scope, "update", "regional backendservice", backendsvcSpec.Name) {
 		log.V(2).Info("Updating a regional backendservice", "name", backendsvcSpec.Name)
 		backendsvc.Backends = backendsvcSpec.Backends
 		if err := s.regionalbackendservices.Update(ctx, key, backendsvc); err != nil {
@@ -471,6 +508,10 @@ func (s *Service) createOrGetTargetTCPProxy(ctx context.Context, service *comput
 			return nil, err
 		}
 
+		if shared.SkipDryRun(ctx, s.scope, "create", "targettcpproxy", targetSpec.Name) {
+			return nil, shared.ErrDryRun
+		}
+
 		log.V(2).Info("Creating a targettcpproxy", "name", targetSpec.Name)
 		if err := s.targettcpproxies.Insert(ctx, key, targetSpec); err != nil {
 			log.Error(err, "Error creating a targettcpproxy", "name", targetSpec.Name)
@@ -483,6 +524,20 @@ func (s *Service) createOrGetTargetTCPProxy(ctx context.Context, service *comput
 		}
 	}
 
+	// The proxy header can be changed on an existing targettcpproxy without recreating it
+	if target.ProxyHeader != targetSpec.ProxyHeader && !shared.SkipDryRun(ctx, s.scope, "update", "targettcpproxy", targetSpec.Name) {
+		log.V(2).Info("Updating proxy header of targettcpproxy", "name", targetSpec.Name, "proxyHeader", targetSpec.ProxyHeader)
+		if err := s.proxyheaders.SetProxyHeader(ctx, key, targetSpec.ProxyHeader); err != nil {
+			log.Error(err, "Error updating a targettcpproxy", "name", targetSpec.Name)
+			return nil, err
+		}
+
+		target, err = s.targettcpproxies.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return target, nil
 }
 
@@ -499,6 +554,10 @@ func (s *Service) createOrGetAddress(ctx context.Context, lbname string) (*compu
 			return nil, err
 		}
 
+		if shared.SkipDryRun(ctx, s.scope, "create", "address", addrSpec.Name) {
+			return nil, shared.ErrDryRun
+		}
+
 		log.V(2).Info("Creating an address", "name", addrSpec.Name)
 		if err := s.addresses.Insert(ctx, key, addrSpec); err != nil {
 			log.Error(err, "Error creating an address", "name", addrSpec.Name)
@@ -536,6 +595,10 @@ func (s *Service) createOrGetInternalAddress(ctx context.Context, lbname string)
 			return nil, err
 		}
 
+		if shared.SkipDryRun(ctx, s.scope, "create", "internal address", addrSpec.Name) {
+			return nil, shared.ErrDryRun
+		}
+
 		log.V(2).Info("Creating an internal address", "name", addrSpec.Name)
 		if err := s.internaladdresses.Insert(ctx, key, addrSpec); err != nil {
 			log.Error(err, "Error creating an internal address", "name", addrSpec.Name)
@@ -567,6 +630,10 @@ func (s *Service) createOrGetForwardingRule(ctx context.Context, lbname string,
 			return nil, err
 		}
 
+		if shared.SkipDryRun(ctx, s.scope, "create", "forwardingrule", spec.Name) {
+			return nil, shared.ErrDryRun
+		}
+
 		log.V(2).Info("Creating a forwardingrule", "name", spec.Name)
 		if err := s.forwardingrules.Insert(ctx, key, spec); err != nil {
 			log.Error(err, "Error creating a forwardingrule", "name", spec.Name)
@@ -581,7 +648,7 @@ func (s *Service) createOrGetForwardingRule(ctx context.Context, lbname string,
 
 	// Labels on ForwardingRules must be added after resource is created
 	labels := s.scope.AdditionalLabels()
-	if !labels.Equals(forwarding.Labels) {
+	if !labels.Equals(forwarding.Labels) && !shared.SkipDryRun(ctx, s.scope, "update labels of", "forwardingrule", spec.Name) {
 		setLabelsRequest := &compute.GlobalSetLabelsRequest{
 			LabelFingerprint: forwarding.LabelFingerprint,
 			Labels:           labels,
@@ -606,8 +673,12 @@ func (s *Service) createOrGetRegionalForwardingRule(ctx context.Context, lbname
 	var ports []string
 	portList := strings.Split(spec.PortRange, "-")
 	ports = append(ports, portList[0])
-	// Also configure ignition port
-	ports = append(ports, "22623")
+	// Also configure the additional ports, which default to the ignition port
+	for _, port := range s.internalAdditionalPorts() {
+		if p := strconv.Itoa(int(port)); p != portList[0] {
+			ports = append(ports, p)
+		}
+	}
 	spec.Ports = ports
 	spec.PortRange = ""
 	subnet, err := s.getSubnet(ctx)
@@ -617,6 +688,7 @@ func (s *Service) createOrGetRegionalForwardingRule(ctx context.Context, lbname
 	}
 	spec.Subnetwork = subnet.SelfLink
 	spec.IPAddress = addr.SelfLink
+	spec.AllowGlobalAccess = s.internalAccessGlobal()
 
 	key := meta.RegionalKey(spec.Name, s.scope.Region())
 	log.V(2).Info("Looking for regional forwardingrule", "name", spec.Name)
@@ -627,6 +699,10 @@ func (s *Service) createOrGetRegionalForwardingRule(ctx context.Context, lbname
 			return nil, err
 		}
 
+		if shared.SkipDryRun(ctx, s.scope, "create", "regional forwardingrule", spec.Name) {
+			return nil, shared.ErrDryRun
+		}
+
 		log.V(2).Info("Creating a regional forwardingrule", "name", spec.Name)
 		if err := s.regionalforwardingrules.Insert(ctx, key, spec); err != nil {
 			log.Error(err, "Error creating a regional forwardingrule", "name", spec.Name)
@@ -639,9 +715,28 @@ func (s *Service) createOrGetRegionalForwardingRule(ctx context.Context, lbname
 		}
 	}
 
+	// Global access can be toggled on an existing forwarding rule without recreating it
+	if forwarding.AllowGlobalAccess != spec.AllowGlobalAccess && !shared.SkipDryRun(ctx, s.scope, "update", "regional forwardingrule", spec.Name) {
+		log.V(2).Info("Updating global access of regional forwardingrule", "name", spec.Name, "allowGlobalAccess", spec.AllowGlobalAccess)
+		patch := &compute.ForwardingRule{
+			AllowGlobalAccess: spec.AllowGlobalAccess,
+			Fingerprint:       forwarding.Fingerprint,
+			ForceSendFields:   []string{"AllowGlobalAccess"},
+		}
+		if err := s.regionalforwardingrules.Patch(ctx, key, patch); err != nil {
+			log.Error(err, "Error updating a regional forwardingrule", "name", spec.Name)
+			return nil, err
+		}
+
+		forwarding, err = s.regionalforwardingrules.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Labels on ForwardingRules must be added after resource is created
 	labels := s.scope.AdditionalLabels()
-	if !labels.Equals(forwarding.Labels) {
+	if !labels.Equals(forwarding.Labels) && !shared.SkipDryRun(ctx, s.scope, "update labels of", "regional forwardingrule", spec.Name) {
 		setLabelsRequest := &compute.RegionSetLabelsRequest{
 			LabelFingerprint: forwarding.LabelFingerprint,
 			Labels:           labels,
@@ -658,6 +753,10 @@ func (s *Service) deleteForwardingRule(ctx context.Context, lbname string) error
 	log := log.FromContext(ctx)
 	spec := s.scope.ForwardingRuleSpec(lbname)
 	key := meta.GlobalKey(spec.Name)
+	if shared.SkipDryRun(ctx, s.scope, "delete", "forwardingrule", spec.Name) {
+		return nil
+	}
+
 	log.V(2).Info("Deleting a forwardingrule", "name", spec.Name)
 	if err := s.forwardingrules.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
 		log.Error(err, "Error updating a forwardingrule", "name", spec.Name)
@@ -671,6 +770,10 @@ func (s *Service) deleteRegionalForwardingRule(ctx context.Context, lbname strin
 	log := log.FromContext(ctx)
 	spec := s.scope.ForwardingRuleSpec(lbname)
 	key := meta.RegionalKey(spec.Name, s.scope.Region())
+	if shared.SkipDryRun(ctx, s.scope, "delete", "regional forwardingrule", spec.Name) {
+		return nil
+	}
+
 	log.V(2).Info("Deleting a regional forwardingrule", "name", spec.Name)
 	if err := s.regionalforwardingrules.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
 		log.Error(err, "Error updating a regional forwardingrule", "name", spec.Name)
@@ -684,6 +787,10 @@ func (s *Service) deleteAddress(ctx context.Context, lbname string) error {
 	log := log.FromContext(ctx)
 	spec := s.scope.AddressSpec(lbname)
 	key := meta.GlobalKey(spec.Name)
+	if shared.SkipDryRun(ctx, s.scope, "delete", "address", spec.Name) {
+		return nil
+	}
+
 	log.V(2).Info("Deleting a address", "name", spec.Name)
 	if err := s.addresses.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
 		return err
@@ -696,6 +803,10 @@ func (s *Service) deleteInternalAddress(ctx context.Context, lbname string) erro
 	log := log.FromContext(ctx)
 	spec := s.scope.AddressSpec(lbname)
 	key := meta.RegionalKey(spec.Name, s.scope.Region())
+	if shared.SkipDryRun(ctx, s.scope, "delete", "internal address", spec.Name) {
+		return nil
+	}
+
 	log.V(2).Info("Deleting an internal address", "name", spec.Name)
 	if err := s.internaladdresses.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
 		return err
@@ -708,6 +819,10 @@ func (s *Service) deleteTargetTCPProxy(ctx context.Context) error {
 	log := log.FromContext(ctx)
 	spec := s.scope.TargetTCPProxySpec()
 	key := meta.GlobalKey(spec.Name)
+	if shared.SkipDryRun(ctx, s.scope, "delete", "targettcpproxy", spec.Name) {
+		return nil
+	}
+
 	log.V(2).Info("Deleting a targettcpproxy", "name", spec.Name)
 	if err := s.targettcpproxies.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
 		log.Error(err, "Error deleting a targettcpproxy", "name", spec.Name)
@@ -721,6 +836,10 @@ func (s *Service) deleteBackendService(ctx context.Context, lbname string) error
 	log := log.FromContext(ctx)
 	spec := s.scope.BackendServiceSpec(lbname)
 	key := meta.GlobalKey(spec.Name)
+	if shared.SkipDryRun(ctx, s.scope, "delete", "backendservice", spec.Name) {
+		return nil
+	}
+
 	log.V(2).Info("Deleting a backendservice", "name", spec.Name)
 	if err := s.backendservices.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
 		log.Error(err, "Error deleting a backendservice", "name", spec.Name)
@@ -734,6 +853,10 @@ func (s *Service) deleteRegionalBackendService(ctx context.Context, lbname strin
 	log := log.FromContext(ctx)
 	spec := s.scope.BackendServiceSpec(lbname)
 	key := meta.RegionalKey(spec.Name, s.scope.Region())
+	if shared.SkipDryRun(ctx, s.scope, "delete", "regional backendservice", spec.Name) {
+		return nil
+	}
+
 	log.V(2).Info("Deleting a regional backendservice", "name", spec.Name)
 	if err := s.regionalbackendservices.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
 		log.Error(err, "Error deleting a regional backendservice", "name", spec.Name)
@@ -747,6 +870,10 @@ func (s *Service) deleteHealthCheck(ctx context.Context, lbname string) error {
 	log := log.FromContext(ctx)
 	spec := s.scope.HealthCheckSpec(lbname)
 	key := meta.GlobalKey(spec.Name)
+	if shared.SkipDryRun(ctx, s.scope, "delete", "healthcheck", spec.Name) {
+		return nil
+	}
+
 	log.V(2).Info("Deleting a healthcheck", "name", spec.Name)
 	if err := s.healthchecks.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
 		log.Error(err, "Error deleting a healthcheck", "name", spec.Name)
@@ -760,6 +887,10 @@ func (s *Service) deleteRegionalHealthCheck(ctx context.Context, lbname string)
 	log := log.FromContext(ctx)
 	spec := s.scope.HealthCheckSpec(lbname)
 	key := meta.RegionalKey(spec.Name, s.scope.Region())
+	if shared.SkipDryRun(ctx, s.scope, "delete", "regional healthcheck", spec.Name) {
+		return nil
+	}
+
 	log.V(2).Info("Deleting a regional healthcheck", "name", spec.Name)
 	if err := s.regionalhealthchecks.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
 		log.Error(err, "Error deleting a regional healthcheck", "name", spec.Name)
@@ -770,24 +901,85 @@ func (s *Service) deleteRegionalHealthCheck(ctx context.Context, lbname string)
 }
 
 func (s *Service) deleteInstanceGroups(ctx context.Context) error {
-	log := log.FromContext(ctx)
 	for zone := range s.scope.Network().APIServerInstanceGroups {
-		spec := s.scope.InstanceGroupSpec(zone)
-		key := meta.ZonalKey(spec.Name, zone)
-		log.V(2).Info("Deleting a instancegroup", "name", spec.Name)
-		if err := s.instancegroups.Delete(ctx, key); err != nil {
-			if !gcperrors.IsNotFound(err) {
-				log.Error(err, "Error deleting a instancegroup", "name", spec.Name)
-				return err
-			}
+		if err := s.deleteInstanceGroup(ctx, zone); err != nil {
+			return err
+		}
+	}
 
-			delete(s.scope.Network().APIServerInstanceGroups, zone)
+	return nil
+}
+
+// deleteOrphanedInstanceGroups deletes the instance groups of zones that are no
+// longer part of the cluster failure domains.
+func (s *Service) deleteOrphanedInstanceGroups(ctx context.Context) error {
+	fd := s.scope.FailureDomains()
+	for zone := range s.scope.Network().APIServerInstanceGroups {
+		if _, ok := fd[zone]; ok {
+			continue
+		}
+
+		if err := s.deleteInstanceGroup(ctx, zone); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+func (s *Service) deleteInstanceGroup(ctx context.Context, zone string) error {
+	log := log.FromContext(ctx)
+	spec := s.scope.InstanceGroupSpec(zone)
+	key := meta.ZonalKey(spec.Name, zone)
+	if shared.SkipDryRun(ctx, s.scope, "delete", "instancegroup", spec.Name) {
+		return nil
+	}
+
+	log.V(2).Info("Deleting a instancegroup", "name", spec.Name)
+	if err := s.instancegroups.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting a instancegroup", "name", spec.Name)
+		return err
+	}
+
+	delete(s.scope.Network().APIServerInstanceGroups, zone)
+	return nil
+}
+
+// backendsChanged reports whether the instance groups referenced by the current
+// backends differ from the desired ones.
+func backendsChanged(current, desired []*compute.Backend) bool {
+	if len(current) != len(desired) {
+		return true
+	}
+
+	groups := make(map[string]struct{}, len(current))
+	for _, be := range current {
+		groups[be.Group] = struct{}{}
+	}
+	for _, be := range desired {
+		if _, ok := groups[be.Group]; !ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// internalAccessGlobal returns true if the Internal Load Balancer should allow global access.
+func (s *Service) internalAccessGlobal() bool {
+	lbSpec := s.scope.LoadBalancer()
+	return lbSpec.InternalLoadBalancer != nil && lbSpec.InternalLoadBalancer.InternalAccess == infrav1.InternalAccessGlobal
+}
+
+// internalAdditionalPorts returns the ports exposed by the Internal Load Balancer in addition to the API server port.
+func (s *Service) internalAdditionalPorts() []int32 {
+	lbSpec := s.scope.LoadBalancer()
+	if lbSpec.InternalLoadBalancer == nil || lbSpec.InternalLoadBalancer.Ports == nil {
+		return infrav1.DefaultInternalLoadBalancerPorts
+	}
+	return lbSpec.InternalLoadBalancer.Ports
+}
+
 // getSubnet gets the subnet to use for an internal Load Balancer.
 func (s *Service) getSubnet(ctx context.Context) (*compute.Subnetwork, error) {
 	log := log.FromContext(ctx)
@@ -797,21 +989,26 @@ func (s *Service) getSubnet(ctx context.Context) (*compute.Subnetwork, error) {
 		cfgSubnet = ptr.Deref(lbSpec.InternalLoadBalancer.Subnet, "")
 	}
 	for _, subnetSpec := range s.scope.SubnetSpecs() {
-		log.V(2).Info("Looking for subnet for load balancer", "name", subnetSpec.Name)
+		// Use the subnet that matches the configuration, or the first one if not configured
+		if cfgSubnet != "" && subnetSpec.Name != cfgSubnet {
+			continue
+		}
 		region := subnetSpec.Region
 		if region == "" {
 			region = s.scope.Region()
 		}
+		// The Internal Load Balancer is regional and its backends are in the cluster region, so
+		// subnets of other regions can't be used
+		if region != s.scope.Region() {
+			if cfgSubnet != "" {
+				return nil, fmt.Errorf("subnet %s is in region %s, but the internal load balancer must be in the cluster region %s", cfgSubnet, region, s.scope.Region())
+			}
+			continue
+		}
+		log.V(2).Info("Looking for subnet for load balancer", "name", subnetSpec.Name)
 
 		subnetKey := meta.RegionalKey(subnetSpec.Name, region)
-		subnet, err := s.subnets.Get(ctx, subnetKey)
-		if err != nil {
-			return nil, err
-		}
-		// Return subnet that matches configuration, or first one if not configured
-		if cfgSubnet == "" || strings.HasSuffix(subnet.Name, cfgSubnet) {
-			return subnet, nil
-		}
+		return s.subnets.Get(ctx, subnetKey)
 	}
 
 	return nil, errors.New("could not find subnet")
@@ -18,6 +18,7 @@ package loadbalancers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"testing"
 
@@ -31,6 +32,7 @@ import (
 	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -292,6 +294,69 @@ func TestService_createOrGetRegionalHealthCheck(t *testing.T) {
 				UnhealthyThreshold: 3,
 			},
 		},
+		{
+			name: "regional health check does not exist for internal load balancer with configured timings (should create healthcheck with timings)",
+			scope: func(s *scope.ClusterScope) Scope {
+				s.GCPCluster.Spec.LoadBalancer = infrav1.LoadBalancerSpec{
+					LoadBalancerType: &lbTypeInternal,
+					InternalLoadBalancer: &infrav1.LoadBalancer{
+						HealthCheck: &infrav1.HealthCheck{
+							CheckIntervalSec:   ptr.To[int64](30),
+							TimeoutSec:         ptr.To[int64](20),
+							HealthyThreshold:   ptr.To[int64](2),
+							UnhealthyThreshold: ptr.To[int64](6),
+						},
+					},
+				}
+				return s
+			},
+			lbName: infrav1.InternalRoleTagValue,
+			mockHealthChecks: &cloud.MockRegionHealthChecks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockRegionHealthChecksObj{},
+			},
+			want: &compute.HealthCheck{
+				CheckIntervalSec:   30,
+				HealthyThreshold:   2,
+				HttpsHealthCheck:   &compute.HTTPSHealthCheck{Port: 6443, PortSpecification: "USE_FIXED_PORT", RequestPath: "/readyz"},
+				Name:               "my-cluster-api-internal",
+				Region:             "us-central1",
+				SelfLink:           "https://www.googleapis.com/compute/v1/projects/proj-id/regions/us-central1/healthChecks/my-cluster-api-internal",
+				TimeoutSec:         20,
+				Type:               "HTTPS",
+				UnhealthyThreshold: 6,
+			},
+		},
+		{
+			name: "regional health check does not exist for internal load balancer with a partial configuration (should keep the other default timings)",
+			scope: func(s *scope.ClusterScope) Scope {
+				s.GCPCluster.Spec.LoadBalancer = infrav1.LoadBalancerSpec{
+					LoadBalancerType: &lbTypeInternal,
+					InternalLoadBalancer: &infrav1.LoadBalancer{
+						HealthCheck: &infrav1.HealthCheck{
+							UnhealthyThreshold: ptr.To[int64](10),
+						},
+					},
+				}
+				return s
+			},
+			lbName: infrav1.InternalRoleTagValue,
+			mockHealthChecks: &cloud.MockRegionHealthChecks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockRegionHealthChecksObj{},
+			},
+			want: &compute.HealthCheck{
+				CheckIntervalSec:   10,
+				HealthyThreshold:   5,
+				HttpsHealthCheck:   &compute.HTTPSHealthCheck{Port: 6443, PortSpecification: "USE_FIXED_PORT", RequestPath: "/readyz"},
+				Name:               "my-cluster-api-internal",
+				Region:             "us-central1",
+				SelfLink:           "https://www.googleapis.com/compute/v1/projects/proj-id/regions/us-central1/healthChecks/my-cluster-api-internal",
+				TimeoutSec:         5,
+				Type:               "HTTPS",
+				UnhealthyThreshold: 10,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -314,6 +379,32 @@ func TestService_createOrGetRegionalHealthCheck(t *testing.T) {
 	}
 }
 
+func TestService_DryRun(t *testing.T) {
+	ctx := context.TODO()
+	clusterScope, err := getBaseClusterScope()
+	if err != nil {
+		t.Fatal(err)
+	}
+	clusterScope.GCPCluster.Annotations = map[string]string{infrav1.DryRunAnnotation: "true"}
+
+	var inserts int
+	s := New(clusterScope)
+	s.instancegroups = &cloud.MockInstanceGroups{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+		Objects:       map[meta.Key]*cloud.MockInstanceGroupsObj{},
+		InsertHook: func(_ context.Context, _ *meta.Key, _ *compute.InstanceGroup, _ *cloud.MockInstanceGroups, _ ...cloud.Option) (bool, error) {
+			inserts++
+			return false, nil
+		},
+	}
+	if _, err := s.createOrGetInstanceGroups(ctx); !errors.Is(err, shared.ErrDryRun) {
+		t.Errorf("Service s.createOrGetInstanceGroups() error = %v, want %v", err, shared.ErrDryRun)
+	}
+	if inserts != 0 {
+		t.Errorf("Service s.createOrGetInstanceGroups() inserted %d instanceGroups in dry-run mode", inserts)
+	}
+}
+
 func TestService_createOrGetBackendService(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -363,6 +454,47 @@ func TestService_createOrGetBackendService(t *testing.T) {
 				TimeoutSec:          600,
 			},
 		},
+		{
+			name: "backend service does not exist for external managed load balancer (should create backendservice with managed scheme)",
+			scope: func(s *scope.ClusterScope) Scope {
+				s.GCPCluster.Spec.LoadBalancer.ExternalLoadBalancingScheme = ptr.To(infrav1.LoadBalancingSchemeExternalManaged)
+				return s
+			},
+			lbName: infrav1.APIServerRoleTagValue,
+			healthCheck: &compute.HealthCheck{
+				HttpsHealthCheck: &compute.HTTPSHealthCheck{Port: 6443, PortSpecification: "USE_FIXED_PORT", RequestPath: "/readyz"},
+				Name:             "my-cluster-apiserver",
+				SelfLink:         "https://www.googleapis.com/compute/v1/projects/proj-id/global/healthChecks/my-cluster-apiserver",
+			},
+			instanceGroups: []*compute.InstanceGroup{
+				{
+					Name:       "my-cluster-master-us-central1-a",
+					NamedPorts: []*compute.NamedPort{{Name: "apiserver", Port: 6443}},
+					SelfLink:   "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-a/instanceGroups/my-cluster-master-us-central1-a",
+				},
+			},
+			mockBackendService: &cloud.MockBackendServices{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockBackendServicesObj{},
+			},
+			want: &compute.BackendService{
+				Backends: []*compute.Backend{
+					{
+						BalancingMode: "UTILIZATION",
+						Group:         "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-a/instanceGroups/my-cluster-master-us-central1-a",
+					},
+				},
+				HealthChecks: []string{
+					"https://www.googleapis.com/compute/v1/projects/proj-id/global/healthChecks/my-cluster-apiserver",
+				},
+				LoadBalancingScheme: "EXTERNAL_MANAGED",
+				Name:                "my-cluster-apiserver",
+				PortName:            "apiserver",
+				Protocol:            "TCP",
+				SelfLink:            "https://www.googleapis.com/compute/v1/projects/proj-id/global/backendServices/my-cluster-apiserver",
+				TimeoutSec:          600,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -386,6 +518,81 @@ func TestService_createOrGetBackendService(t *testing.T) {
 	}
 }
 
+func TestService_removeFailureDomain(t *testing.T) {
+	ctx := context.TODO()
+	clusterScope, err := getBaseClusterScope()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		groupA = "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-a/instanceGroups/my-cluster-apiserver-us-central1-a"
+		groupB = "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-b/instanceGroups/my-cluster-apiserver-us-central1-b"
+	)
+	// us-central1-b has been removed from the failure domains.
+	clusterScope.Network().APIServerInstanceGroups = map[string]string{
+		"us-central1-a": groupA,
+		"us-central1-b": groupB,
+	}
+	keyA := *meta.ZonalKey("my-cluster-apiserver-us-central1-a", "us-central1-a")
+	keyB := *meta.ZonalKey("my-cluster-apiserver-us-central1-b", "us-central1-b")
+	mockInstanceGroups := &cloud.MockInstanceGroups{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+		Objects: map[meta.Key]*cloud.MockInstanceGroupsObj{
+			keyA: {Obj: &compute.InstanceGroup{Name: keyA.Name, SelfLink: groupA}},
+			keyB: {Obj: &compute.InstanceGroup{Name: keyB.Name, SelfLink: groupB}},
+		},
+	}
+	var updated *compute.BackendService
+	mockBackendServices := &cloud.MockBackendServices{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+		Objects: map[meta.Key]*cloud.MockBackendServicesObj{
+			*meta.GlobalKey("my-cluster-apiserver"): {Obj: &compute.BackendService{
+				Name: "my-cluster-apiserver",
+				Backends: []*compute.Backend{
+					{BalancingMode: "UTILIZATION", Group: groupA},
+					{BalancingMode: "UTILIZATION", Group: groupB},
+				},
+			}},
+		},
+		UpdateHook: func(_ context.Context, _ *meta.Key, obj *compute.BackendService, _ *cloud.MockBackendServices, _ ...cloud.Option) error {
+			updated = obj
+			return nil
+		},
+	}
+
+	s := New(clusterScope)
+	s.instancegroups = mockInstanceGroups
+	s.backendservices = mockBackendServices
+	groups, err := s.createOrGetInstanceGroups(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.createOrGetBackendService(ctx, infrav1.APIServerRoleTagValue, loadBalancingModeUtilization, groups, &compute.HealthCheck{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.deleteOrphanedInstanceGroups(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if updated == nil {
+		t.Fatal("Service s.createOrGetBackendService() did not update the backendservice")
+	}
+	want := []*compute.Backend{{BalancingMode: "UTILIZATION", Group: groupA}}
+	if d := cmp.Diff(want, updated.Backends); d != "" {
+		t.Errorf("Service s.createOrGetBackendService() backends mismatch (-want +got):\n%s", d)
+	}
+	if _, ok := mockInstanceGroups.Objects[keyB]; ok {
+		t.Errorf("Service s.deleteOrphanedInstanceGroups() did not delete instancegroup %s", keyB.Name)
+	}
+	if _, ok := mockInstanceGroups.Objects[keyA]; !ok {
+		t.Errorf("Service s.deleteOrphanedInstanceGroups() deleted instancegroup %s", keyA.Name)
+	}
+	if d := cmp.Diff(map[string]string{"us-central1-a": groupA}, clusterScope.Network().APIServerInstanceGroups); d != "" {
+		t.Errorf("APIServerInstanceGroups mismatch (-want +got):\n%s", d)
+	}
+}
+
 func TestService_createOrGetRegionalBackendService(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -632,6 +839,110 @@ func TestService_createOrGetInternalAddress(t *testing.T) {
 	}
 }
 
+func TestService_getSubnet(t *testing.T) {
+	subnets := infrav1.Subnets{
+		{Name: "subnet", Region: "us-central1"},
+		{Name: "mynet", Region: "us-central1"},
+		{Name: "net", Region: "us-central1"},
+	}
+	multiRegionSubnets := infrav1.Subnets{
+		{Name: "europe", Region: "europe-west1"},
+		{Name: "subnet", Region: "us-central1"},
+	}
+	mockSubnetworks := &cloud.MockSubnetworks{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+		Objects: map[meta.Key]*cloud.MockSubnetworksObj{
+			*meta.RegionalKey("subnet", "us-central1"):  {Obj: &compute.Subnetwork{Name: "subnet"}},
+			*meta.RegionalKey("mynet", "us-central1"):   {Obj: &compute.Subnetwork{Name: "mynet"}},
+			*meta.RegionalKey("net", "us-central1"):     {Obj: &compute.Subnetwork{Name: "net"}},
+			*meta.RegionalKey("europe", "europe-west1"): {Obj: &compute.Subnetwork{Name: "europe"}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		subnets  infrav1.Subnets
+		subnet   *string
+		wantName string
+		wantErr  bool
+	}{
+		{
+			name:     "first subnet if not configured",
+			wantName: "subnet",
+		},
+		{
+			name:     "first subnet of the cluster region if not configured",
+			subnets:  multiRegionSubnets,
+			wantName: "subnet",
+		},
+		{
+			name:    "configured subnet in another region",
+			subnets: multiRegionSubnets,
+			subnet:  ptr.To("europe"),
+			wantErr: true,
+		},
+		{
+			name:     "configured subnet is matched by its exact name",
+			subnet:   ptr.To("net"),
+			wantName: "net",
+		},
+		{
+			name:     "configured subnet that is a suffix of other subnets",
+			subnet:   ptr.To("mynet"),
+			wantName: "mynet",
+		},
+		{
+			name:    "configured subnet that doesn't exist",
+			subnet:  ptr.To("et"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			clusterScope, err := getBaseClusterScope()
+			if err != nil {
+				t.Fatal(err)
+			}
+			clusterScope.GCPCluster.Spec.Network.Subnets = subnets
+			if tt.subnets != nil {
+				clusterScope.GCPCluster.Spec.Network.Subnets = tt.subnets
+			}
+			clusterScope.GCPCluster.Spec.LoadBalancer = infrav1.LoadBalancerSpec{
+				LoadBalancerType:     &lbTypeInternal,
+				InternalLoadBalancer: &infrav1.LoadBalancer{Subnet: tt.subnet},
+			}
+			s := New(clusterScope)
+			s.subnets = mockSubnetworks
+			got, err := s.getSubnet(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service s.getSubnet() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Name != tt.wantName {
+				t.Errorf("Service s.getSubnet() = %s, want %s", got.Name, tt.wantName)
+			}
+		})
+	}
+}
+
+// fakeProxyHeaders records the proxy header updates and applies them to the mocked target TCP proxies.
+type fakeProxyHeaders struct {
+	calls *[]string
+	mock  *cloud.MockTargetTcpProxies
+}
+
+func (f *fakeProxyHeaders) SetProxyHeader(_ context.Context, key *meta.Key, proxyHeader string) error {
+	*f.calls = append(*f.calls, "setProxyHeader:"+key.Name+":"+proxyHeader)
+	target := f.mock.Objects[*key].ToGA()
+	target.ProxyHeader = proxyHeader
+	f.mock.Objects[*key].Obj = target
+	return nil
+}
+
 func TestService_createOrGetTargetTCPProxy(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -639,6 +950,7 @@ func TestService_createOrGetTargetTCPProxy(t *testing.T) {
 		backendService     *compute.BackendService
 		mockTargetTCPProxy *cloud.MockTargetTcpProxies
 		want               *compute.TargetTcpProxy
+		wantCalls          []string
 		wantErr            bool
 	}{
 		{
@@ -657,6 +969,75 @@ func TestService_createOrGetTargetTCPProxy(t *testing.T) {
 				SelfLink:    "https://www.googleapis.com/compute/v1/projects/proj-id/global/targetTcpProxies/my-cluster-apiserver",
 			},
 		},
+		{
+			name: "target tcp proxy does not exist and proxy header is PROXY_V1 (should create target tcp proxy with proxy header)",
+			scope: func(s *scope.ClusterScope) Scope {
+				s.GCPCluster.Spec.LoadBalancer.ProxyHeader = ptr.To(infrav1.ProxyHeaderProxyV1)
+				return s
+			},
+			backendService: &compute.BackendService{
+				Name: "my-cluster-api-internal",
+			},
+			mockTargetTCPProxy: &cloud.MockTargetTcpProxies{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockTargetTcpProxiesObj{},
+			},
+			want: &compute.TargetTcpProxy{
+				Name:        "my-cluster-apiserver",
+				ProxyHeader: "PROXY_V1",
+				SelfLink:    "https://www.googleapis.com/compute/v1/projects/proj-id/global/targetTcpProxies/my-cluster-apiserver",
+			},
+		},
+		{
+			name: "target tcp proxy exists and proxy header is changed to PROXY_V1 (should update target tcp proxy)",
+			scope: func(s *scope.ClusterScope) Scope {
+				s.GCPCluster.Spec.LoadBalancer.ProxyHeader = ptr.To(infrav1.ProxyHeaderProxyV1)
+				return s
+			},
+			backendService: &compute.BackendService{
+				Name: "my-cluster-api-internal",
+			},
+			mockTargetTCPProxy: &cloud.MockTargetTcpProxies{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects: map[meta.Key]*cloud.MockTargetTcpProxiesObj{
+					*meta.GlobalKey("my-cluster-apiserver"): {
+						Obj: &compute.TargetTcpProxy{
+							Name:        "my-cluster-apiserver",
+							ProxyHeader: "NONE",
+							SelfLink:    "https://www.googleapis.com/compute/v1/projects/proj-id/global/targetTcpProxies/my-cluster-apiserver",
+						},
+					},
+				},
+			},
+			want: &compute.TargetTcpProxy{
+				Name:        "my-cluster-apiserver",
+				ProxyHeader: "PROXY_V1",
+				SelfLink:    "https://www.googleapis.com/compute/v1/projects/proj-id/global/targetTcpProxies/my-cluster-apiserver",
+			},
+			wantCalls: []string{"setProxyHeader:my-cluster-apiserver:PROXY_V1"},
+		},
+		{
+			name: "target tcp proxy does not exist for external managed load balancer (should create target tcp proxy for the managed backend service)",
+			scope: func(s *scope.ClusterScope) Scope {
+				s.GCPCluster.Spec.LoadBalancer.ExternalLoadBalancingScheme = ptr.To(infrav1.LoadBalancingSchemeExternalManaged)
+				return s
+			},
+			backendService: &compute.BackendService{
+				LoadBalancingScheme: "EXTERNAL_MANAGED",
+				Name:                "my-cluster-apiserver",
+				SelfLink:            "https://www.googleapis.com/compute/v1/projects/proj-id/global/backendServices/my-cluster-apiserver",
+			},
+			mockTargetTCPProxy: &cloud.MockTargetTcpProxies{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockTargetTcpProxiesObj{},
+			},
+			want: &compute.TargetTcpProxy{
+				Name:        "my-cluster-apiserver",
+				ProxyHeader: "NONE",
+				SelfLink:    "https://www.googleapis.com/compute/v1/projects/proj-id/global/targetTcpProxies/my-cluster-apiserver",
+				Service:     "https://www.googleapis.com/compute/v1/projects/proj-id/global/backendServices/my-cluster-apiserver",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -667,6 +1048,8 @@ func TestService_createOrGetTargetTCPProxy(t *testing.T) {
 			}
 			s := New(tt.scope(clusterScope))
 			s.targettcpproxies = tt.mockTargetTCPProxy
+			var calls []string
+			s.proxyheaders = &fakeProxyHeaders{calls: &calls, mock: tt.mockTargetTCPProxy}
 			got, err := s.createOrGetTargetTCPProxy(ctx, tt.backendService)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Service s.createOrGetTargetTCPProxy() error = %v, wantErr %v", err, tt.wantErr)
@@ -675,6 +1058,9 @@ func TestService_createOrGetTargetTCPProxy(t *testing.T) {
 			if d := cmp.Diff(tt.want, got); d != "" {
 				t.Errorf("Service s.createOrGetTargetTCPProxy() mismatch (-want +got):\n%s", d)
 			}
+			if d := cmp.Diff(tt.wantCalls, calls); d != "" {
+				t.Errorf("Service s.createOrGetTargetTCPProxy() proxy header updates mismatch (-want +got):\n%s", d)
+			}
 		})
 	}
 }
@@ -746,6 +1132,34 @@ func TestService_createOrGetForwardingRule(t *testing.T) {
 			},
 			includeLabels: true,
 		},
+		{
+			name: "forwarding rule does not exist for external managed load balancer (should create forwardingrule with managed scheme)",
+			scope: func(s *scope.ClusterScope) Scope {
+				s.GCPCluster.Spec.LoadBalancer.ExternalLoadBalancingScheme = ptr.To(infrav1.LoadBalancingSchemeExternalManaged)
+				return s
+			},
+			lbName: infrav1.APIServerRoleTagValue,
+			address: &compute.Address{
+				Name:     "my-cluster-apiserver",
+				SelfLink: "https://www.googleapis.com/compute/v1/projects/proj-id/regions/us-central1/addresses/my-cluster-apiserver",
+			},
+			backendService: &compute.BackendService{},
+			targetTcpproxy: &compute.TargetTcpProxy{
+				Name: "my-cluster-apiserver",
+			},
+			mockForwardingRule: &cloud.MockGlobalForwardingRules{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockGlobalForwardingRulesObj{},
+			},
+			want: &compute.ForwardingRule{
+				IPAddress:           "https://www.googleapis.com/compute/v1/projects/proj-id/regions/us-central1/addresses/my-cluster-apiserver",
+				IPProtocol:          "TCP",
+				LoadBalancingScheme: "EXTERNAL_MANAGED",
+				PortRange:           "443-443",
+				Name:                "my-cluster-apiserver",
+				SelfLink:            "https://www.googleapis.com/compute/v1/projects/proj-id/global/forwardingRules/my-cluster-apiserver",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -820,6 +1234,174 @@ func TestService_createOrGetRegionalForwardingRule(t *testing.T) {
 				SelfLink:            "https://www.googleapis.com/compute/v1/projects/proj-id/regions/us-central1/forwardingRules/my-cluster-api-internal",
 			},
 		},
+		{
+			name: "regional forwarding rule does not exist for internal load balancer with global access (should create forwardingrule with global access)",
+			scope: func(s *scope.ClusterScope) Scope {
+				s.GCPCluster.Spec.LoadBalancer.InternalLoadBalancer = &infrav1.LoadBalancer{
+					InternalAccess: infrav1.InternalAccessGlobal,
+				}
+				return s
+			},
+			lbName: infrav1.InternalRoleTagValue,
+			address: &compute.Address{
+				Name:     "my-cluster-api-internal",
+				SelfLink: "https://www.googleapis.com/compute/v1/projects/proj-id/regions/us-central1/addresses/my-cluster-api-internal",
+			},
+			backendService: &compute.BackendService{
+				Name: "my-cluster-api-internal",
+			},
+			targetTcpproxy: &compute.TargetTcpProxy{},
+			mockSubnetworks: &cloud.MockSubnetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockSubnetworksObj{
+					*meta.RegionalKey("control-plane", "us-central1"): {},
+				},
+			},
+			mockForwardingRule: &cloud.MockForwardingRules{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockForwardingRulesObj{},
+			},
+			want: &compute.ForwardingRule{
+				AllowGlobalAccess:   true,
+				IPAddress:           "https://www.googleapis.com/compute/v1/projects/proj-id/regions/us-central1/addresses/my-cluster-api-internal",
+				IPProtocol:          "TCP",
+				LoadBalancingScheme: "INTERNAL",
+				Ports:               []string{"6443", "22623"},
+				Region:              "us-central1",
+				Name:                "my-cluster-api-internal",
+				SelfLink:            "https://www.googleapis.com/compute/v1/projects/proj-id/regions/us-central1/forwardingRules/my-cluster-api-internal",
+			},
+		},
+		{
+			name: "regional forwarding rule does not exist for internal load balancer with additional ports (should create forwardingrule with configured ports)",
+			scope: func(s *scope.ClusterScope) Scope {
+				s.GCPCluster.Spec.LoadBalancer.InternalLoadBalancer = &infrav1.LoadBalancer{
+					Ports: []int32{8132, 6443},
+				}
+				return s
+			},
+			lbName: infrav1.InternalRoleTagValue,
+			address: &compute.Address{
+				Name:     "my-cluster-api-internal",
+				SelfLink: "https://www.googleapis.com/compute/v1/projects/proj-id/regions/us-central1/addresses/my-cluster-api-internal",
+			},
+			backendService: &compute.BackendService{
+				Name: "my-cluster-api-internal",
+			},
+			targetTcpproxy: &compute.TargetTcpProxy{},
+			mockSubnetworks: &cloud.MockSubnetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockSubnetworksObj{
+					*meta.RegionalKey("control-plane", "us-central1"): {},
+				},
+			},
+			mockForwardingRule: &cloud.MockForwardingRules{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockForwardingRulesObj{},
+			},
+			want: &compute.ForwardingRule{
+				IPAddress:           "https://www.googleapis.com/compute/v1/projects/proj-id/regions/us-central1/addresses/my-cluster-api-internal",
+				IPProtocol:          "TCP",
+				LoadBalancingScheme: "INTERNAL",
+				Ports:               []string{"6443", "8132"},
+				Region:              "us-central1",
+				Name:                "my-cluster-api-internal",
+				SelfLink:            "https://www.googleapis.com/compute/v1/projects/proj-id/regions/us-central1/forwardingRules/my-cluster-api-internal",
+			},
+		},
+		{
+			name: "regional forwarding rule does not exist for internal load balancer without additional ports (should create forwardingrule with only the API server port)",
+			scope: func(s *scope.ClusterScope) Scope {
+				s.GCPCluster.Spec.LoadBalancer.InternalLoadBalancer = &infrav1.LoadBalancer{
+					Ports: []int32{},
+				}
+				return s
+			},
+			lbName: infrav1.InternalRoleTagValue,
+			address: &compute.Address{
+				Name:     "my-cluster-api-internal",
+				SelfLink: "https://www.googleapis.com/compute/v1/projects/proj-id/regions/us-central1/addresses/my-cluster-api-internal",
+			},
+			backendService: &compute.BackendService{
+				Name: "my-cluster-api-internal",
+			},
+			targetTcpproxy: &compute.TargetTcpProxy{},
+			mockSubnetworks: &cloud.MockSubnetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockSubnetworksObj{
+					*meta.RegionalKey("control-plane", "us-central1"): {},
+				},
+			},
+			mockForwardingRule: &cloud.MockForwardingRules{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockForwardingRulesObj{},
+			},
+			want: &compute.ForwardingRule{
+				IPAddress:           "https://www.googleapis.com/compute/v1/projects/proj-id/regions/us-central1/addresses/my-cluster-api-internal",
+				IPProtocol:          "TCP",
+				LoadBalancingScheme: "INTERNAL",
+				Ports:               []string{"6443"},
+				Region:              "us-central1",
+				Name:                "my-cluster-api-internal",
+				SelfLink:            "https://www.googleapis.com/compute/v1/projects/proj-id/regions/us-central1/forwardingRules/my-cluster-api-internal",
+			},
+		},
+		{
+			name: "regional forwarding rule exists and internal access is flipped to global (should update forwardingrule)",
+			scope: func(s *scope.ClusterScope) Scope {
+				s.GCPCluster.Spec.LoadBalancer.InternalLoadBalancer = &infrav1.LoadBalancer{
+					InternalAccess: infrav1.InternalAccessGlobal,
+				}
+				return s
+			},
+			lbName: infrav1.InternalRoleTagValue,
+			address: &compute.Address{
+				Name:     "my-cluster-api-internal",
+				SelfLink: "https://www.googleapis.com/compute/v1/projects/proj-id/regions/us-central1/addresses/my-cluster-api-internal",
+			},
+			backendService: &compute.BackendService{
+				Name: "my-cluster-api-internal",
+			},
+			targetTcpproxy: &compute.TargetTcpProxy{},
+			mockSubnetworks: &cloud.MockSubnetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockSubnetworksObj{
+					*meta.RegionalKey("control-plane", "us-central1"): {},
+				},
+			},
+			mockForwardingRule: &cloud.MockForwardingRules{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects: map[meta.Key]*cloud.MockForwardingRulesObj{
+					*meta.RegionalKey("my-cluster-api-internal", "us-central1"): {
+						Obj: &compute.ForwardingRule{
+							IPAddress:           "https://www.googleapis.com/compute/v1/projects/proj-id/regions/us-central1/addresses/my-cluster-api-internal",
+							IPProtocol:          "TCP",
+							LoadBalancingScheme: "INTERNAL",
+							Ports:               []string{"6443", "22623"},
+							Region:              "us-central1",
+							Name:                "my-cluster-api-internal",
+							SelfLink:            "https://www.googleapis.com/compute/v1/projects/proj-id/regions/us-central1/forwardingRules/my-cluster-api-internal",
+						},
+					},
+				},
+				PatchHook: func(_ context.Context, key *meta.Key, obj *compute.ForwardingRule, m *cloud.MockForwardingRules, _ ...cloud.Option) error {
+					fwd := m.Objects[*key].ToGA()
+					fwd.AllowGlobalAccess = obj.AllowGlobalAccess
+					m.Objects[*key].Obj = fwd
+					return nil
+				},
+			},
+			want: &compute.ForwardingRule{
+				AllowGlobalAccess:   true,
+				IPAddress:           "https://www.googleapis.com/compute/v1/projects/proj-id/regions/us-central1/addresses/my-cluster-api-internal",
+				IPProtocol:          "TCP",
+				LoadBalancingScheme: "INTERNAL",
+				Ports:               []string{"6443", "22623"},
+				Region:              "us-central1",
+				Name:                "my-cluster-api-internal",
+				SelfLink:            "https://www.googleapis.com/compute/v1/projects/proj-id/regions/us-central1/forwardingRules/my-cluster-api-internal",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -51,6 +51,7 @@ type regionalforwardingrulesInterface interface {
 	Get(ctx context.Context, key *meta.Key, options ...k8scloud.Option) (*compute.ForwardingRule, error)
 	Insert(ctx context.Context, key *meta.Key, obj *compute.ForwardingRule, options ...k8scloud.Option) error
 	Delete(ctx context.Context, key *meta.Key, options ...k8scloud.Option) error
+	Patch(ctx context.Context, key *meta.Key, obj *compute.ForwardingRule, options ...k8scloud.Option) error
 	SetLabels(ctx context.Context, key *meta.Key, obj *compute.RegionSetLabelsRequest, options ...k8scloud.Option) error
 }
 
@@ -73,6 +74,10 @@ type targettcpproxiesInterface interface {
 	Delete(ctx context.Context, key *meta.Key, options ...k8scloud.Option) error
 }
 
+type proxyHeaderInterface interface {
+	SetProxyHeader(ctx context.Context, key *meta.Key, proxyHeader string) error
+}
+
 type subnetsInterface interface {
 	Get(ctx context.Context, key *meta.Key, options ...k8scloud.Option) (*compute.Subnetwork, error)
 }
@@ -103,6 +108,7 @@ type Service struct {
 	instancegroups          instancegroupsInterface
 	targettcpproxies        targettcpproxiesInterface
 	subnets                 subnetsInterface
+	proxyheaders            proxyHeaderInterface
 }
 
 var _ cloud.Reconciler = &Service{}
@@ -127,5 +133,10 @@ func New(scope Scope) *Service {
 		instancegroups:          scope.Cloud().InstanceGroups(),
 		targettcpproxies:        scope.Cloud().TargetTcpProxies(),
 		subnets:                 cloudScope.Subnetworks(),
+		proxyheaders: &proxyHeaderClient{
+			compute:     scope.ComputeService(),
+			rateLimiter: scope.RateLimiter(),
+			project:     scope.Project(),
+		},
 	}
 }
@@ -26,6 +26,7 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -44,7 +45,10 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			return err
 		}
 
-		s.scope.Network().Router = ptr.To[string](router.SelfLink)
+		// The router is not created in dry-run mode.
+		if router != nil {
+			s.scope.Network().Router = ptr.To[string](router.SelfLink)
+		}
 	}
 
 	s.scope.Network().SelfLink = ptr.To[string](network.SelfLink)
@@ -82,12 +86,16 @@ func (s *Service) Delete(ctx context.Context) error {
 		return err
 	}
 
-	if router != nil && router.Description == infrav1.ClusterTagKey(s.scope.Name()) {
+	if router != nil && router.Description == infrav1.ClusterTagKey(s.scope.Name()) && !shared.SkipDryRun(ctx, s.scope, "delete", "cloudnat router", routerSpec.Name) {
 		if err := s.routers.Delete(ctx, routerKey); err != nil && !gcperrors.IsNotFound(err) {
 			return err
 		}
 	}
 
+	if shared.SkipDryRun(ctx, s.scope, "delete", "network", s.scope.NetworkName()) {
+		return nil
+	}
+
 	if err := s.networks.Delete(ctx, networkKey); err != nil {
 		log.Error(err, "Error deleting a network", "name", s.scope.NetworkName())
 		return err
@@ -115,6 +123,10 @@ func (s *Service) createOrGetNetwork(ctx context.Context) (*compute.Network, err
 			return nil, err
 		}
 
+		if shared.SkipDryRun(ctx, s.scope, "create", "network", s.scope.NetworkName()) {
+			return nil, shared.ErrDryRun
+		}
+
 		log.V(2).Info("Creating a network", "name", s.scope.NetworkName())
 		if err := s.networks.Insert(ctx, networkKey, s.scope.NetworkSpec()); err != nil {
 			log.Error(err, "Error creating a network", "name", s.scope.NetworkName())
@@ -130,7 +142,8 @@ func (s *Service) createOrGetNetwork(ctx context.Context) (*compute.Network, err
 	return network, nil
 }
 
-// createOrGetRouter creates a cloudnat router if not exist otherwise return the existing.
+// createOrGetRouter creates a cloudnat router if not exist otherwise return the existing. No router is returned
+// when it does not exist in dry-run mode.
 func (s *Service) createOrGetRouter(ctx context.Context, network *compute.Network) (*compute.Router, error) {
 	log := log.FromContext(ctx)
 	spec := s.scope.NatRouterSpec()
@@ -148,6 +161,10 @@ func (s *Service) createOrGetRouter(ctx context.Context, network *compute.Networ
 			return nil, err
 		}
 
+		if shared.SkipDryRun(ctx, s.scope, "create", "cloudnat router", spec.Name) {
+			return nil, nil
+		}
+
 		spec.Network = network.SelfLink
 		spec.Description = infrav1.ClusterTagKey(s.scope.Name())
 		log.V(2).Info("Creating a cloudnat router", "name", spec.Name)
@@ -18,6 +18,7 @@ package networks
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -131,7 +132,57 @@ func TestService_createOrGetNetwork(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	configuredGCPCluster := fakeGCPCluster.DeepCopy()
+	configuredGCPCluster.Spec.Network.AutoCreateSubnetworks = ptr.To(false)
+	configuredGCPCluster.Spec.Network.Mtu = 8896
+	clusterScopeConfigured, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: configuredGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// assertNetwork returns an assertion that the network was created with the given subnet mode and MTU.
+	assertNetwork := func(autoCreateSubnetworks bool, mtu int64) func(ctx context.Context, tt testCase) error {
+		return func(ctx context.Context, tt testCase) error {
+			network, err := tt.mockNetwork.Get(ctx, meta.GlobalKey(*fakeGCPCluster.Spec.Network.Name))
+			if err != nil {
+				return err
+			}
+			if network.AutoCreateSubnetworks != autoCreateSubnetworks {
+				return fmt.Errorf("network AutoCreateSubnetworks = %t, want %t", network.AutoCreateSubnetworks, autoCreateSubnetworks)
+			}
+			if network.Mtu != mtu {
+				return fmt.Errorf("network Mtu = %d, want %d", network.Mtu, mtu)
+			}
+			return nil
+		}
+	}
+
 	tests := []testCase{
+		{
+			name:  "network does not exist (should create network in auto subnet mode with the default MTU)",
+			scope: func() Scope { return clusterScope },
+			mockNetwork: &cloud.MockNetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockNetworksObj{},
+			},
+			assert: assertNetwork(true, 1460),
+		},
+		{
+			name:  "network does not exist and is configured (should create network in custom subnet mode with the configured MTU)",
+			scope: func() Scope { return clusterScopeConfigured },
+			mockNetwork: &cloud.MockNetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockNetworksObj{},
+			},
+			assert: assertNetwork(false, 8896),
+		},
 		{
 			name:  "network already exist (should return existing network)",
 			scope: func() Scope { return clusterScope },
@@ -387,17 +438,61 @@ func TestService_Delete(t *testing.T) {
 				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
 			},
 		},
+		{
+			name:  "network not created by CAPG, should not delete it",
+			scope: func() Scope { return clusterScope },
+			mockNetwork: &cloud.MockNetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockNetworksObj{
+					*meta.GlobalKey(*fakeGCPCluster.Spec.Network.Name): {Obj: &compute.Network{Description: "my-custom-network"}},
+				},
+			},
+			assert: func(_ context.Context, tt testCase) error {
+				if _, ok := tt.mockNetwork.Objects[*meta.GlobalKey(*fakeGCPCluster.Spec.Network.Name)]; !ok {
+					return errors.New("network not created by CAPG was deleted")
+				}
+				return nil
+			},
+		},
+		{
+			name:  "network created by CAPG, should delete it",
+			scope: func() Scope { return clusterScope },
+			mockNetwork: &cloud.MockNetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockNetworksObj{
+					*meta.GlobalKey(*fakeGCPCluster.Spec.Network.Name): {Obj: &compute.Network{Description: infrav1.ClusterTagKey(fakeCluster.Name)}},
+				},
+			},
+			mockRouter: &cloud.MockRouters{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRoutersObj{},
+			},
+			assert: func(_ context.Context, tt testCase) error {
+				if _, ok := tt.mockNetwork.Objects[*meta.GlobalKey(*fakeGCPCluster.Spec.Network.Name)]; ok {
+					return errors.New("network created by CAPG was not deleted")
+				}
+				return nil
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.TODO()
 			s := New(tt.scope())
 			s.networks = tt.mockNetwork
+			if tt.mockRouter != nil {
+				s.routers = tt.mockRouter
+			}
 			err := s.Delete(ctx)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Service.Delete() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.assert != nil {
+				if err := tt.assert(ctx, tt); err != nil {
+					t.Errorf("network was not deleted as expected: %v", err)
+				}
+			}
 		})
 	}
 }
@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routers
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Reconcile reconcile cluster cloud router components.
+func (s *Service) Reconcile(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	if s.scope.IsSharedVpc() {
+		log.V(2).Info("Shared VPC enabled. Ignore Reconciling cloud router resources")
+		return nil
+	}
+
+	if !s.scope.CloudRouterEnabled() {
+		// The cloud router may have been created before being removed from the spec.
+		if s.scope.Network().CloudRouter == nil {
+			return nil
+		}
+		return s.deleteRouter(ctx)
+	}
+
+	log.Info("Reconciling cloud router resources")
+	spec := s.scope.CloudRouterSpec()
+	routerKey := meta.RegionalKey(spec.Name, s.scope.Region())
+	log.V(2).Info("Looking for cloud router", "name", spec.Name)
+	router, err := s.routers.Get(ctx, routerKey)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for cloud router", "name", spec.Name)
+			return err
+		}
+
+		if shared.SkipDryRun(ctx, s.scope, "create", "cloud router", spec.Name) {
+			return nil
+		}
+
+		log.V(2).Info("Creating a cloud router", "name", spec.Name)
+		if err := s.routers.Insert(ctx, routerKey, spec); err != nil {
+			log.Error(err, "Error creating a cloud router", "name", spec.Name)
+			return err
+		}
+
+		router, err = s.routers.Get(ctx, routerKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	if router.Description == infrav1.ClusterTagKey(s.scope.Name()) {
+		s.scope.Network().CloudRouter = ptr.To[string](router.SelfLink)
+	}
+
+	if !bgpEqual(router.Bgp, spec.Bgp) {
+		if shared.SkipDryRun(ctx, s.scope, "update", "cloud router", spec.Name) {
+			return nil
+		}
+
+		log.V(2).Info("Updating cloud router BGP configuration", "name", spec.Name)
+		if err := s.routers.Patch(ctx, routerKey, &compute.Router{Bgp: bgpPatch(spec.Bgp)}); err != nil {
+			log.Error(err, "Error updating cloud router BGP configuration", "name", spec.Name)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete delete cluster cloud router components.
+func (s *Service) Delete(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	if s.scope.IsSharedVpc() {
+		log.V(2).Info("Shared VPC enabled. Ignore Deleting cloud router resources")
+		return nil
+	}
+	log.Info("Deleting cloud router resources")
+
+	return s.deleteRouter(ctx)
+}
+
+// deleteRouter deletes the cloud router if it exists and was created by CAPG, and forgets it in the status.
+func (s *Service) deleteRouter(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	spec := s.scope.CloudRouterSpec()
+	routerKey := meta.RegionalKey(spec.Name, s.scope.Region())
+	router, err := s.routers.Get(ctx, routerKey)
+	if err != nil {
+		if gcperrors.IsNotFound(err) {
+			s.scope.Network().CloudRouter = nil
+			return nil
+		}
+		log.Error(err, "Error looking for cloud router before deleting", "name", spec.Name)
+		return err
+	}
+
+	if router.Description != infrav1.ClusterTagKey(s.scope.Name()) {
+		log.V(2).Info("Cloud router not created by CAPG, skipping deletion", "name", spec.Name)
+		s.scope.Network().CloudRouter = nil
+		return nil
+	}
+
+	if shared.SkipDryRun(ctx, s.scope, "delete", "cloud router", spec.Name) {
+		return nil
+	}
+
+	log.V(2).Info("Deleting cloud router", "name", spec.Name)
+	if err := s.routers.Delete(ctx, routerKey); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting cloud router", "name", spec.Name)
+		return err
+	}
+
+	s.scope.Network().CloudRouter = nil
+	return nil
+}
+
+// bgpPatch returns the BGP configuration patching the cloud router to the desired one. The advertisements
+// which are not desired anymore are explicitly cleared, as empty fields are otherwise left unchanged by a patch.
+func bgpPatch(desired *compute.RouterBgp) *compute.RouterBgp {
+	bgp := *desired
+	bgp.ForceSendFields = []string{"AdvertiseMode"}
+	if len(bgp.AdvertisedGroups) == 0 {
+		bgp.NullFields = append(bgp.NullFields, "AdvertisedGroups")
+	}
+	if len(bgp.AdvertisedIpRanges) == 0 {
+		bgp.NullFields = append(bgp.NullFields, "AdvertisedIpRanges")
+	}
+	return &bgp
+}
+
+// bgpEqual reports whether the BGP configuration of the cloud router matches the desired one. Missing and
+// empty advertisements are equal, and the advertised IP ranges are only compared by range and description.
+func bgpEqual(current, desired *compute.RouterBgp) bool {
+	if current == nil {
+		return desired == nil
+	}
+
+	return current.Asn == desired.Asn &&
+		current.AdvertiseMode == desired.AdvertiseMode &&
+		cmp.Equal(current.AdvertisedGroups, desired.AdvertisedGroups, cmpopts.EquateEmpty()) &&
+		cmp.Equal(advertisedIPRanges(current), advertisedIPRanges(desired), cmpopts.EquateEmpty())
+}
+
+// advertisedIPRanges returns the ranges advertised by the BGP configuration, with their description.
+func advertisedIPRanges(bgp *compute.RouterBgp) []string {
+	ranges := make([]string, 0, len(bgp.AdvertisedIpRanges))
+	for _, r := range bgp.AdvertisedIpRanges {
+		ranges = append(ranges, r.Range+" "+r.Description)
+	}
+	return ranges
+}
@@ -0,0 +1,406 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+}
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{},
+}
+
+var fakeGCPCluster = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+		Network: infrav1.NetworkSpec{
+			Name: ptr.To("my-network"),
+		},
+	},
+}
+
+var fakeGCPClusterCloudRouter = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+		Network: infrav1.NetworkSpec{
+			Name: ptr.To("my-network"),
+		},
+		CloudRouter: &infrav1.CloudRouterSpec{
+			ASN: 65001,
+			AdvertisedIPRanges: []infrav1.CloudRouterAdvertisedIPRange{
+				{Range: "10.100.0.0/16", Description: "pods"},
+			},
+		},
+	},
+}
+
+var routerKey = meta.RegionalKey("my-cluster-bgp-router", "us-central1")
+
+type testCase struct {
+	name        string
+	scope       func() Scope
+	mockRouters *cloud.MockRouters
+	wantErr     bool
+	assert      func(ctx context.Context, t testCase) error
+}
+
+func newScope(t *testing.T, gcpCluster *infrav1.GCPCluster) *scope.ClusterScope {
+	t.Helper()
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: gcpCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return clusterScope
+}
+
+func TestService_Reconcile(t *testing.T) {
+	clusterScope := newScope(t, fakeGCPCluster)
+	clusterScopeCloudRouter := newScope(t, fakeGCPClusterCloudRouter)
+	gcpClusterDefaultAdvertisements := fakeGCPClusterCloudRouter.DeepCopy()
+	gcpClusterDefaultAdvertisements.Spec.CloudRouter.AdvertisedIPRanges = nil
+	clusterScopeDefaultAdvertisements := newScope(t, gcpClusterDefaultAdvertisements)
+	gcpClusterCloudRouterRemoved := fakeGCPCluster.DeepCopy()
+	gcpClusterCloudRouterRemoved.Status.Network.CloudRouter = ptr.To("https://www.googleapis.com/compute/v1/projects/my-proj/regions/us-central1/routers/my-cluster-bgp-router")
+	clusterScopeCloudRouterRemoved := newScope(t, gcpClusterCloudRouterRemoved)
+
+	wantBgp := &compute.RouterBgp{
+		Asn:              65001,
+		AdvertiseMode:    "CUSTOM",
+		AdvertisedGroups: []string{"ALL_SUBNETS"},
+		AdvertisedIpRanges: []*compute.RouterAdvertisedIpRange{
+			{Range: "10.100.0.0/16", Description: "pods"},
+		},
+	}
+
+	tests := []testCase{
+		{
+			name:  "cloud router does not exist (should create router with ASN and advertised ranges)",
+			scope: func() Scope { return clusterScopeCloudRouter },
+			mockRouters: &cloud.MockRouters{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRoutersObj{},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				router, err := t.mockRouters.Get(ctx, routerKey)
+				if err != nil {
+					return err
+				}
+
+				want := &compute.Router{
+					Name:        "my-cluster-bgp-router",
+					Description: infrav1.ClusterTagKey("my-cluster"),
+					Network:     "projects/my-proj/global/networks/my-network",
+					Bgp:         wantBgp,
+					SelfLink:    router.SelfLink,
+				}
+				if d := cmp.Diff(want, router); d != "" {
+					return fmt.Errorf("cloud router mismatch (-want +got):\n%s", d)
+				}
+				if link := clusterScopeCloudRouter.Network().CloudRouter; link == nil || *link != router.SelfLink {
+					return fmt.Errorf("cloud router was not recorded in the status: %v", link)
+				}
+				return nil
+			},
+		},
+		{
+			name:  "cloud router with a different ASN (should patch the BGP configuration)",
+			scope: func() Scope { return clusterScopeCloudRouter },
+			mockRouters: &cloud.MockRouters{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockRoutersObj{
+					*routerKey: {Obj: &compute.Router{
+						Name:        "my-cluster-bgp-router",
+						Description: infrav1.ClusterTagKey("my-cluster"),
+						Bgp:         &compute.RouterBgp{Asn: 64512, AdvertiseMode: "DEFAULT"},
+					}},
+				},
+				PatchHook: func(_ context.Context, key *meta.Key, obj *compute.Router, m *cloud.MockRouters, _ ...cloud.Option) error {
+					router := m.Objects[*key].ToGA()
+					router.Bgp = obj.Bgp
+					m.Objects[*key].Obj = router
+					return nil
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				router, err := t.mockRouters.Get(ctx, routerKey)
+				if err != nil {
+					return err
+				}
+				if d := cmp.Diff(wantBgp, router.Bgp, cmpopts.IgnoreFields(compute.RouterBgp{}, "ForceSendFields", "NullFields")); d != "" {
+					return fmt.Errorf("cloud router BGP mismatch (-want +got):\n%s", d)
+				}
+				return nil
+			},
+		},
+		{
+			name:  "cloud router with custom advertisements removed from the spec (should clear them)",
+			scope: func() Scope { return clusterScopeDefaultAdvertisements },
+			mockRouters: &cloud.MockRouters{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockRoutersObj{
+					*routerKey: {Obj: &compute.Router{
+						Name:        "my-cluster-bgp-router",
+						Description: infrav1.ClusterTagKey("my-cluster"),
+						Bgp:         wantBgp,
+					}},
+				},
+				PatchHook: func(_ context.Context, key *meta.Key, obj *compute.Router, m *cloud.MockRouters, _ ...cloud.Option) error {
+					router := m.Objects[*key].ToGA()
+					router.Bgp = obj.Bgp
+					m.Objects[*key].Obj = router
+					return nil
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				router, err := t.mockRouters.Get(ctx, routerKey)
+				if err != nil {
+					return err
+				}
+				want := &compute.RouterBgp{
+					Asn:             65001,
+					AdvertiseMode:   "DEFAULT",
+					ForceSendFields: []string{"AdvertiseMode"},
+					NullFields:      []string{"AdvertisedGroups", "AdvertisedIpRanges"},
+				}
+				if d := cmp.Diff(want, router.Bgp); d != "" {
+					return fmt.Errorf("cloud router BGP patch mismatch (-want +got):\n%s", d)
+				}
+				return nil
+			},
+		},
+		{
+			name:  "cloud router up to date (should not patch the BGP configuration)",
+			scope: func() Scope { return clusterScopeCloudRouter },
+			mockRouters: &cloud.MockRouters{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockRoutersObj{
+					*routerKey: {Obj: &compute.Router{
+						Name:        "my-cluster-bgp-router",
+						Description: infrav1.ClusterTagKey("my-cluster"),
+						Bgp: &compute.RouterBgp{
+							Asn:              65001,
+							AdvertiseMode:    "CUSTOM",
+							AdvertisedGroups: []string{"ALL_SUBNETS"},
+							AdvertisedIpRanges: []*compute.RouterAdvertisedIpRange{
+								{Range: "10.100.0.0/16", Description: "pods"},
+							},
+						},
+					}},
+				},
+				PatchHook: func(_ context.Context, _ *meta.Key, _ *compute.Router, _ *cloud.MockRouters, _ ...cloud.Option) error {
+					return errors.New("unexpected patch")
+				},
+			},
+		},
+		{
+			name:  "error getting cloud router with non 404 error code (should return an error)",
+			scope: func() Scope { return clusterScopeCloudRouter },
+			mockRouters: &cloud.MockRouters{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRoutersObj{},
+				GetHook: func(_ context.Context, _ *meta.Key, _ *cloud.MockRouters, _ ...cloud.Option) (bool, *compute.Router, error) {
+					return true, &compute.Router{}, &googleapi.Error{Code: http.StatusBadRequest}
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:  "cloud router disabled and never created (should not look for the router)",
+			scope: func() Scope { return clusterScope },
+			mockRouters: &cloud.MockRouters{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRoutersObj{},
+				GetHook: func(_ context.Context, _ *meta.Key, _ *cloud.MockRouters, _ ...cloud.Option) (bool, *compute.Router, error) {
+					return true, nil, errors.New("unexpected get")
+				},
+			},
+		},
+		{
+			name:  "cloud router removed from the spec (should delete existing owned router)",
+			scope: func() Scope { return clusterScopeCloudRouterRemoved },
+			mockRouters: &cloud.MockRouters{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockRoutersObj{
+					*routerKey: {Obj: &compute.Router{
+						Name:        "my-cluster-bgp-router",
+						Description: infrav1.ClusterTagKey("my-cluster"),
+					}},
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				if _, err := t.mockRouters.Get(ctx, routerKey); err == nil {
+					return errors.New("cloud router was not deleted")
+				}
+				if link := clusterScopeCloudRouterRemoved.Network().CloudRouter; link != nil {
+					return fmt.Errorf("deleted cloud router is still recorded in the status: %s", *link)
+				}
+				return nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			s := New(tt.scope())
+			s.routers = tt.mockRouters
+			err := s.Reconcile(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.assert != nil {
+				if err := tt.assert(ctx, tt); err != nil {
+					t.Errorf("cloud router was not reconciled as expected: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	clusterScopeCloudRouter := newScope(t, fakeGCPClusterCloudRouter)
+
+	tests := []testCase{
+		{
+			name:  "cloud router does not exist, should do nothing",
+			scope: func() Scope { return clusterScopeCloudRouter },
+			mockRouters: &cloud.MockRouters{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRoutersObj{},
+			},
+		},
+		{
+			name:  "owned cloud router, should be deleted",
+			scope: func() Scope { return clusterScopeCloudRouter },
+			mockRouters: &cloud.MockRouters{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockRoutersObj{
+					*routerKey: {Obj: &compute.Router{
+						Name:        "my-cluster-bgp-router",
+						Description: infrav1.ClusterTagKey("my-cluster"),
+					}},
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				if _, err := t.mockRouters.Get(ctx, routerKey); err == nil {
+					return errors.New("cloud router was not deleted")
+				}
+				return nil
+			},
+		},
+		{
+			name:  "cloud router not created by CAPG, should be kept",
+			scope: func() Scope { return clusterScopeCloudRouter },
+			mockRouters: &cloud.MockRouters{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockRoutersObj{
+					*routerKey: {Obj: &compute.Router{
+						Name: "my-cluster-bgp-router",
+					}},
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				if _, err := t.mockRouters.Get(ctx, routerKey); err != nil {
+					return errors.New("cloud router not created by CAPG was deleted")
+				}
+				return nil
+			},
+		},
+		{
+			name:  "error deleting cloud router, should return error",
+			scope: func() Scope { return clusterScopeCloudRouter },
+			mockRouters: &cloud.MockRouters{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockRoutersObj{
+					*routerKey: {Obj: &compute.Router{
+						Name:        "my-cluster-bgp-router",
+						Description: infrav1.ClusterTagKey("my-cluster"),
+					}},
+				},
+				DeleteError: map[meta.Key]error{
+					*routerKey: &googleapi.Error{Code: http.StatusBadRequest},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			s := New(tt.scope())
+			s.routers = tt.mockRouters
+			err := s.Delete(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Delete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.assert != nil {
+				if err := tt.assert(ctx, tt); err != nil {
+					t.Errorf("cloud router was not deleted as expected: %v", err)
+				}
+			}
+		})
+	}
+}
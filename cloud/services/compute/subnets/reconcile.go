@@ -25,6 +25,7 @@ import (
 	"google.golang.org/api/compute/v1"
 
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -60,11 +61,14 @@ func (s *Service) Delete(ctx context.Context) error {
 			return err
 		}
 
-		// Skip delete if subnet was not created by CAPG.
-		// If subnet description is not set by the Spec, or by our default value, then assume it was created externally.
-		if subnet.Description != infrav1.ClusterTagKey(s.scope.Name()) && (subnetSpec.Description == "" || subnet.Description != subnetSpec.Description) {
+		// Skip delete if subnet was not created by CAPG, but keep deleting the other subnets which may be.
+		if !s.isOwned(subnet, subnetSpec) {
 			logger.V(2).Info("Skipping subnet deletion as it was created outside of Cluster API", "name", subnetSpec.Name)
-			return nil
+			continue
+		}
+
+		if shared.SkipDryRun(ctx, s.scope, "delete", "subnet", subnetSpec.Name) {
+			continue
 		}
 
 		logger.V(2).Info("Deleting a subnet", "name", subnetSpec.Name)
@@ -99,6 +103,10 @@ func (s *Service) createOrGetSubnets(ctx context.Context) ([]*compute.Subnetwork
 			}
 
 			// Subnet was not found, let's create it
+			if shared.SkipDryRun(ctx, s.scope, "create", "subnet", subnetSpec.Name) {
+				continue
+			}
+
 			logger.V(2).Info("Creating a subnet", "name", subnetSpec.Name)
 			if err := s.subnets.Insert(ctx, subnetKey, subnetSpec); err != nil {
 				logger.Error(err, "Error creating a subnet", "name", subnetSpec.Name)
@@ -117,6 +125,16 @@ func (s *Service) createOrGetSubnets(ctx context.Context) ([]*compute.Subnetwork
 	return subnets, nil
 }
 
+// isOwned returns true if the subnet was created by CAPG. Subnetworks don't support labels, so the description set
+// at creation time marks the ownership: either the cluster tag, or the description given by the Spec. A subnet
+// with any other description is assumed to have been created externally.
+func (s *Service) isOwned(subnet, subnetSpec *compute.Subnetwork) bool {
+	if subnet.Description == infrav1.ClusterTagKey(s.scope.Name()) {
+		return true
+	}
+	return subnetSpec.Description != "" && subnet.Description == subnetSpec.Description
+}
+
 // getSubnetRegion returns subnet region if user provided it, otherwise returns default scope region.
 func (s *Service) getSubnetRegion(subnetSpec *compute.Subnetwork) string {
 	if subnetSpec.Region != "" {
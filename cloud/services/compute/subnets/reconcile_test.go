@@ -252,7 +252,44 @@ func TestService_Delete(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	adoptedGCPCluster := fakeGCPCluster.DeepCopy()
+	adoptedGCPCluster.Spec.Network.Subnets = infrav1.Subnets{
+		{Name: "adopted", CidrBlock: "10.0.1.0/28", Region: "us-central1"},
+		{Name: "owned", CidrBlock: "10.0.2.0/28", Region: "us-central1"},
+	}
+	clusterScopeAdopted, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: adoptedGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	tests := []testCase{
+		{
+			name:  "adopted subnet is kept while owned subnet is deleted",
+			scope: func() Scope { return clusterScopeAdopted },
+			mockSubnetworks: &cloud.MockSubnetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockSubnetworksObj{
+					*meta.RegionalKey("adopted", "us-central1"): {Obj: &compute.Subnetwork{Name: "adopted"}},
+					*meta.RegionalKey("owned", "us-central1"):   {Obj: &compute.Subnetwork{Name: "owned", Description: infrav1.ClusterTagKey(fakeCluster.Name)}},
+				},
+			},
+			assert: func(_ context.Context, tt testCase) error {
+				if _, ok := tt.mockSubnetworks.Objects[*meta.RegionalKey("adopted", "us-central1")]; !ok {
+					return errors.New("adopted subnet was deleted")
+				}
+				if _, ok := tt.mockSubnetworks.Objects[*meta.RegionalKey("owned", "us-central1")]; ok {
+					return errors.New("owned subnet was not deleted")
+				}
+				return nil
+			},
+		},
 		{
 			name:  "subnet does not exist, should do nothing",
 			scope: func() Scope { return clusterScope },
@@ -312,6 +349,11 @@ func TestService_Delete(t *testing.T) {
 				t.Errorf("Service.Delete() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.assert != nil {
+				if err := tt.assert(ctx, tt); err != nil {
+					t.Errorf("subnets were not deleted as expected: %v", err)
+				}
+			}
 		})
 	}
 }
@@ -0,0 +1,200 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	container "cloud.google.com/go/container/apiv1"
+	"cloud.google.com/go/container/apiv1/containerpb"
+	credentials "cloud.google.com/go/iam/credentials/apiv1"
+	resourcemanager "cloud.google.com/go/resourcemanager/apiv3"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeClusterManager is a GKE cluster manager server serving a single cluster, and recording the create and
+// update requests it receives.
+type fakeClusterManager struct {
+	containerpb.UnimplementedClusterManagerServer
+
+	mu                    sync.Mutex
+	cluster               *containerpb.Cluster
+	serverConfig          *containerpb.ServerConfig
+	serverConfigErr       error
+	createClusterRequests []*containerpb.CreateClusterRequest
+	updateClusterRequests []*containerpb.UpdateClusterRequest
+}
+
+func (f *fakeClusterManager) GetCluster(_ context.Context, _ *containerpb.GetClusterRequest) (*containerpb.Cluster, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.cluster == nil {
+		return nil, status.Error(codes.NotFound, "cluster not found")
+	}
+	return f.cluster, nil
+}
+
+func (f *fakeClusterManager) GetServerConfig(_ context.Context, _ *containerpb.GetServerConfigRequest) (*containerpb.ServerConfig, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.serverConfigErr != nil {
+		return nil, f.serverConfigErr
+	}
+	return f.serverConfig, nil
+}
+
+func (f *fakeClusterManager) CreateCluster(_ context.Context, req *containerpb.CreateClusterRequest) (*containerpb.Operation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.createClusterRequests = append(f.createClusterRequests, req)
+	return &containerpb.Operation{}, nil
+}
+
+func (f *fakeClusterManager) UpdateCluster(_ context.Context, req *containerpb.UpdateClusterRequest) (*containerpb.Operation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.updateClusterRequests = append(f.updateClusterRequests, req)
+	return &containerpb.Operation{}, nil
+}
+
+// setCluster replaces the cluster served by the fake.
+func (f *fakeClusterManager) setCluster(cluster *containerpb.Cluster) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cluster = cluster
+}
+
+// newFakeClusterManagerService returns a Service reconciling the control plane with the given spec against the
+// fake cluster manager.
+func newFakeClusterManagerService(t *testing.T, fakeClusterManager *fakeClusterManager, spec infrav1exp.GCPManagedControlPlaneSpec) *Service {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	containerpb.RegisterClusterManagerServer(server, fakeClusterManager)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial the fake cluster manager: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	ctx := context.Background()
+	managedClusterClient, err := container.NewClusterManagerClient(ctx, option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatalf("failed to create the cluster manager client: %v", err)
+	}
+	tagBindingsClient, err := resourcemanager.NewTagBindingsClient(ctx, option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatalf("failed to create the tag bindings client: %v", err)
+	}
+	credentialsClient, err := credentials.NewIamCredentialsClient(ctx, option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatalf("failed to create the credentials client: %v", err)
+	}
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+	_ = clusterv1exp.AddToScheme(scheme)
+	_ = infrav1exp.AddToScheme(scheme)
+
+	credentialsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "credentials",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"credentials": []byte(`{"project_id": "my-project"}`),
+		},
+	}
+	spec.Project = "my-project"
+	spec.Location = "us-central1"
+	spec.ClusterName = "my-cluster"
+	gcpManagedControlPlane := &infrav1exp.GCPManagedControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster-control-plane",
+			Namespace: "default",
+		},
+		Spec: spec,
+	}
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(credentialsSecret, gcpManagedControlPlane).
+		Build()
+
+	managedControlPlaneScope, err := scope.NewManagedControlPlaneScope(ctx, scope.ManagedControlPlaneScopeParams{
+		CredentialsClient:    credentialsClient,
+		ManagedClusterClient: managedClusterClient,
+		TagBindingsClient:    tagBindingsClient,
+		Client:               fakec,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-cluster",
+				Namespace: "default",
+			},
+		},
+		GCPManagedCluster: &infrav1exp.GCPManagedCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-cluster",
+				Namespace: "default",
+			},
+			Spec: infrav1exp.GCPManagedClusterSpec{
+				Project: "my-project",
+				Region:  "us-central1",
+				CredentialsRef: &infrav1.ObjectReference{
+					Name:      "credentials",
+					Namespace: "default",
+				},
+			},
+		},
+		GCPManagedControlPlane: gcpManagedControlPlane,
+	})
+	if err != nil {
+		t.Fatalf("failed to create the managed control plane scope: %v", err)
+	}
+
+	return New(managedControlPlaneScope)
+}
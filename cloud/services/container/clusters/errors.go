@@ -23,6 +23,9 @@ import (
 // ErrAutopilotClusterMachinePoolsNotAllowed is used when there are machine pools specified for an autopilot enabled cluster.
 var ErrAutopilotClusterMachinePoolsNotAllowed = errors.New("cannot use machine pools with an autopilot enabled cluster")
 
+// ErrAdoptedClusterNotFound is used when the GKE cluster to adopt does not exist.
+var ErrAdoptedClusterNotFound = errors.New("cannot adopt a GKE cluster which does not exist")
+
 // NewErrUnexpectedClusterStatus creates a new error for an unexpected cluster status.
 func NewErrUnexpectedClusterStatus(status string) error {
 	return &UnexpectedClusterStatusError{status}
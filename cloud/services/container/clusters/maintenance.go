@@ -0,0 +1,178 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+// defaultDailyMaintenanceWindowDuration is the duration GKE uses for daily maintenance windows.
+const defaultDailyMaintenanceWindowDuration = 4 * time.Hour
+
+// rruleWeekdays maps the RRULE BYDAY values to weekdays.
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// nextMaintenanceWindow returns the first occurrence of the maintenance window of the policy that
+// has not ended at the given time. It returns nil when the policy has no window or uses a
+// recurrence that cannot be evaluated.
+func nextMaintenanceWindow(policy *containerpb.MaintenancePolicy, now time.Time) *infrav1exp.MaintenanceWindowOccurrence {
+	var start, end time.Time
+	var ok bool
+	switch {
+	case policy.GetWindow().GetDailyMaintenanceWindow() != nil:
+		start, end, ok = nextDailyMaintenanceWindow(policy.GetWindow().GetDailyMaintenanceWindow(), now.UTC())
+	case policy.GetWindow().GetRecurringWindow() != nil:
+		start, end, ok = nextRecurringMaintenanceWindow(policy.GetWindow().GetRecurringWindow(), now.UTC())
+	}
+	if !ok {
+		return nil
+	}
+
+	return &infrav1exp.MaintenanceWindowOccurrence{
+		StartTime: metav1.NewTime(start),
+		EndTime:   metav1.NewTime(end),
+	}
+}
+
+// nextDailyMaintenanceWindow evaluates a daily window, whose start time is given as HH:MM in GMT.
+func nextDailyMaintenanceWindow(window *containerpb.DailyMaintenanceWindow, now time.Time) (time.Time, time.Time, bool) {
+	startTime, err := time.Parse("15:04", window.GetStartTime())
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	duration := parseMaintenanceWindowDuration(window.GetDuration())
+	timeOfDay := startTime.Sub(truncateToDay(startTime))
+
+	// Start from the previous day to account for a window spanning midnight.
+	day := truncateToDay(now).AddDate(0, 0, -1)
+	for {
+		start := day.Add(timeOfDay)
+		if end := start.Add(duration); end.After(now) {
+			return start, end, true
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+}
+
+// nextRecurringMaintenanceWindow evaluates a recurring window. Only the daily and weekly
+// frequencies of RFC 5545 RRULE are supported, optionally restricted with BYDAY.
+func nextRecurringMaintenanceWindow(window *containerpb.RecurringTimeWindow, now time.Time) (time.Time, time.Time, bool) {
+	if window.GetWindow().GetStartTime() == nil || window.GetWindow().GetEndTime() == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	anchor := window.GetWindow().GetStartTime().AsTime()
+	duration := window.GetWindow().GetEndTime().AsTime().Sub(anchor)
+	if duration <= 0 {
+		return time.Time{}, time.Time{}, false
+	}
+
+	weekdays, ok := parseRecurrence(window.GetRecurrence(), anchor.Weekday())
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+
+	timeOfDay := anchor.Sub(truncateToDay(anchor))
+	day := truncateToDay(now).AddDate(0, 0, -1)
+	if anchorDay := truncateToDay(anchor); anchorDay.After(day) {
+		day = anchorDay
+	}
+	// Every weekday is covered within a week, plus one day for a window spanning midnight.
+	for i := 0; i < 8; i++ {
+		start := day.Add(timeOfDay)
+		if !start.Before(anchor) && slices.Contains(weekdays, start.Weekday()) {
+			if end := start.Add(duration); end.After(now) {
+				return start, end, true
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return time.Time{}, time.Time{}, false
+}
+
+// parseRecurrence returns the weekdays on which a RRULE recurrence occurs.
+func parseRecurrence(recurrence string, anchorWeekday time.Weekday) ([]time.Weekday, bool) {
+	var freq string
+	var weekdays []time.Weekday
+	for _, part := range strings.Split(strings.TrimPrefix(recurrence, "RRULE:"), ";") {
+		key, value, found := strings.Cut(part, "=")
+		if !found {
+			return nil, false
+		}
+		switch key {
+		case "FREQ":
+			freq = value
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := rruleWeekdays[day]
+				if !ok {
+					return nil, false
+				}
+				weekdays = append(weekdays, weekday)
+			}
+		case "INTERVAL":
+			if value != "1" {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+	}
+
+	switch freq {
+	case "DAILY":
+		if len(weekdays) == 0 {
+			weekdays = []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday}
+		}
+	case "WEEKLY":
+		if len(weekdays) == 0 {
+			weekdays = []time.Weekday{anchorWeekday}
+		}
+	default:
+		return nil, false
+	}
+
+	return weekdays, true
+}
+
+// parseMaintenanceWindowDuration parses a RFC 3339 duration such as PT4H0M0S.
+func parseMaintenanceWindowDuration(duration string) time.Duration {
+	d, err := time.ParseDuration(strings.ToLower(strings.TrimPrefix(duration, "PT")))
+	if err != nil || d <= 0 {
+		return defaultDailyMaintenanceWindowDuration
+	}
+
+	return d
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+func TestNextMaintenanceWindow(t *testing.T) {
+	// A Wednesday.
+	now := time.Date(2025, time.March, 5, 10, 0, 0, 0, time.UTC)
+
+	dailyPolicy := func(startTime, duration string) *containerpb.MaintenancePolicy {
+		return &containerpb.MaintenancePolicy{
+			Window: &containerpb.MaintenanceWindow{
+				Policy: &containerpb.MaintenanceWindow_DailyMaintenanceWindow{
+					DailyMaintenanceWindow: &containerpb.DailyMaintenanceWindow{
+						StartTime: startTime,
+						Duration:  duration,
+					},
+				},
+			},
+		}
+	}
+	recurringPolicy := func(start, end time.Time, recurrence string) *containerpb.MaintenancePolicy {
+		return &containerpb.MaintenancePolicy{
+			Window: &containerpb.MaintenanceWindow{
+				Policy: &containerpb.MaintenanceWindow_RecurringWindow{
+					RecurringWindow: &containerpb.RecurringTimeWindow{
+						Window: &containerpb.TimeWindow{
+							StartTime: timestamppb.New(start),
+							EndTime:   timestamppb.New(end),
+						},
+						Recurrence: recurrence,
+					},
+				},
+			},
+		}
+	}
+	occurrence := func(start, end time.Time) *infrav1exp.MaintenanceWindowOccurrence {
+		return &infrav1exp.MaintenanceWindowOccurrence{
+			StartTime: metav1.NewTime(start),
+			EndTime:   metav1.NewTime(end),
+		}
+	}
+
+	tests := []struct {
+		name   string
+		policy *containerpb.MaintenancePolicy
+		now    time.Time
+		want   *infrav1exp.MaintenanceWindowOccurrence
+	}{
+		{
+			name:   "no maintenance policy",
+			policy: nil,
+			now:    now,
+			want:   nil,
+		},
+		{
+			name:   "daily window later today",
+			policy: dailyPolicy("12:00", "PT4H0M0S"),
+			now:    now,
+			want: occurrence(
+				time.Date(2025, time.March, 5, 12, 0, 0, 0, time.UTC),
+				time.Date(2025, time.March, 5, 16, 0, 0, 0, time.UTC),
+			),
+		},
+		{
+			name:   "daily window already passed today defaults duration",
+			policy: dailyPolicy("03:00", ""),
+			now:    now,
+			want: occurrence(
+				time.Date(2025, time.March, 6, 3, 0, 0, 0, time.UTC),
+				time.Date(2025, time.March, 6, 7, 0, 0, 0, time.UTC),
+			),
+		},
+		{
+			name:   "daily window in progress across midnight",
+			policy: dailyPolicy("22:00", "PT4H0M0S"),
+			now:    time.Date(2025, time.March, 5, 1, 0, 0, 0, time.UTC),
+			want: occurrence(
+				time.Date(2025, time.March, 4, 22, 0, 0, 0, time.UTC),
+				time.Date(2025, time.March, 5, 2, 0, 0, 0, time.UTC),
+			),
+		},
+		{
+			name:   "daily window with invalid start time",
+			policy: dailyPolicy("noon", ""),
+			now:    now,
+			want:   nil,
+		},
+		{
+			name: "recurring weekend window",
+			policy: recurringPolicy(
+				time.Date(2025, time.January, 4, 1, 0, 0, 0, time.UTC),
+				time.Date(2025, time.January, 4, 5, 0, 0, 0, time.UTC),
+				"FREQ=WEEKLY;BYDAY=SA,SU",
+			),
+			now: now,
+			want: occurrence(
+				time.Date(2025, time.March, 8, 1, 0, 0, 0, time.UTC),
+				time.Date(2025, time.March, 8, 5, 0, 0, 0, time.UTC),
+			),
+		},
+		{
+			name: "recurring weekly window on the weekday of the start time",
+			policy: recurringPolicy(
+				time.Date(2025, time.January, 5, 1, 0, 0, 0, time.UTC),
+				time.Date(2025, time.January, 5, 5, 0, 0, 0, time.UTC),
+				"FREQ=WEEKLY",
+			),
+			now: now,
+			want: occurrence(
+				time.Date(2025, time.March, 9, 1, 0, 0, 0, time.UTC),
+				time.Date(2025, time.March, 9, 5, 0, 0, 0, time.UTC),
+			),
+		},
+		{
+			name: "recurring daily window in progress",
+			policy: recurringPolicy(
+				time.Date(2025, time.January, 1, 8, 0, 0, 0, time.UTC),
+				time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC),
+				"FREQ=DAILY",
+			),
+			now: now,
+			want: occurrence(
+				time.Date(2025, time.March, 5, 8, 0, 0, 0, time.UTC),
+				time.Date(2025, time.March, 5, 12, 0, 0, 0, time.UTC),
+			),
+		},
+		{
+			name: "recurring window starting in the future",
+			policy: recurringPolicy(
+				time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, time.April, 1, 6, 0, 0, 0, time.UTC),
+				"FREQ=DAILY",
+			),
+			now: now,
+			want: occurrence(
+				time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, time.April, 1, 6, 0, 0, 0, time.UTC),
+			),
+		},
+		{
+			name: "recurring window with unsupported frequency",
+			policy: recurringPolicy(
+				time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, time.January, 1, 6, 0, 0, 0, time.UTC),
+				"FREQ=MONTHLY;BYMONTHDAY=1",
+			),
+			now:  now,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nextMaintenanceWindow(tt.policy, tt.now)
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("nextMaintenanceWindow() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
@@ -31,6 +32,8 @@ import (
 	"github.com/googleapis/gax-go/v2/apierror"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -51,6 +54,14 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEControlPlaneReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
 		return ctrl.Result{}, err
 	}
+	if cluster == nil && s.scope.GCPManagedControlPlane.IsAdopted() {
+		log.Error(ErrAdoptedClusterNotFound, "Cluster to adopt not found", "name", s.scope.ClusterName())
+		s.scope.GCPManagedControlPlane.Status.Initialized = false
+		s.scope.GCPManagedControlPlane.Status.Ready = false
+		conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEControlPlaneReconciliationFailedReason, clusterv1.ConditionSeverityError, ErrAdoptedClusterNotFound.Error())
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneReadyCondition, infrav1exp.GKEControlPlaneReconciliationFailedReason, clusterv1.ConditionSeverityError, ErrAdoptedClusterNotFound.Error())
+		return ctrl.Result{}, ErrAdoptedClusterNotFound
+	}
 	if cluster == nil {
 		log.Info("Cluster not found, creating")
 		s.scope.GCPManagedControlPlane.Status.Initialized = false
@@ -81,6 +92,18 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 			}
 		}
 
+		if s.scope.GCPManagedControlPlane.Spec.ControlPlaneVersion == nil {
+			// The default version is only recorded, so failing to resolve it must not block the creation.
+			if defaultVersion, err := s.resolveDefaultVersion(ctx, &log); err == nil {
+				log.Info("Resolved the default control plane version", "version", defaultVersion)
+				s.scope.GCPManagedControlPlane.Status.DefaultVersion = defaultVersion
+			}
+		}
+
+		if shared.SkipDryRun(ctx, s.scope, "create", "GKE cluster", s.scope.ClusterName()) {
+			return ctrl.Result{}, nil
+		}
+
 		if err = s.createCluster(ctx, &log); err != nil {
 			log.Error(err, "failed creating cluster")
 			conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEControlPlaneReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
@@ -97,6 +120,7 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 
 	log.V(2).Info("gke cluster found", "status", cluster.GetStatus())
 	s.scope.GCPManagedControlPlane.Status.CurrentVersion = convertToSdkMasterVersion(cluster.GetCurrentMasterVersion())
+	s.scope.GCPManagedControlPlane.Status.NextMaintenanceWindow = nextMaintenanceWindow(cluster.GetMaintenancePolicy(), time.Now())
 
 	switch cluster.GetStatus() {
 	case containerpb.Cluster_PROVISIONING:
@@ -109,10 +133,7 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
 	case containerpb.Cluster_RECONCILING:
 		log.Info("Cluster reconciling in progress")
-		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneUpdatingCondition)
-		s.scope.GCPManagedControlPlane.Status.Initialized = true
-		s.scope.GCPManagedControlPlane.Status.Ready = true
-		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+		return s.updateInProgress(), nil
 	case containerpb.Cluster_STOPPING:
 		log.Info("Cluster stopping in progress")
 		conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEControlPlaneDeletingReason, clusterv1.ConditionSeverityInfo, "")
@@ -140,17 +161,24 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 	}
 
 	needUpdate, updateClusterRequest := s.checkDiffAndPrepareUpdate(cluster, &log)
-	if needUpdate {
+	if needUpdate && !shared.SkipDryRun(ctx, s.scope, "update", "GKE cluster", s.scope.ClusterName()) {
 		log.Info("Update required")
 		err = s.updateCluster(ctx, updateClusterRequest, &log)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
 		log.Info("Cluster updating in progress")
-		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneUpdatingCondition)
-		s.scope.GCPManagedControlPlane.Status.Initialized = true
-		s.scope.GCPManagedControlPlane.Status.Ready = true
-		return ctrl.Result{}, nil
+		return s.updateInProgress(), nil
+	}
+
+	if desiredNetworkPolicy := s.checkNetworkPolicyDiff(cluster, &log); desiredNetworkPolicy != nil && !shared.SkipDryRun(ctx, s.scope, "update network policy of", "GKE cluster", s.scope.ClusterName()) {
+		log.Info("Network policy update required")
+		err = s.setNetworkPolicy(ctx, desiredNetworkPolicy, &log)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		log.Info("Cluster updating in progress")
+		return s.updateInProgress(), nil
 	}
 	conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneUpdatingCondition, infrav1exp.GKEControlPlaneUpdatedReason, clusterv1.ConditionSeverityInfo, "")
 
@@ -178,6 +206,15 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 	return ctrl.Result{}, nil
 }
 
+// updateInProgress marks the cluster as updating and requeues, so that the cluster is compared with the spec again
+// once the update completes and the changes made in the meantime are applied.
+func (s *Service) updateInProgress() ctrl.Result {
+	conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneUpdatingCondition)
+	s.scope.GCPManagedControlPlane.Status.Initialized = true
+	s.scope.GCPManagedControlPlane.Status.Ready = true
+	return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}
+}
+
 // Delete delete GKE cluster.
 func (s *Service) Delete(ctx context.Context) (ctrl.Result, error) {
 	log := log.FromContext(ctx).WithValues("service", "container.clusters")
@@ -209,6 +246,10 @@ func (s *Service) Delete(ctx context.Context) (ctrl.Result, error) {
 		break
 	}
 
+	if shared.SkipDryRun(ctx, s.scope, "delete", "GKE cluster", s.scope.ClusterName()) {
+		return ctrl.Result{}, nil
+	}
+
 	if err = s.deleteCluster(ctx, &log); err != nil {
 		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneDeletingCondition, infrav1exp.GKEControlPlaneReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
 		return ctrl.Result{}, err
@@ -243,25 +284,74 @@ func (s *Service) describeCluster(ctx context.Context, log *logr.Logger) (*conta
 }
 
 func (s *Service) createCluster(ctx context.Context, log *logr.Logger) error {
-	nodePools, machinePools, _ := s.scope.GetAllNodePools(ctx)
+	nodePools, machinePools, err := s.scope.GetAllNodePools(ctx)
+	if err != nil {
+		return err
+	}
 
 	log.V(2).Info("Running pre-flight checks on machine pools before cluster creation")
-	if err := shared.ManagedMachinePoolsPreflightCheck(nodePools, machinePools, s.scope.Region()); err != nil {
-		return fmt.Errorf("preflight checks on machine pools before cluster create: %w", err)
+	if s.scope.GCPManagedControlPlane.Spec.AllowPartialNodePoolCreation {
+		var checks []func(*infrav1exp.GCPManagedMachinePool) error
+		if s.scope.GCPManagedControlPlane.IsConfidentialNodesEnabled() {
+			checks = append(checks, shared.ConfidentialNodesPreflightCheck)
+		}
+		if s.usesIPAliases() {
+			checks = append(checks, func(managedPool *infrav1exp.GCPManagedMachinePool) error {
+				return shared.PodRangePreflightCheck(managedPool, s.clusterSubnets())
+			})
+		}
+		result, err := shared.ManagedMachinePoolsPartialPreflightCheck(nodePools, machinePools, s.scope.Region(), checks...)
+		if err != nil {
+			return fmt.Errorf("preflight checks on machine pools before cluster create: %w", err)
+		}
+		if len(result.Warnings) > 0 {
+			warnings := make([]string, len(result.Warnings))
+			for i, warning := range result.Warnings {
+				warnings[i] = warning.Error()
+			}
+			log.Info("Machine pools failing pre-flight checks are not created with the cluster", "warnings", warnings)
+			conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneNodePoolsPreflightCondition, infrav1exp.GKEControlPlaneNodePoolsPreflightFailedReason, clusterv1.ConditionSeverityWarning, "%s", strings.Join(warnings, "; "))
+		} else {
+			conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneNodePoolsPreflightCondition)
+		}
+		nodePools, machinePools = result.ManagedPools, result.MachinePools
+	} else {
+		if err := shared.ManagedMachinePoolsPreflightCheck(nodePools, machinePools, s.scope.Region()); err != nil {
+			return fmt.Errorf("preflight checks on machine pools before cluster create: %w", err)
+		}
+		if s.scope.GCPManagedControlPlane.IsConfidentialNodesEnabled() {
+			for i := range nodePools {
+				if err := shared.ConfidentialNodesPreflightCheck(&nodePools[i]); err != nil {
+					return fmt.Errorf("preflight checks on machine pools before cluster create: %w", err)
+				}
+			}
+		}
+		if s.usesIPAliases() {
+			for i := range nodePools {
+				if err := shared.PodRangePreflightCheck(&nodePools[i], s.clusterSubnets()); err != nil {
+					return fmt.Errorf("preflight checks on machine pools before cluster create: %w", err)
+				}
+			}
+		}
 	}
 
 	isRegional := shared.IsRegional(s.scope.Region())
 	cluster := &containerpb.Cluster{
 		Name:        s.scope.ClusterName(),
 		Description: s.scope.GCPManagedControlPlane.Spec.Description,
-		Network:     *s.scope.GCPManagedCluster.Spec.Network.Name,
-		Subnetwork:  s.getSubnetNameInClusterRegion(),
+		Network:     s.getNetwork(),
+		Subnetwork:  s.getSubnetworkInClusterRegion(),
 		Autopilot: &containerpb.Autopilot{
-			Enabled: s.scope.GCPManagedControlPlane.Spec.EnableAutopilot,
-		},
-		IdentityServiceConfig: &containerpb.IdentityServiceConfig{
-			Enabled: s.scope.GCPManagedControlPlane.Spec.EnableIdentityService,
+			Enabled:              s.scope.GCPManagedControlPlane.Spec.EnableAutopilot,
+			WorkloadPolicyConfig: infrav1exp.ConvertToSdkAutopilotWorkloadPolicy(s.scope.GCPManagedControlPlane.Spec.AutopilotWorkloadPolicy),
 		},
+		IdentityServiceConfig:     convertToSdkIdentityServiceConfig(&s.scope.GCPManagedControlPlane.Spec),
+		ConfidentialNodes:         convertToSdkConfidentialNodes(s.scope.GCPManagedControlPlane.Spec.ConfidentialNodes),
+		AddonsConfig:              infrav1exp.ConvertToSdkAddonsConfig(s.scope.GCPManagedControlPlane.Spec.AddonsConfig),
+		NetworkPolicy:             infrav1exp.ConvertToSdkNetworkPolicy(s.scope.GCPManagedControlPlane.Spec.AddonsConfig),
+		Autoscaling:               infrav1exp.ConvertToSdkClusterAutoscaling(s.scope.GCPManagedControlPlane.Spec.ClusterAutoscaling),
+		ResourceUsageExportConfig: infrav1exp.ConvertToSdkResourceUsageExportConfig(s.scope.GCPManagedControlPlane.Spec.ResourceUsageExportConfig),
+		NodePoolDefaults:          infrav1exp.ConvertToSdkNodePoolDefaults(s.scope.GCPManagedControlPlane.Spec.NodePoolDefaults),
 		ReleaseChannel: &containerpb.ReleaseChannel{
 			Channel: convertToSdkReleaseChannel(s.scope.GCPManagedControlPlane.Spec.ReleaseChannel),
 		},
@@ -290,18 +380,21 @@ func (s *Service) createCluster(ctx context.Context, log *logr.Logger) error {
 					Enabled: true,
 				}
 			}
-			cluster.NetworkConfig.DefaultEnablePrivateNodes = &cn.PrivateCluster.EnablePrivateNodes
+			cluster.NetworkConfig = &containerpb.NetworkConfig{
+				DefaultEnablePrivateNodes: &cn.PrivateCluster.EnablePrivateNodes,
+			}
 
 			cluster.PrivateClusterConfig.MasterIpv4CidrBlock = cn.PrivateCluster.ControlPlaneCidrBlock
 			cluster.ControlPlaneEndpointsConfig.IpEndpointsConfig.GlobalAccess = &cn.PrivateCluster.ControlPlaneGlobalAccess
-
-			cluster.NetworkConfig = &containerpb.NetworkConfig{
-				DefaultSnatStatus: &containerpb.DefaultSnatStatus{
-					Disabled: cn.PrivateCluster.DisableDefaultSNAT,
-				},
-			}
+			cluster.ControlPlaneEndpointsConfig.IpEndpointsConfig.PrivateEndpointSubnetwork = cn.PrivateCluster.PrivateEndpointSubnetwork
 		}
 	}
+	if defaultSnatStatus := convertToSdkDefaultSnatStatus(&s.scope.GCPManagedControlPlane.Spec); defaultSnatStatus != nil {
+		if cluster.NetworkConfig == nil {
+			cluster.NetworkConfig = &containerpb.NetworkConfig{}
+		}
+		cluster.NetworkConfig.DefaultSnatStatus = defaultSnatStatus
+	}
 	if !s.scope.IsAutopilotCluster() {
 		cluster.NodePools = scope.ConvertToSdkNodePools(nodePools, machinePools, isRegional, cluster.GetName())
 		if s.scope.GCPManagedControlPlane.Spec.LoggingService != nil {
@@ -318,7 +411,7 @@ func (s *Service) createCluster(ctx context.Context, log *logr.Logger) error {
 	}
 
 	log.V(2).Info("Creating GKE cluster")
-	_, err := s.scope.ManagedControlPlaneClient().CreateCluster(ctx, createClusterRequest)
+	_, err = s.scope.ManagedControlPlaneClient().CreateCluster(ctx, createClusterRequest)
 	if err != nil {
 		log.Error(err, "Error creating GKE cluster", "name", s.scope.ClusterName())
 		return err
@@ -338,16 +431,43 @@ func (s *Service) createCluster(ctx context.Context, log *logr.Logger) error {
 	return nil
 }
 
-// getSubnetNameInClusterRegion returns the subnet which is in the same region as cluster. If not found it returns empty string.
-func (s *Service) getSubnetNameInClusterRegion() string {
+// getNetwork returns the network of the cluster. A network hosted in another project is
+// referenced by its relative resource name.
+func (s *Service) getNetwork() string {
+	name := ptr.Deref(s.scope.GCPManagedCluster.Spec.Network.Name, "")
+	if s.scope.NetworkProject() == s.scope.GCPManagedControlPlane.Spec.Project {
+		return name
+	}
+
+	return fmt.Sprintf("projects/%s/global/networks/%s", s.scope.NetworkProject(), name)
+}
+
+// getSubnetworkInClusterRegion returns the subnet which is in the same region as cluster. If not found it returns
+// empty string. A subnet hosted in another project is referenced by its relative resource name.
+func (s *Service) getSubnetworkInClusterRegion() string {
 	for _, subnet := range s.scope.GCPManagedCluster.Spec.Network.Subnets {
-		if subnet.Region == s.scope.Region() {
+		if subnet.Region != s.scope.Region() {
+			continue
+		}
+		if s.scope.NetworkProject() == s.scope.GCPManagedControlPlane.Spec.Project {
 			return subnet.Name
 		}
+
+		return fmt.Sprintf("projects/%s/regions/%s/subnetworks/%s", s.scope.NetworkProject(), subnet.Region, subnet.Name)
 	}
 	return ""
 }
 
+// usesIPAliases returns true if the pod IPs of the cluster are alias IPs from secondary ranges of its subnet.
+func (s *Service) usesIPAliases() bool {
+	return s.scope.GCPManagedControlPlane.Spec.ClusterNetwork != nil && s.scope.GCPManagedControlPlane.Spec.ClusterNetwork.UseIPAliases
+}
+
+// clusterSubnets returns the subnets of the cluster network in the cluster region.
+func (s *Service) clusterSubnets() infrav1.Subnets {
+	return s.scope.GCPManagedCluster.Spec.Network.Subnets.FilterByRegion(s.scope.Region())
+}
+
 func (s *Service) updateCluster(ctx context.Context, updateClusterRequest *containerpb.UpdateClusterRequest, log *logr.Logger) error {
 	_, err := s.scope.ManagedControlPlaneClient().UpdateCluster(ctx, updateClusterRequest)
 	if err != nil {
@@ -358,6 +478,20 @@ func (s *Service) updateCluster(ctx context.Context, updateClusterRequest *conta
 	return nil
 }
 
+func (s *Service) setNetworkPolicy(ctx context.Context, networkPolicy *containerpb.NetworkPolicy, log *logr.Logger) error {
+	setNetworkPolicyRequest := &containerpb.SetNetworkPolicyRequest{
+		Name:          s.scope.ClusterFullName(),
+		NetworkPolicy: networkPolicy,
+	}
+	_, err := s.scope.ManagedControlPlaneClient().SetNetworkPolicy(ctx, setNetworkPolicyRequest)
+	if err != nil {
+		log.Error(err, "Error setting GKE cluster network policy", "name", s.scope.ClusterName())
+		return err
+	}
+
+	return nil
+}
+
 func (s *Service) deleteCluster(ctx context.Context, log *logr.Logger) error {
 	deleteClusterRequest := &containerpb.DeleteClusterRequest{
 		Name: s.scope.ClusterFullName(),
@@ -419,64 +553,194 @@ func convertToSdkMasterAuthorizedNetworksConfig(config *infrav1exp.MasterAuthori
 	}
 }
 
+// convertToSdkIdentityServiceConfig converts the identity service configuration defined in CRs to the SDK version.
+func convertToSdkIdentityServiceConfig(spec *infrav1exp.GCPManagedControlPlaneSpec) *containerpb.IdentityServiceConfig {
+	// if config is nil, fall back to the deprecated flag which defaults to the GKE default (disabled).
+	if spec.IdentityServiceConfig == nil {
+		return &containerpb.IdentityServiceConfig{
+			Enabled: spec.EnableIdentityService,
+		}
+	}
+
+	return &containerpb.IdentityServiceConfig{
+		Enabled: spec.IdentityServiceConfig.Enabled,
+	}
+}
+
+// convertToSdkConfidentialNodes converts the ConfidentialNodes defined in CRs to the SDK version.
+func convertToSdkConfidentialNodes(config *infrav1exp.ConfidentialNodes) *containerpb.ConfidentialNodes {
+	// if config is nil, the GKE default (disabled) is used.
+	if config == nil {
+		return nil
+	}
+
+	return &containerpb.ConfidentialNodes{
+		Enabled: config.Enabled,
+	}
+}
+
+// convertToSdkDefaultSnatStatus converts the default SNAT status defined in CRs to the SDK version. The
+// DefaultSnatStatus field takes precedence over the DisableDefaultSNAT field of the private cluster.
+func convertToSdkDefaultSnatStatus(spec *infrav1exp.GCPManagedControlPlaneSpec) *containerpb.DefaultSnatStatus {
+	if spec.DefaultSnatStatus != nil {
+		return &containerpb.DefaultSnatStatus{
+			Disabled: spec.DefaultSnatStatus.Disabled,
+		}
+	}
+	if spec.ClusterNetwork != nil && spec.ClusterNetwork.PrivateCluster != nil {
+		return &containerpb.DefaultSnatStatus{
+			Disabled: spec.ClusterNetwork.PrivateCluster.DisableDefaultSNAT,
+		}
+	}
+	// if not specified, the GKE default (enabled) is used.
+	return nil
+}
+
+// checkDiffAndPrepareUpdate compares the cluster with the spec and returns the request updating the first
+// difference found. GKE applies a single change per update, so the remaining differences are updated by the
+// next reconciliations, once the cluster is running again.
 func (s *Service) checkDiffAndPrepareUpdate(existingCluster *containerpb.Cluster, log *logr.Logger) (bool, *containerpb.UpdateClusterRequest) {
 	log.V(4).Info("Checking diff and preparing update.")
 
-	needUpdate := false
 	clusterUpdate := containerpb.ClusterUpdate{}
+	// An adopted cluster keeps the configuration of the fields which are not specified, instead of the defaults.
+	adopted := s.scope.GCPManagedControlPlane.IsAdopted()
 	// Release channel
 	desiredReleaseChannel := convertToSdkReleaseChannel(s.scope.GCPManagedControlPlane.Spec.ReleaseChannel)
-	if desiredReleaseChannel != existingCluster.GetReleaseChannel().GetChannel() {
+	if (!adopted || s.scope.GCPManagedControlPlane.Spec.ReleaseChannel != nil) && desiredReleaseChannel != existingCluster.GetReleaseChannel().GetChannel() {
 		log.V(2).Info("Release channel update required", "current", existingCluster.GetReleaseChannel().GetChannel(), "desired", desiredReleaseChannel)
-		needUpdate = true
 		clusterUpdate.DesiredReleaseChannel = &containerpb.ReleaseChannel{
 			Channel: desiredReleaseChannel,
 		}
+		return true, s.newUpdateClusterRequest(&clusterUpdate, log)
 	}
-	// Master version
+	// Master version, only when specified. The default version resolved before the creation is not enforced, as
+	// GKE upgrades the control plane along the release channel.
 	if s.scope.GCPManagedControlPlane.Spec.ControlPlaneVersion != nil {
 		desiredMasterVersion := convertToSdkMasterVersion(*s.scope.GCPManagedControlPlane.Spec.ControlPlaneVersion)
 		existingClusterMasterVersion := convertToSdkMasterVersion(existingCluster.GetCurrentMasterVersion())
 		if desiredMasterVersion != existingClusterMasterVersion {
-			needUpdate = true
 			clusterUpdate.DesiredMasterVersion = desiredMasterVersion
 			log.V(2).Info("Master version update required", "current", existingClusterMasterVersion, "desired", desiredMasterVersion)
+			return true, s.newUpdateClusterRequest(&clusterUpdate, log)
 		}
 	}
 
 	// LoggingService
-	if existingCluster.GetLoggingService() != s.scope.GCPManagedControlPlane.Spec.LoggingService.String() {
-		needUpdate = true
+	if (!adopted || s.scope.GCPManagedControlPlane.Spec.LoggingService != nil) && existingCluster.GetLoggingService() != s.scope.GCPManagedControlPlane.Spec.LoggingService.String() {
 		clusterUpdate.DesiredLoggingService = s.scope.GCPManagedControlPlane.Spec.LoggingService.String()
 		log.V(2).Info("LoggingService config update required", "current", existingCluster.GetLoggingService(), "desired", s.scope.GCPManagedControlPlane.Spec.LoggingService.String())
+		return true, s.newUpdateClusterRequest(&clusterUpdate, log)
 	}
 
 	// MonitoringService
-	if existingCluster.GetMonitoringService() != s.scope.GCPManagedControlPlane.Spec.MonitoringService.String() {
-		needUpdate = true
-		clusterUpdate.DesiredLoggingService = s.scope.GCPManagedControlPlane.Spec.MonitoringService.String()
+	if (!adopted || s.scope.GCPManagedControlPlane.Spec.MonitoringService != nil) && existingCluster.GetMonitoringService() != s.scope.GCPManagedControlPlane.Spec.MonitoringService.String() {
+		clusterUpdate.DesiredMonitoringService = s.scope.GCPManagedControlPlane.Spec.MonitoringService.String()
 		log.V(2).Info("MonitoringService config update required", "current", existingCluster.GetMonitoringService(), "desired", s.scope.GCPManagedControlPlane.Spec.MonitoringService.String())
+		return true, s.newUpdateClusterRequest(&clusterUpdate, log)
 	}
 
 	// DesiredMasterAuthorizedNetworksConfig
 	// When desiredMasterAuthorizedNetworksConfig is nil, it means that the user wants to disable the feature.
 	desiredMasterAuthorizedNetworksConfig := convertToSdkMasterAuthorizedNetworksConfig(s.scope.GCPManagedControlPlane.Spec.MasterAuthorizedNetworksConfig)
-	if !compareMasterAuthorizedNetworksConfig(desiredMasterAuthorizedNetworksConfig, existingCluster.GetControlPlaneEndpointsConfig().GetIpEndpointsConfig().GetAuthorizedNetworksConfig()) {
-		needUpdate = true
-		clusterUpdate.DesiredControlPlaneEndpointsConfig.IpEndpointsConfig.AuthorizedNetworksConfig = desiredMasterAuthorizedNetworksConfig
+	if (!adopted || s.scope.GCPManagedControlPlane.Spec.MasterAuthorizedNetworksConfig != nil) && !compareMasterAuthorizedNetworksConfig(desiredMasterAuthorizedNetworksConfig, existingCluster.GetControlPlaneEndpointsConfig().GetIpEndpointsConfig().GetAuthorizedNetworksConfig()) {
+		clusterUpdate.DesiredControlPlaneEndpointsConfig = &containerpb.ControlPlaneEndpointsConfig{
+			IpEndpointsConfig: &containerpb.ControlPlaneEndpointsConfig_IPEndpointsConfig{
+				AuthorizedNetworksConfig: desiredMasterAuthorizedNetworksConfig,
+			},
+		}
 		log.V(2).Info("Master authorized networks config update required", "current", existingCluster.GetControlPlaneEndpointsConfig().GetIpEndpointsConfig().GetAuthorizedNetworksConfig(), "desired", desiredMasterAuthorizedNetworksConfig)
+		return true, s.newUpdateClusterRequest(&clusterUpdate, log)
 	}
 	log.V(4).Info("Master authorized networks config update check", "current", existingCluster.GetControlPlaneEndpointsConfig().GetIpEndpointsConfig().GetAuthorizedNetworksConfig())
 	if desiredMasterAuthorizedNetworksConfig != nil {
 		log.V(4).Info("Master authorized networks config update check", "desired", desiredMasterAuthorizedNetworksConfig)
 	}
 
-	updateClusterRequest := containerpb.UpdateClusterRequest{
+	// IdentityServiceConfig
+	desiredIdentityServiceConfig := convertToSdkIdentityServiceConfig(&s.scope.GCPManagedControlPlane.Spec)
+	identityServiceSpecified := s.scope.GCPManagedControlPlane.Spec.IdentityServiceConfig != nil || s.scope.GCPManagedControlPlane.Spec.EnableIdentityService
+	if (!adopted || identityServiceSpecified) && !compareIdentityServiceConfig(desiredIdentityServiceConfig, existingCluster.GetIdentityServiceConfig()) {
+		clusterUpdate.DesiredIdentityServiceConfig = desiredIdentityServiceConfig
+		log.V(2).Info("Identity service config update required", "current", existingCluster.GetIdentityServiceConfig(), "desired", desiredIdentityServiceConfig)
+		return true, s.newUpdateClusterRequest(&clusterUpdate, log)
+	}
+
+	// AddonsConfig
+	desiredAddonsConfig := infrav1exp.ConvertToSdkAddonsConfig(s.scope.GCPManagedControlPlane.Spec.AddonsConfig)
+	// The NetworkPolicy addon can only be disabled once network policy enforcement is disabled on the cluster,
+	// so keep the current addon state until checkNetworkPolicyDiff has disabled it.
+	if desiredAddonsConfig.GetNetworkPolicyConfig().GetDisabled() && existingCluster.GetNetworkPolicy().GetEnabled() {
+		desiredAddonsConfig.NetworkPolicyConfig = existingCluster.GetAddonsConfig().GetNetworkPolicyConfig()
+	}
+	if !compareAddonsConfig(desiredAddonsConfig, existingCluster.GetAddonsConfig()) {
+		clusterUpdate.DesiredAddonsConfig = desiredAddonsConfig
+		log.V(2).Info("Addons config update required", "current", existingCluster.GetAddonsConfig(), "desired", desiredAddonsConfig)
+		return true, s.newUpdateClusterRequest(&clusterUpdate, log)
+	}
+
+	// ClusterAutoscaling
+	desiredClusterAutoscaling := infrav1exp.ConvertToSdkClusterAutoscaling(s.scope.GCPManagedControlPlane.Spec.ClusterAutoscaling)
+	if desiredClusterAutoscaling != nil && !compareClusterAutoscaling(desiredClusterAutoscaling, existingCluster.GetAutoscaling()) {
+		// The update replaces the whole cluster autoscaling config, keep the fields that are not managed by CAPG.
+		desiredClusterAutoscaling.AutoscalingProfile = existingCluster.GetAutoscaling().GetAutoscalingProfile()
+		desiredClusterAutoscaling.AutoprovisioningLocations = existingCluster.GetAutoscaling().GetAutoprovisioningLocations()
+		clusterUpdate.DesiredClusterAutoscaling = desiredClusterAutoscaling
+		log.V(2).Info("Cluster autoscaling update required", "current", existingCluster.GetAutoscaling(), "desired", desiredClusterAutoscaling)
+		return true, s.newUpdateClusterRequest(&clusterUpdate, log)
+	}
+
+	// ResourceUsageExportConfig
+	desiredResourceUsageExportConfig := infrav1exp.ConvertToSdkResourceUsageExportConfig(s.scope.GCPManagedControlPlane.Spec.ResourceUsageExportConfig)
+	if desiredResourceUsageExportConfig != nil && !compareResourceUsageExportConfig(desiredResourceUsageExportConfig, existingCluster.GetResourceUsageExportConfig()) {
+		clusterUpdate.DesiredResourceUsageExportConfig = desiredResourceUsageExportConfig
+		log.V(2).Info("Resource usage export config update required", "current", existingCluster.GetResourceUsageExportConfig(), "desired", desiredResourceUsageExportConfig)
+		return true, s.newUpdateClusterRequest(&clusterUpdate, log)
+	}
+
+	// AutopilotWorkloadPolicy
+	desiredWorkloadPolicy := infrav1exp.ConvertToSdkAutopilotWorkloadPolicy(s.scope.GCPManagedControlPlane.Spec.AutopilotWorkloadPolicy)
+	if s.scope.IsAutopilotCluster() && desiredWorkloadPolicy != nil && desiredWorkloadPolicy.GetAllowNetAdmin() != existingCluster.GetAutopilot().GetWorkloadPolicyConfig().GetAllowNetAdmin() {
+		clusterUpdate.DesiredAutopilotWorkloadPolicyConfig = desiredWorkloadPolicy
+		log.V(2).Info("Autopilot workload policy update required", "current", existingCluster.GetAutopilot().GetWorkloadPolicyConfig(), "desired", desiredWorkloadPolicy)
+		return true, s.newUpdateClusterRequest(&clusterUpdate, log)
+	}
+
+	// DefaultSnatStatus
+	if desiredDefaultSnatStatus := s.scope.GCPManagedControlPlane.Spec.DefaultSnatStatus; desiredDefaultSnatStatus != nil && desiredDefaultSnatStatus.Disabled != existingCluster.GetNetworkConfig().GetDefaultSnatStatus().GetDisabled() {
+		clusterUpdate.DesiredDefaultSnatStatus = convertToSdkDefaultSnatStatus(&s.scope.GCPManagedControlPlane.Spec)
+		log.V(2).Info("Default SNAT status update required", "current", existingCluster.GetNetworkConfig().GetDefaultSnatStatus(), "desired", clusterUpdate.DesiredDefaultSnatStatus)
+		return true, s.newUpdateClusterRequest(&clusterUpdate, log)
+	}
+
+	// NodePoolDefaults, only the logging variant and the image streaming configuration can be updated.
+	if desiredNodeConfigDefaults := infrav1exp.ConvertToSdkNodePoolDefaults(s.scope.GCPManagedControlPlane.Spec.NodePoolDefaults).GetNodeConfigDefaults(); desiredNodeConfigDefaults != nil {
+		existingNodeConfigDefaults := existingCluster.GetNodePoolDefaults().GetNodeConfigDefaults()
+		desiredLoggingConfig := desiredNodeConfigDefaults.GetLoggingConfig()
+		if desiredLoggingConfig != nil && desiredLoggingConfig.GetVariantConfig().GetVariant() != existingNodeConfigDefaults.GetLoggingConfig().GetVariantConfig().GetVariant() {
+			clusterUpdate.DesiredNodePoolLoggingConfig = desiredLoggingConfig
+			log.V(2).Info("Node pool defaults logging variant update required", "current", existingNodeConfigDefaults.GetLoggingConfig(), "desired", desiredLoggingConfig)
+			return true, s.newUpdateClusterRequest(&clusterUpdate, log)
+		}
+		desiredGcfsConfig := desiredNodeConfigDefaults.GetGcfsConfig()
+		if desiredGcfsConfig != nil && desiredGcfsConfig.GetEnabled() != existingNodeConfigDefaults.GetGcfsConfig().GetEnabled() {
+			clusterUpdate.DesiredGcfsConfig = desiredGcfsConfig
+			log.V(2).Info("Node pool defaults image streaming update required", "current", existingNodeConfigDefaults.GetGcfsConfig(), "desired", desiredGcfsConfig)
+			return true, s.newUpdateClusterRequest(&clusterUpdate, log)
+		}
+	}
+
+	return false, s.newUpdateClusterRequest(&clusterUpdate, log)
+}
+
+// newUpdateClusterRequest returns the request applying the cluster update.
+func (s *Service) newUpdateClusterRequest(clusterUpdate *containerpb.ClusterUpdate, log *logr.Logger) *containerpb.UpdateClusterRequest {
+	updateClusterRequest := &containerpb.UpdateClusterRequest{
 		Name:   s.scope.ClusterFullName(),
-		Update: &clusterUpdate,
+		Update: clusterUpdate,
 	}
-	log.V(4).Info("Update cluster request. ", "needUpdate", needUpdate, "updateClusterRequest", &updateClusterRequest)
-	return needUpdate, &updateClusterRequest
+	log.V(4).Info("Update cluster request. ", "updateClusterRequest", updateClusterRequest)
+	return updateClusterRequest
 }
 
 // compare if two MasterAuthorizedNetworksConfig are equal.
@@ -497,12 +761,117 @@ func compareMasterAuthorizedNetworksConfig(a, b *containerpb.MasterAuthorizedNet
 	if a.GcpPublicCidrsAccessEnabled != nil && b.GcpPublicCidrsAccessEnabled != nil && a.GetGcpPublicCidrsAccessEnabled() != b.GetGcpPublicCidrsAccessEnabled() {
 		return false
 	}
-	// if one cidrBlocks is nil, but the other is empty, they are equal.
-	if (a.CidrBlocks == nil && b.CidrBlocks != nil && len(b.GetCidrBlocks()) == 0) || (b.CidrBlocks == nil && a.CidrBlocks != nil && len(a.GetCidrBlocks()) == 0) {
-		return true
+	// The CIDR blocks are compared regardless of their order and display names, which are only updated along
+	// with the CIDR blocks. A nil list is equal to an empty one.
+	return cmp.Equal(authorizedCIDRBlocks(a), authorizedCIDRBlocks(b), cmpopts.EquateEmpty(), cmpopts.SortSlices(func(x, y string) bool { return x < y }))
+}
+
+// authorizedCIDRBlocks returns the CIDR blocks of a MasterAuthorizedNetworksConfig.
+func authorizedCIDRBlocks(config *containerpb.MasterAuthorizedNetworksConfig) []string {
+	blocks := make([]string, 0, len(config.GetCidrBlocks()))
+	for _, block := range config.GetCidrBlocks() {
+		blocks = append(blocks, block.GetCidrBlock())
+	}
+	return blocks
+}
+
+// compare if two IdentityServiceConfig are equal. A nil config is equivalent to a disabled one.
+func compareIdentityServiceConfig(a, b *containerpb.IdentityServiceConfig) bool {
+	return a.GetEnabled() == b.GetEnabled()
+}
+
+// checkNetworkPolicyDiff returns the network policy to set on the cluster, or nil if no change is required.
+// Enforcement can only be enabled once the NetworkPolicy addon is enabled, so the change is deferred until
+// the addons config has been updated by checkDiffAndPrepareUpdate.
+func (s *Service) checkNetworkPolicyDiff(existingCluster *containerpb.Cluster, log *logr.Logger) *containerpb.NetworkPolicy {
+	desiredNetworkPolicy := infrav1exp.ConvertToSdkNetworkPolicy(s.scope.GCPManagedControlPlane.Spec.AddonsConfig)
+	if desiredNetworkPolicy == nil || compareNetworkPolicy(desiredNetworkPolicy, existingCluster.GetNetworkPolicy()) {
+		return nil
+	}
+	if desiredNetworkPolicy.GetEnabled() && !isNetworkPolicyAddonEnabled(existingCluster.GetAddonsConfig()) {
+		log.V(2).Info("Network policy update waiting for the NetworkPolicy addon to be enabled")
+		return nil
+	}
+
+	log.V(2).Info("Network policy update required", "current", existingCluster.GetNetworkPolicy(), "desired", desiredNetworkPolicy)
+	return desiredNetworkPolicy
+}
+
+// compare if the addons configured in a are equal to those of b. Addons that are not configured in a are ignored.
+func compareAddonsConfig(a, b *containerpb.AddonsConfig) bool {
+	if a.GetNetworkPolicyConfig() != nil && isNetworkPolicyAddonEnabled(a) != isNetworkPolicyAddonEnabled(b) {
+		return false
+	}
+	// The HTTP load balancing addon is enabled by default.
+	if a.GetHttpLoadBalancing() != nil && a.GetHttpLoadBalancing().GetDisabled() != b.GetHttpLoadBalancing().GetDisabled() {
+		return false
 	}
-	if !cmp.Equal(a.GetCidrBlocks(), b.GetCidrBlocks(), cmpopts.IgnoreUnexported(containerpb.MasterAuthorizedNetworksConfig_CidrBlock{})) {
+	// The horizontal pod autoscaling addon is enabled by default.
+	if a.GetHorizontalPodAutoscaling() != nil && a.GetHorizontalPodAutoscaling().GetDisabled() != b.GetHorizontalPodAutoscaling().GetDisabled() {
 		return false
 	}
 	return true
 }
+
+// compare if the cluster autoscaling configured in a is equal to b. The resource limits are compared regardless of
+// their order, and the node pool defaults that are not configured in a are ignored.
+func compareClusterAutoscaling(a, b *containerpb.ClusterAutoscaling) bool {
+	if a.GetEnableNodeAutoprovisioning() != b.GetEnableNodeAutoprovisioning() {
+		return false
+	}
+	if !cmp.Equal(a.GetResourceLimits(), b.GetResourceLimits(),
+		cmpopts.EquateEmpty(),
+		cmpopts.IgnoreUnexported(containerpb.ResourceLimit{}),
+		cmpopts.SortSlices(func(x, y *containerpb.ResourceLimit) bool { return x.GetResourceType() < y.GetResourceType() }),
+	) {
+		return false
+	}
+	return compareAutoprovisioningNodePoolDefaults(a.GetAutoprovisioningNodePoolDefaults(), b.GetAutoprovisioningNodePoolDefaults())
+}
+
+// compare if the node pool defaults configured in a are equal to those of b. Defaults that are not configured in a
+// are ignored.
+func compareAutoprovisioningNodePoolDefaults(a, b *containerpb.AutoprovisioningNodePoolDefaults) bool {
+	if a.GetServiceAccount() != "" && a.GetServiceAccount() != b.GetServiceAccount() {
+		return false
+	}
+	if len(a.GetOauthScopes()) != 0 && !cmp.Equal(a.GetOauthScopes(), b.GetOauthScopes(), cmpopts.SortSlices(func(x, y string) bool { return x < y })) {
+		return false
+	}
+	if a.GetDiskSizeGb() != 0 && a.GetDiskSizeGb() != b.GetDiskSizeGb() {
+		return false
+	}
+	if a.GetDiskType() != "" && a.GetDiskType() != b.GetDiskType() {
+		return false
+	}
+	if a.GetImageType() != "" && a.GetImageType() != b.GetImageType() {
+		return false
+	}
+	if a.GetShieldedInstanceConfig() != nil &&
+		(a.GetShieldedInstanceConfig().GetEnableSecureBoot() != b.GetShieldedInstanceConfig().GetEnableSecureBoot() ||
+			a.GetShieldedInstanceConfig().GetEnableIntegrityMonitoring() != b.GetShieldedInstanceConfig().GetEnableIntegrityMonitoring()) {
+		return false
+	}
+	return true
+}
+
+// compare if two ResourceUsageExportConfig are equal. A nil consumption metering config is equivalent to a
+// disabled one.
+func compareResourceUsageExportConfig(a, b *containerpb.ResourceUsageExportConfig) bool {
+	return a.GetBigqueryDestination().GetDatasetId() == b.GetBigqueryDestination().GetDatasetId() &&
+		a.GetEnableNetworkEgressMetering() == b.GetEnableNetworkEgressMetering() &&
+		a.GetConsumptionMeteringConfig().GetEnabled() == b.GetConsumptionMeteringConfig().GetEnabled()
+}
+
+// compare if two NetworkPolicy are equal. A nil network policy is equivalent to a disabled one.
+func compareNetworkPolicy(a, b *containerpb.NetworkPolicy) bool {
+	if a.GetEnabled() != b.GetEnabled() {
+		return false
+	}
+	return !a.GetEnabled() || a.GetProvider() == b.GetProvider()
+}
+
+// isNetworkPolicyAddonEnabled returns true if the NetworkPolicy addon is enabled. The addon is disabled by default.
+func isNetworkPolicyAddonEnabled(addonsConfig *containerpb.AddonsConfig) bool {
+	return addonsConfig.GetNetworkPolicyConfig() != nil && !addonsConfig.GetNetworkPolicyConfig().GetDisabled()
+}
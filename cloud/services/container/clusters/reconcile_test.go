@@ -0,0 +1,1315 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/testing/protocmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestConvertToSdkIdentityServiceConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		spec *infrav1exp.GCPManagedControlPlaneSpec
+		want *containerpb.IdentityServiceConfig
+	}{
+		{
+			name: "nil config defaults to disabled",
+			spec: &infrav1exp.GCPManagedControlPlaneSpec{},
+			want: &containerpb.IdentityServiceConfig{Enabled: false},
+		},
+		{
+			name: "nil config falls back to the deprecated flag",
+			spec: &infrav1exp.GCPManagedControlPlaneSpec{
+				EnableIdentityService: true,
+			},
+			want: &containerpb.IdentityServiceConfig{Enabled: true},
+		},
+		{
+			name: "enabled config",
+			spec: &infrav1exp.GCPManagedControlPlaneSpec{
+				IdentityServiceConfig: &infrav1exp.IdentityServiceConfig{Enabled: true},
+			},
+			want: &containerpb.IdentityServiceConfig{Enabled: true},
+		},
+		{
+			name: "config takes precedence over the deprecated flag",
+			spec: &infrav1exp.GCPManagedControlPlaneSpec{
+				EnableIdentityService: true,
+				IdentityServiceConfig: &infrav1exp.IdentityServiceConfig{Enabled: false},
+			},
+			want: &containerpb.IdentityServiceConfig{Enabled: false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := convertToSdkIdentityServiceConfig(tt.spec)
+			if d := cmp.Diff(tt.want, got, cmpopts.IgnoreUnexported(containerpb.IdentityServiceConfig{})); d != "" {
+				t.Errorf("convertToSdkIdentityServiceConfig() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestCompareIdentityServiceConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		a    *containerpb.IdentityServiceConfig
+		b    *containerpb.IdentityServiceConfig
+		want bool
+	}{
+		{
+			name: "both nil",
+			want: true,
+		},
+		{
+			name: "disabled and nil (GKE default) are equal",
+			a:    &containerpb.IdentityServiceConfig{Enabled: false},
+			b:    nil,
+			want: true,
+		},
+		{
+			name: "enabled and nil are not equal",
+			a:    &containerpb.IdentityServiceConfig{Enabled: true},
+			b:    nil,
+			want: false,
+		},
+		{
+			name: "enabled and disabled are not equal",
+			a:    &containerpb.IdentityServiceConfig{Enabled: true},
+			b:    &containerpb.IdentityServiceConfig{Enabled: false},
+			want: false,
+		},
+		{
+			name: "both enabled",
+			a:    &containerpb.IdentityServiceConfig{Enabled: true},
+			b:    &containerpb.IdentityServiceConfig{Enabled: true},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareIdentityServiceConfig(tt.a, tt.b); got != tt.want {
+				t.Errorf("compareIdentityServiceConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareMasterAuthorizedNetworksConfig(t *testing.T) {
+	block := func(cidr, name string) *containerpb.MasterAuthorizedNetworksConfig_CidrBlock {
+		return &containerpb.MasterAuthorizedNetworksConfig_CidrBlock{CidrBlock: cidr, DisplayName: name}
+	}
+	tests := []struct {
+		name string
+		a    *containerpb.MasterAuthorizedNetworksConfig
+		b    *containerpb.MasterAuthorizedNetworksConfig
+		want bool
+	}{
+		{
+			name: "both nil",
+			want: true,
+		},
+		{
+			name: "nil and empty CIDR blocks are equal",
+			a:    &containerpb.MasterAuthorizedNetworksConfig{Enabled: true},
+			b:    &containerpb.MasterAuthorizedNetworksConfig{Enabled: true, CidrBlocks: []*containerpb.MasterAuthorizedNetworksConfig_CidrBlock{}},
+			want: true,
+		},
+		{
+			name: "reordered CIDR blocks are equal",
+			a:    &containerpb.MasterAuthorizedNetworksConfig{Enabled: true, CidrBlocks: []*containerpb.MasterAuthorizedNetworksConfig_CidrBlock{block("10.0.0.0/8", "a"), block("192.168.0.0/16", "b")}},
+			b:    &containerpb.MasterAuthorizedNetworksConfig{Enabled: true, CidrBlocks: []*containerpb.MasterAuthorizedNetworksConfig_CidrBlock{block("192.168.0.0/16", "b"), block("10.0.0.0/8", "a")}},
+			want: true,
+		},
+		{
+			name: "display name only differences are ignored",
+			a:    &containerpb.MasterAuthorizedNetworksConfig{Enabled: true, CidrBlocks: []*containerpb.MasterAuthorizedNetworksConfig_CidrBlock{block("10.0.0.0/8", "office")}},
+			b:    &containerpb.MasterAuthorizedNetworksConfig{Enabled: true, CidrBlocks: []*containerpb.MasterAuthorizedNetworksConfig_CidrBlock{block("10.0.0.0/8", "")}},
+			want: true,
+		},
+		{
+			name: "different CIDR blocks are not equal",
+			a:    &containerpb.MasterAuthorizedNetworksConfig{Enabled: true, CidrBlocks: []*containerpb.MasterAuthorizedNetworksConfig_CidrBlock{block("10.0.0.0/8", "a"), block("192.168.0.0/16", "b")}},
+			b:    &containerpb.MasterAuthorizedNetworksConfig{Enabled: true, CidrBlocks: []*containerpb.MasterAuthorizedNetworksConfig_CidrBlock{block("10.0.0.0/8", "a")}},
+			want: false,
+		},
+		{
+			name: "enabled and disabled are not equal",
+			a:    &containerpb.MasterAuthorizedNetworksConfig{Enabled: true},
+			b:    &containerpb.MasterAuthorizedNetworksConfig{Enabled: false},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareMasterAuthorizedNetworksConfig(tt.a, tt.b); got != tt.want {
+				t.Errorf("compareMasterAuthorizedNetworksConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckDiffAndPrepareUpdateMasterAuthorizedNetworksConfig(t *testing.T) {
+	log := logr.Discard()
+	s := newTestService(infrav1exp.GCPManagedControlPlaneSpec{
+		MasterAuthorizedNetworksConfig: &infrav1exp.MasterAuthorizedNetworksConfig{
+			CidrBlocks: []*infrav1exp.MasterAuthorizedNetworksConfigCidrBlock{
+				{CidrBlock: "10.0.0.0/8", DisplayName: "office"},
+				{CidrBlock: "192.168.0.0/16", DisplayName: "vpn"},
+			},
+			GcpPublicCidrsAccessEnabled: ptr.To(false),
+		},
+	})
+	existing := newTestCluster(func(cluster *containerpb.Cluster) {
+		cluster.ControlPlaneEndpointsConfig.IpEndpointsConfig.AuthorizedNetworksConfig = &containerpb.MasterAuthorizedNetworksConfig{
+			Enabled: true,
+			CidrBlocks: []*containerpb.MasterAuthorizedNetworksConfig_CidrBlock{
+				{CidrBlock: "192.168.0.0/16", DisplayName: "vpn"},
+				{CidrBlock: "10.0.0.0/8"},
+			},
+			GcpPublicCidrsAccessEnabled: ptr.To(false),
+		}
+	})
+
+	needUpdate, updateClusterRequest := s.checkDiffAndPrepareUpdate(existing, &log)
+	if needUpdate {
+		t.Errorf("checkDiffAndPrepareUpdate() needUpdate = true, want false: %v", updateClusterRequest)
+	}
+
+	// A changed set of CIDR blocks is updated.
+	existing.ControlPlaneEndpointsConfig.IpEndpointsConfig.AuthorizedNetworksConfig.CidrBlocks = []*containerpb.MasterAuthorizedNetworksConfig_CidrBlock{
+		{CidrBlock: "10.0.0.0/8", DisplayName: "office"},
+	}
+	needUpdate, updateClusterRequest = s.checkDiffAndPrepareUpdate(existing, &log)
+	if !needUpdate {
+		t.Fatalf("checkDiffAndPrepareUpdate() needUpdate = false, want true")
+	}
+	wantClusterUpdate := &containerpb.ClusterUpdate{
+		DesiredControlPlaneEndpointsConfig: &containerpb.ControlPlaneEndpointsConfig{
+			IpEndpointsConfig: &containerpb.ControlPlaneEndpointsConfig_IPEndpointsConfig{
+				AuthorizedNetworksConfig: &containerpb.MasterAuthorizedNetworksConfig{
+					Enabled: true,
+					CidrBlocks: []*containerpb.MasterAuthorizedNetworksConfig_CidrBlock{
+						{CidrBlock: "10.0.0.0/8", DisplayName: "office"},
+						{CidrBlock: "192.168.0.0/16", DisplayName: "vpn"},
+					},
+					GcpPublicCidrsAccessEnabled: ptr.To(false),
+				},
+			},
+		},
+	}
+	if d := cmp.Diff(wantClusterUpdate, updateClusterRequest.GetUpdate(), protocmp.Transform()); d != "" {
+		t.Errorf("checkDiffAndPrepareUpdate() Update mismatch (-want +got):\n%s", d)
+	}
+}
+
+func TestCheckDiffAndPrepareUpdateMonitoringService(t *testing.T) {
+	log := logr.Discard()
+	s := newTestService(infrav1exp.GCPManagedControlPlaneSpec{})
+	existing := newTestCluster(func(cluster *containerpb.Cluster) {
+		cluster.MonitoringService = "none"
+	})
+
+	needUpdate, updateClusterRequest := s.checkDiffAndPrepareUpdate(existing, &log)
+	if !needUpdate {
+		t.Fatalf("checkDiffAndPrepareUpdate() needUpdate = false, want true")
+	}
+	wantClusterUpdate := &containerpb.ClusterUpdate{
+		DesiredMonitoringService: "monitoring.googleapis.com/kubernetes",
+	}
+	if d := cmp.Diff(wantClusterUpdate, updateClusterRequest.GetUpdate(), protocmp.Transform()); d != "" {
+		t.Errorf("checkDiffAndPrepareUpdate() Update mismatch (-want +got):\n%s", d)
+	}
+}
+
+func TestConvertToSdkConfidentialNodes(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *infrav1exp.ConfidentialNodes
+		want   *containerpb.ConfidentialNodes
+	}{
+		{
+			name:   "nil config uses the GKE default",
+			config: nil,
+			want:   nil,
+		},
+		{
+			name:   "enabled config",
+			config: &infrav1exp.ConfidentialNodes{Enabled: true},
+			want:   &containerpb.ConfidentialNodes{Enabled: true},
+		},
+		{
+			name:   "disabled config",
+			config: &infrav1exp.ConfidentialNodes{Enabled: false},
+			want:   &containerpb.ConfidentialNodes{Enabled: false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := convertToSdkConfidentialNodes(tt.config)
+			if d := cmp.Diff(tt.want, got, cmpopts.IgnoreUnexported(containerpb.ConfidentialNodes{})); d != "" {
+				t.Errorf("convertToSdkConfidentialNodes() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+// newTestService returns a Service managing a GKE cluster with the given spec, using the default
+// logging and monitoring services.
+func newTestService(spec infrav1exp.GCPManagedControlPlaneSpec) *Service {
+	spec.LoggingService = ptr.To[infrav1exp.LoggingService]("logging.googleapis.com/kubernetes")
+	spec.MonitoringService = ptr.To[infrav1exp.MonitoringService]("monitoring.googleapis.com/kubernetes")
+	return New(&scope.ManagedControlPlaneScope{
+		GCPManagedControlPlane: &infrav1exp.GCPManagedControlPlane{
+			Spec: spec,
+		},
+	})
+}
+
+// newTestCluster returns an existing GKE cluster matching the default spec, modified by the given function.
+func newTestCluster(modify func(*containerpb.Cluster)) *containerpb.Cluster {
+	cluster := &containerpb.Cluster{
+		LoggingService:    "logging.googleapis.com/kubernetes",
+		MonitoringService: "monitoring.googleapis.com/kubernetes",
+		ControlPlaneEndpointsConfig: &containerpb.ControlPlaneEndpointsConfig{
+			IpEndpointsConfig: &containerpb.ControlPlaneEndpointsConfig_IPEndpointsConfig{
+				AuthorizedNetworksConfig: &containerpb.MasterAuthorizedNetworksConfig{
+					GcpPublicCidrsAccessEnabled: ptr.To(false),
+				},
+			},
+		},
+	}
+	if modify != nil {
+		modify(cluster)
+	}
+	return cluster
+}
+
+func TestCheckDiffAndPrepareUpdateNetworkPolicy(t *testing.T) {
+	networkPolicyAddon := func(enabled bool) *infrav1exp.AddonsConfig {
+		return &infrav1exp.AddonsConfig{
+			NetworkPolicyConfig: &infrav1exp.NetworkPolicyConfig{
+				Enabled:  enabled,
+				Provider: infrav1exp.CalicoNetworkPolicyProvider,
+			},
+		}
+	}
+	existingState := func(addonEnabled, policyEnabled bool) func(*containerpb.Cluster) {
+		return func(cluster *containerpb.Cluster) {
+			cluster.AddonsConfig = &containerpb.AddonsConfig{
+				NetworkPolicyConfig: &containerpb.NetworkPolicyConfig{Disabled: !addonEnabled},
+			}
+			if policyEnabled {
+				cluster.NetworkPolicy = &containerpb.NetworkPolicy{Enabled: true, Provider: containerpb.NetworkPolicy_CALICO}
+			}
+		}
+	}
+
+	tests := []struct {
+		name              string
+		addonsConfig      *infrav1exp.AddonsConfig
+		existing          func(*containerpb.Cluster)
+		wantNeedUpdate    bool
+		wantAddonsConfig  *containerpb.AddonsConfig
+		wantNetworkPolicy *containerpb.NetworkPolicy
+	}{
+		{
+			name:     "addons config not specified",
+			existing: existingState(false, false),
+		},
+		{
+			name:             "enabling on an existing cluster enables the addon first",
+			addonsConfig:     networkPolicyAddon(true),
+			existing:         existingState(false, false),
+			wantNeedUpdate:   true,
+			wantAddonsConfig: &containerpb.AddonsConfig{NetworkPolicyConfig: &containerpb.NetworkPolicyConfig{Disabled: false}},
+		},
+		{
+			name:             "enabling on an existing cluster without addons config enables the addon first",
+			addonsConfig:     networkPolicyAddon(true),
+			existing:         nil,
+			wantNeedUpdate:   true,
+			wantAddonsConfig: &containerpb.AddonsConfig{NetworkPolicyConfig: &containerpb.NetworkPolicyConfig{Disabled: false}},
+		},
+		{
+			name:              "enabling on an existing cluster with the addon enabled sets the network policy",
+			addonsConfig:      networkPolicyAddon(true),
+			existing:          existingState(true, false),
+			wantNetworkPolicy: &containerpb.NetworkPolicy{Enabled: true, Provider: containerpb.NetworkPolicy_CALICO},
+		},
+		{
+			name:         "already enabled",
+			addonsConfig: networkPolicyAddon(true),
+			existing:     existingState(true, true),
+		},
+		{
+			name:              "disabling disables the network policy first",
+			addonsConfig:      networkPolicyAddon(false),
+			existing:          existingState(true, true),
+			wantNetworkPolicy: &containerpb.NetworkPolicy{Enabled: false},
+		},
+		{
+			name:             "disabling with the network policy disabled disables the addon",
+			addonsConfig:     networkPolicyAddon(false),
+			existing:         existingState(true, false),
+			wantNeedUpdate:   true,
+			wantAddonsConfig: &containerpb.AddonsConfig{NetworkPolicyConfig: &containerpb.NetworkPolicyConfig{Disabled: true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := logr.Discard()
+			s := newTestService(infrav1exp.GCPManagedControlPlaneSpec{AddonsConfig: tt.addonsConfig})
+			cluster := newTestCluster(tt.existing)
+
+			needUpdate, updateClusterRequest := s.checkDiffAndPrepareUpdate(cluster, &log)
+			if needUpdate != tt.wantNeedUpdate {
+				t.Errorf("checkDiffAndPrepareUpdate() needUpdate = %v, want %v", needUpdate, tt.wantNeedUpdate)
+			}
+			if d := cmp.Diff(tt.wantAddonsConfig, updateClusterRequest.GetUpdate().GetDesiredAddonsConfig(), protocmp.Transform()); d != "" {
+				t.Errorf("checkDiffAndPrepareUpdate() DesiredAddonsConfig mismatch (-want +got):\n%s", d)
+			}
+			if d := cmp.Diff(tt.wantNetworkPolicy, s.checkNetworkPolicyDiff(cluster, &log), protocmp.Transform()); d != "" {
+				t.Errorf("checkNetworkPolicyDiff() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestCheckDiffAndPrepareUpdateHTTPLoadBalancing(t *testing.T) {
+	tests := []struct {
+		name             string
+		addonsConfig     *infrav1exp.AddonsConfig
+		existing         func(*containerpb.Cluster)
+		wantNeedUpdate   bool
+		wantAddonsConfig *containerpb.AddonsConfig
+	}{
+		{
+			name:         "addon not specified",
+			addonsConfig: &infrav1exp.AddonsConfig{},
+		},
+		{
+			name: "disabling on an existing cluster with the GKE default",
+			addonsConfig: &infrav1exp.AddonsConfig{
+				HTTPLoadBalancing: &infrav1exp.HTTPLoadBalancing{Enabled: false},
+			},
+			wantNeedUpdate:   true,
+			wantAddonsConfig: &containerpb.AddonsConfig{HttpLoadBalancing: &containerpb.HttpLoadBalancing{Disabled: true}},
+		},
+		{
+			name: "disabling on an existing cluster with the addon enabled",
+			addonsConfig: &infrav1exp.AddonsConfig{
+				HTTPLoadBalancing: &infrav1exp.HTTPLoadBalancing{Enabled: false},
+			},
+			existing: func(cluster *containerpb.Cluster) {
+				cluster.AddonsConfig = &containerpb.AddonsConfig{HttpLoadBalancing: &containerpb.HttpLoadBalancing{Disabled: false}}
+			},
+			wantNeedUpdate:   true,
+			wantAddonsConfig: &containerpb.AddonsConfig{HttpLoadBalancing: &containerpb.HttpLoadBalancing{Disabled: true}},
+		},
+		{
+			name: "already disabled",
+			addonsConfig: &infrav1exp.AddonsConfig{
+				HTTPLoadBalancing: &infrav1exp.HTTPLoadBalancing{Enabled: false},
+			},
+			existing: func(cluster *containerpb.Cluster) {
+				cluster.AddonsConfig = &containerpb.AddonsConfig{HttpLoadBalancing: &containerpb.HttpLoadBalancing{Disabled: true}}
+			},
+		},
+		{
+			name: "enabling on an existing cluster with the addon disabled",
+			addonsConfig: &infrav1exp.AddonsConfig{
+				HTTPLoadBalancing: &infrav1exp.HTTPLoadBalancing{Enabled: true},
+			},
+			existing: func(cluster *containerpb.Cluster) {
+				cluster.AddonsConfig = &containerpb.AddonsConfig{HttpLoadBalancing: &containerpb.HttpLoadBalancing{Disabled: true}}
+			},
+			wantNeedUpdate:   true,
+			wantAddonsConfig: &containerpb.AddonsConfig{HttpLoadBalancing: &containerpb.HttpLoadBalancing{Disabled: false}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := logr.Discard()
+			s := newTestService(infrav1exp.GCPManagedControlPlaneSpec{AddonsConfig: tt.addonsConfig})
+
+			needUpdate, updateClusterRequest := s.checkDiffAndPrepareUpdate(newTestCluster(tt.existing), &log)
+			if needUpdate != tt.wantNeedUpdate {
+				t.Errorf("checkDiffAndPrepareUpdate() needUpdate = %v, want %v", needUpdate, tt.wantNeedUpdate)
+			}
+			if d := cmp.Diff(tt.wantAddonsConfig, updateClusterRequest.GetUpdate().GetDesiredAddonsConfig(), protocmp.Transform()); d != "" {
+				t.Errorf("checkDiffAndPrepareUpdate() DesiredAddonsConfig mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestConvertToSdkAddonsConfigHorizontalPodAutoscaling(t *testing.T) {
+	tests := []struct {
+		name         string
+		addonsConfig *infrav1exp.AddonsConfig
+		want         *containerpb.HorizontalPodAutoscaling
+	}{
+		{
+			name:         "addons config not specified uses the GKE default",
+			addonsConfig: nil,
+			want:         nil,
+		},
+		{
+			name:         "addon not specified uses the GKE default",
+			addonsConfig: &infrav1exp.AddonsConfig{},
+			want:         nil,
+		},
+		{
+			name: "addon disabled",
+			addonsConfig: &infrav1exp.AddonsConfig{
+				HorizontalPodAutoscaling: &infrav1exp.HorizontalPodAutoscaling{Enabled: false},
+			},
+			want: &containerpb.HorizontalPodAutoscaling{Disabled: true},
+		},
+		{
+			name: "addon enabled",
+			addonsConfig: &infrav1exp.AddonsConfig{
+				HorizontalPodAutoscaling: &infrav1exp.HorizontalPodAutoscaling{Enabled: true},
+			},
+			want: &containerpb.HorizontalPodAutoscaling{Disabled: false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := infrav1exp.ConvertToSdkAddonsConfig(tt.addonsConfig).GetHorizontalPodAutoscaling()
+			if d := cmp.Diff(tt.want, got, protocmp.Transform()); d != "" {
+				t.Errorf("ConvertToSdkAddonsConfig() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestCheckDiffAndPrepareUpdateHorizontalPodAutoscaling(t *testing.T) {
+	tests := []struct {
+		name             string
+		addonsConfig     *infrav1exp.AddonsConfig
+		existing         func(*containerpb.Cluster)
+		wantNeedUpdate   bool
+		wantAddonsConfig *containerpb.AddonsConfig
+	}{
+		{
+			name:         "addon not specified keeps the existing state",
+			addonsConfig: &infrav1exp.AddonsConfig{},
+			existing: func(cluster *containerpb.Cluster) {
+				cluster.AddonsConfig = &containerpb.AddonsConfig{HorizontalPodAutoscaling: &containerpb.HorizontalPodAutoscaling{Disabled: true}}
+			},
+		},
+		{
+			name: "disabling on an existing cluster",
+			addonsConfig: &infrav1exp.AddonsConfig{
+				HorizontalPodAutoscaling: &infrav1exp.HorizontalPodAutoscaling{Enabled: false},
+			},
+			wantNeedUpdate:   true,
+			wantAddonsConfig: &containerpb.AddonsConfig{HorizontalPodAutoscaling: &containerpb.HorizontalPodAutoscaling{Disabled: true}},
+		},
+		{
+			name: "enabled matches the GKE default",
+			addonsConfig: &infrav1exp.AddonsConfig{
+				HorizontalPodAutoscaling: &infrav1exp.HorizontalPodAutoscaling{Enabled: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := logr.Discard()
+			s := newTestService(infrav1exp.GCPManagedControlPlaneSpec{AddonsConfig: tt.addonsConfig})
+
+			needUpdate, updateClusterRequest := s.checkDiffAndPrepareUpdate(newTestCluster(tt.existing), &log)
+			if needUpdate != tt.wantNeedUpdate {
+				t.Errorf("checkDiffAndPrepareUpdate() needUpdate = %v, want %v", needUpdate, tt.wantNeedUpdate)
+			}
+			if d := cmp.Diff(tt.wantAddonsConfig, updateClusterRequest.GetUpdate().GetDesiredAddonsConfig(), protocmp.Transform()); d != "" {
+				t.Errorf("checkDiffAndPrepareUpdate() DesiredAddonsConfig mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestCheckDiffAndPrepareUpdateClusterAutoscaling(t *testing.T) {
+	cpuAndMemoryLimits := []infrav1exp.ResourceLimit{
+		{ResourceType: "cpu", Minimum: 1, Maximum: 64},
+		{ResourceType: "memory", Minimum: 1, Maximum: 256},
+	}
+	existingAutoscaling := func(cluster *containerpb.Cluster) {
+		cluster.Autoscaling = &containerpb.ClusterAutoscaling{
+			EnableNodeAutoprovisioning: true,
+			// GKE doesn't preserve the order of the resource limits.
+			ResourceLimits: []*containerpb.ResourceLimit{
+				{ResourceType: "memory", Minimum: 1, Maximum: 256},
+				{ResourceType: "cpu", Minimum: 1, Maximum: 64},
+			},
+			AutoscalingProfile: containerpb.ClusterAutoscaling_OPTIMIZE_UTILIZATION,
+			AutoprovisioningNodePoolDefaults: &containerpb.AutoprovisioningNodePoolDefaults{
+				OauthScopes:    []string{"https://www.googleapis.com/auth/cloud-platform"},
+				ServiceAccount: "default",
+				DiskSizeGb:     100,
+			},
+		}
+	}
+
+	tests := []struct {
+		name                   string
+		clusterAutoscaling     *infrav1exp.ClusterAutoscaling
+		existing               func(*containerpb.Cluster)
+		wantNeedUpdate         bool
+		wantClusterAutoscaling *containerpb.ClusterAutoscaling
+	}{
+		{
+			name:     "cluster autoscaling not specified keeps the existing state",
+			existing: existingAutoscaling,
+		},
+		{
+			name: "enabling node auto-provisioning",
+			clusterAutoscaling: &infrav1exp.ClusterAutoscaling{
+				EnableNodeAutoprovisioning: true,
+				ResourceLimits:             cpuAndMemoryLimits,
+			},
+			wantNeedUpdate: true,
+			wantClusterAutoscaling: &containerpb.ClusterAutoscaling{
+				EnableNodeAutoprovisioning: true,
+				ResourceLimits: []*containerpb.ResourceLimit{
+					{ResourceType: "cpu", Minimum: 1, Maximum: 64},
+					{ResourceType: "memory", Minimum: 1, Maximum: 256},
+				},
+			},
+		},
+		{
+			name: "matching limits and unspecified node pool defaults",
+			clusterAutoscaling: &infrav1exp.ClusterAutoscaling{
+				EnableNodeAutoprovisioning: true,
+				ResourceLimits:             cpuAndMemoryLimits,
+				AutoprovisioningNodePoolDefaults: &infrav1exp.AutoprovisioningNodePoolDefaults{
+					DiskSizeGb: ptr.To[int32](100),
+				},
+			},
+			existing: existingAutoscaling,
+		},
+		{
+			name: "adding a GPU resource limit",
+			clusterAutoscaling: &infrav1exp.ClusterAutoscaling{
+				EnableNodeAutoprovisioning: true,
+				ResourceLimits: append(cpuAndMemoryLimits, infrav1exp.ResourceLimit{
+					ResourceType: "nvidia-tesla-t4", Maximum: 4,
+				}),
+			},
+			existing:       existingAutoscaling,
+			wantNeedUpdate: true,
+			wantClusterAutoscaling: &containerpb.ClusterAutoscaling{
+				EnableNodeAutoprovisioning: true,
+				ResourceLimits: []*containerpb.ResourceLimit{
+					{ResourceType: "cpu", Minimum: 1, Maximum: 64},
+					{ResourceType: "memory", Minimum: 1, Maximum: 256},
+					{ResourceType: "nvidia-tesla-t4", Maximum: 4},
+				},
+				AutoscalingProfile: containerpb.ClusterAutoscaling_OPTIMIZE_UTILIZATION,
+			},
+		},
+		{
+			name: "changing a resource limit",
+			clusterAutoscaling: &infrav1exp.ClusterAutoscaling{
+				EnableNodeAutoprovisioning: true,
+				ResourceLimits: []infrav1exp.ResourceLimit{
+					{ResourceType: "cpu", Minimum: 1, Maximum: 128},
+					{ResourceType: "memory", Minimum: 1, Maximum: 256},
+				},
+			},
+			existing:       existingAutoscaling,
+			wantNeedUpdate: true,
+			wantClusterAutoscaling: &containerpb.ClusterAutoscaling{
+				EnableNodeAutoprovisioning: true,
+				ResourceLimits: []*containerpb.ResourceLimit{
+					{ResourceType: "cpu", Minimum: 1, Maximum: 128},
+					{ResourceType: "memory", Minimum: 1, Maximum: 256},
+				},
+				AutoscalingProfile: containerpb.ClusterAutoscaling_OPTIMIZE_UTILIZATION,
+			},
+		},
+		{
+			name: "changing a node pool default",
+			clusterAutoscaling: &infrav1exp.ClusterAutoscaling{
+				EnableNodeAutoprovisioning: true,
+				ResourceLimits:             cpuAndMemoryLimits,
+				AutoprovisioningNodePoolDefaults: &infrav1exp.AutoprovisioningNodePoolDefaults{
+					ServiceAccount: &infrav1exp.ServiceAccountConfig{
+						Email: ptr.To("nodes@my-project.iam.gserviceaccount.com"),
+					},
+					EnableSecureBoot: ptr.To(true),
+				},
+			},
+			existing:       existingAutoscaling,
+			wantNeedUpdate: true,
+			wantClusterAutoscaling: &containerpb.ClusterAutoscaling{
+				EnableNodeAutoprovisioning: true,
+				ResourceLimits: []*containerpb.ResourceLimit{
+					{ResourceType: "cpu", Minimum: 1, Maximum: 64},
+					{ResourceType: "memory", Minimum: 1, Maximum: 256},
+				},
+				AutoscalingProfile: containerpb.ClusterAutoscaling_OPTIMIZE_UTILIZATION,
+				AutoprovisioningNodePoolDefaults: &containerpb.AutoprovisioningNodePoolDefaults{
+					ServiceAccount: "nodes@my-project.iam.gserviceaccount.com",
+					ShieldedInstanceConfig: &containerpb.ShieldedInstanceConfig{
+						EnableSecureBoot: true,
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := logr.Discard()
+			s := newTestService(infrav1exp.GCPManagedControlPlaneSpec{ClusterAutoscaling: tt.clusterAutoscaling})
+
+			needUpdate, updateClusterRequest := s.checkDiffAndPrepareUpdate(newTestCluster(tt.existing), &log)
+			if needUpdate != tt.wantNeedUpdate {
+				t.Errorf("checkDiffAndPrepareUpdate() needUpdate = %v, want %v", needUpdate, tt.wantNeedUpdate)
+			}
+			if d := cmp.Diff(tt.wantClusterAutoscaling, updateClusterRequest.GetUpdate().GetDesiredClusterAutoscaling(), protocmp.Transform()); d != "" {
+				t.Errorf("checkDiffAndPrepareUpdate() DesiredClusterAutoscaling mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestConvertToSdkResourceUsageExportConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *infrav1exp.ResourceUsageExportConfig
+		want   *containerpb.ResourceUsageExportConfig
+	}{
+		{
+			name:   "resource usage export not specified",
+			config: nil,
+			want:   nil,
+		},
+		{
+			name: "resource usage export with network egress and consumption metering",
+			config: &infrav1exp.ResourceUsageExportConfig{
+				BigQueryDatasetID:           "gke_usage_metering",
+				EnableNetworkEgressMetering: true,
+				EnableConsumptionMetering:   true,
+			},
+			want: &containerpb.ResourceUsageExportConfig{
+				BigqueryDestination:         &containerpb.ResourceUsageExportConfig_BigQueryDestination{DatasetId: "gke_usage_metering"},
+				EnableNetworkEgressMetering: true,
+				ConsumptionMeteringConfig:   &containerpb.ResourceUsageExportConfig_ConsumptionMeteringConfig{Enabled: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := infrav1exp.ConvertToSdkResourceUsageExportConfig(tt.config)
+			if d := cmp.Diff(tt.want, got, protocmp.Transform()); d != "" {
+				t.Errorf("ConvertToSdkResourceUsageExportConfig() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestCheckDiffAndPrepareUpdateResourceUsageExportConfig(t *testing.T) {
+	existingExport := func(cluster *containerpb.Cluster) {
+		cluster.ResourceUsageExportConfig = &containerpb.ResourceUsageExportConfig{
+			BigqueryDestination: &containerpb.ResourceUsageExportConfig_BigQueryDestination{DatasetId: "gke_usage_metering"},
+		}
+	}
+
+	tests := []struct {
+		name                          string
+		config                        *infrav1exp.ResourceUsageExportConfig
+		existing                      func(*containerpb.Cluster)
+		wantNeedUpdate                bool
+		wantResourceUsageExportConfig *containerpb.ResourceUsageExportConfig
+	}{
+		{
+			name:     "resource usage export not specified keeps the existing state",
+			existing: existingExport,
+		},
+		{
+			name:     "matching dataset with consumption metering disabled",
+			config:   &infrav1exp.ResourceUsageExportConfig{BigQueryDatasetID: "gke_usage_metering"},
+			existing: existingExport,
+		},
+		{
+			name:           "enabling resource usage export",
+			config:         &infrav1exp.ResourceUsageExportConfig{BigQueryDatasetID: "gke_usage_metering"},
+			wantNeedUpdate: true,
+			wantResourceUsageExportConfig: &containerpb.ResourceUsageExportConfig{
+				BigqueryDestination:       &containerpb.ResourceUsageExportConfig_BigQueryDestination{DatasetId: "gke_usage_metering"},
+				ConsumptionMeteringConfig: &containerpb.ResourceUsageExportConfig_ConsumptionMeteringConfig{},
+			},
+		},
+		{
+			name: "changing the dataset and enabling consumption metering",
+			config: &infrav1exp.ResourceUsageExportConfig{
+				BigQueryDatasetID:         "usage_metering",
+				EnableConsumptionMetering: true,
+			},
+			existing:       existingExport,
+			wantNeedUpdate: true,
+			wantResourceUsageExportConfig: &containerpb.ResourceUsageExportConfig{
+				BigqueryDestination:       &containerpb.ResourceUsageExportConfig_BigQueryDestination{DatasetId: "usage_metering"},
+				ConsumptionMeteringConfig: &containerpb.ResourceUsageExportConfig_ConsumptionMeteringConfig{Enabled: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := logr.Discard()
+			s := newTestService(infrav1exp.GCPManagedControlPlaneSpec{ResourceUsageExportConfig: tt.config})
+
+			needUpdate, updateClusterRequest := s.checkDiffAndPrepareUpdate(newTestCluster(tt.existing), &log)
+			if needUpdate != tt.wantNeedUpdate {
+				t.Errorf("checkDiffAndPrepareUpdate() needUpdate = %v, want %v", needUpdate, tt.wantNeedUpdate)
+			}
+			if d := cmp.Diff(tt.wantResourceUsageExportConfig, updateClusterRequest.GetUpdate().GetDesiredResourceUsageExportConfig(), protocmp.Transform()); d != "" {
+				t.Errorf("checkDiffAndPrepareUpdate() DesiredResourceUsageExportConfig mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestConvertToSdkNodePoolDefaults(t *testing.T) {
+	maxThroughput := infrav1exp.MaxThroughputLoggingVariant
+
+	tests := []struct {
+		name     string
+		defaults *infrav1exp.NodePoolDefaults
+		want     *containerpb.NodePoolDefaults
+	}{
+		{
+			name:     "node pool defaults not specified",
+			defaults: nil,
+			want:     nil,
+		},
+		{
+			name:     "node config defaults not specified",
+			defaults: &infrav1exp.NodePoolDefaults{},
+			want:     &containerpb.NodePoolDefaults{},
+		},
+		{
+			name: "node config defaults with logging variant and image streaming",
+			defaults: &infrav1exp.NodePoolDefaults{
+				NodeConfigDefaults: &infrav1exp.NodeConfigDefaults{
+					LoggingVariant: &maxThroughput,
+					GcfsConfig:     &infrav1exp.GcfsConfig{Enabled: true},
+				},
+			},
+			want: &containerpb.NodePoolDefaults{
+				NodeConfigDefaults: &containerpb.NodeConfigDefaults{
+					LoggingConfig: &containerpb.NodePoolLoggingConfig{
+						VariantConfig: &containerpb.LoggingVariantConfig{Variant: containerpb.LoggingVariantConfig_MAX_THROUGHPUT},
+					},
+					GcfsConfig: &containerpb.GcfsConfig{Enabled: true},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := infrav1exp.ConvertToSdkNodePoolDefaults(tt.defaults)
+			if d := cmp.Diff(tt.want, got, protocmp.Transform()); d != "" {
+				t.Errorf("ConvertToSdkNodePoolDefaults() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestCheckDiffAndPrepareUpdateNodePoolDefaults(t *testing.T) {
+	defaultVariant := infrav1exp.DefaultLoggingVariant
+	maxThroughput := infrav1exp.MaxThroughputLoggingVariant
+	existingDefaults := func(cluster *containerpb.Cluster) {
+		cluster.NodePoolDefaults = &containerpb.NodePoolDefaults{
+			NodeConfigDefaults: &containerpb.NodeConfigDefaults{
+				LoggingConfig: &containerpb.NodePoolLoggingConfig{
+					VariantConfig: &containerpb.LoggingVariantConfig{Variant: containerpb.LoggingVariantConfig_DEFAULT},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name               string
+		defaults           *infrav1exp.NodePoolDefaults
+		existing           func(*containerpb.Cluster)
+		wantNeedUpdate     bool
+		wantLoggingVariant containerpb.LoggingVariantConfig_Variant
+		wantGcfsConfig     *containerpb.GcfsConfig
+	}{
+		{
+			name:     "node pool defaults not specified keeps the existing state",
+			existing: existingDefaults,
+		},
+		{
+			name: "matching logging variant",
+			defaults: &infrav1exp.NodePoolDefaults{
+				NodeConfigDefaults: &infrav1exp.NodeConfigDefaults{LoggingVariant: &defaultVariant},
+			},
+			existing: existingDefaults,
+		},
+		{
+			name: "changing the logging variant",
+			defaults: &infrav1exp.NodePoolDefaults{
+				NodeConfigDefaults: &infrav1exp.NodeConfigDefaults{LoggingVariant: &maxThroughput},
+			},
+			existing:           existingDefaults,
+			wantNeedUpdate:     true,
+			wantLoggingVariant: containerpb.LoggingVariantConfig_MAX_THROUGHPUT,
+		},
+		{
+			name: "enabling image streaming",
+			defaults: &infrav1exp.NodePoolDefaults{
+				NodeConfigDefaults: &infrav1exp.NodeConfigDefaults{GcfsConfig: &infrav1exp.GcfsConfig{Enabled: true}},
+			},
+			existing:       existingDefaults,
+			wantNeedUpdate: true,
+			wantGcfsConfig: &containerpb.GcfsConfig{Enabled: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := logr.Discard()
+			s := newTestService(infrav1exp.GCPManagedControlPlaneSpec{NodePoolDefaults: tt.defaults})
+
+			needUpdate, updateClusterRequest := s.checkDiffAndPrepareUpdate(newTestCluster(tt.existing), &log)
+			if needUpdate != tt.wantNeedUpdate {
+				t.Errorf("checkDiffAndPrepareUpdate() needUpdate = %v, want %v", needUpdate, tt.wantNeedUpdate)
+			}
+			if got := updateClusterRequest.GetUpdate().GetDesiredNodePoolLoggingConfig().GetVariantConfig().GetVariant(); got != tt.wantLoggingVariant {
+				t.Errorf("checkDiffAndPrepareUpdate() DesiredNodePoolLoggingConfig variant = %v, want %v", got, tt.wantLoggingVariant)
+			}
+			if d := cmp.Diff(tt.wantGcfsConfig, updateClusterRequest.GetUpdate().GetDesiredGcfsConfig(), protocmp.Transform()); d != "" {
+				t.Errorf("checkDiffAndPrepareUpdate() DesiredGcfsConfig mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestConvertToSdkDefaultSnatStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		spec *infrav1exp.GCPManagedControlPlaneSpec
+		want *containerpb.DefaultSnatStatus
+	}{
+		{
+			name: "default SNAT status not specified",
+			spec: &infrav1exp.GCPManagedControlPlaneSpec{},
+			want: nil,
+		},
+		{
+			name: "default SNAT disabled",
+			spec: &infrav1exp.GCPManagedControlPlaneSpec{
+				DefaultSnatStatus: &infrav1exp.DefaultSnatStatus{Disabled: true},
+			},
+			want: &containerpb.DefaultSnatStatus{Disabled: true},
+		},
+		{
+			name: "default SNAT disabled on the private cluster",
+			spec: &infrav1exp.GCPManagedControlPlaneSpec{
+				ClusterNetwork: &infrav1exp.ClusterNetwork{
+					PrivateCluster: &infrav1exp.PrivateCluster{DisableDefaultSNAT: true},
+				},
+			},
+			want: &containerpb.DefaultSnatStatus{Disabled: true},
+		},
+		{
+			name: "default SNAT status takes precedence over the private cluster",
+			spec: &infrav1exp.GCPManagedControlPlaneSpec{
+				ClusterNetwork: &infrav1exp.ClusterNetwork{
+					PrivateCluster: &infrav1exp.PrivateCluster{DisableDefaultSNAT: true},
+				},
+				DefaultSnatStatus: &infrav1exp.DefaultSnatStatus{Disabled: false},
+			},
+			want: &containerpb.DefaultSnatStatus{Disabled: false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := convertToSdkDefaultSnatStatus(tt.spec)
+			if d := cmp.Diff(tt.want, got, protocmp.Transform()); d != "" {
+				t.Errorf("convertToSdkDefaultSnatStatus() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestCheckDiffAndPrepareUpdateDefaultSnatStatus(t *testing.T) {
+	existingSnatStatus := func(disabled bool) func(*containerpb.Cluster) {
+		return func(cluster *containerpb.Cluster) {
+			cluster.NetworkConfig = &containerpb.NetworkConfig{
+				DefaultSnatStatus: &containerpb.DefaultSnatStatus{Disabled: disabled},
+			}
+		}
+	}
+
+	tests := []struct {
+		name                  string
+		defaultSnatStatus     *infrav1exp.DefaultSnatStatus
+		existing              func(*containerpb.Cluster)
+		wantNeedUpdate        bool
+		wantDefaultSnatStatus *containerpb.DefaultSnatStatus
+	}{
+		{
+			name:     "default SNAT status not specified keeps the existing state",
+			existing: existingSnatStatus(true),
+		},
+		{
+			name:              "matching default SNAT status",
+			defaultSnatStatus: &infrav1exp.DefaultSnatStatus{Disabled: true},
+			existing:          existingSnatStatus(true),
+		},
+		{
+			name:                  "disabling the default SNAT",
+			defaultSnatStatus:     &infrav1exp.DefaultSnatStatus{Disabled: true},
+			existing:              existingSnatStatus(false),
+			wantNeedUpdate:        true,
+			wantDefaultSnatStatus: &containerpb.DefaultSnatStatus{Disabled: true},
+		},
+		{
+			name:                  "enabling the default SNAT",
+			defaultSnatStatus:     &infrav1exp.DefaultSnatStatus{Disabled: false},
+			existing:              existingSnatStatus(true),
+			wantNeedUpdate:        true,
+			wantDefaultSnatStatus: &containerpb.DefaultSnatStatus{Disabled: false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := logr.Discard()
+			s := newTestService(infrav1exp.GCPManagedControlPlaneSpec{DefaultSnatStatus: tt.defaultSnatStatus})
+
+			needUpdate, updateClusterRequest := s.checkDiffAndPrepareUpdate(newTestCluster(tt.existing), &log)
+			if needUpdate != tt.wantNeedUpdate {
+				t.Errorf("checkDiffAndPrepareUpdate() needUpdate = %v, want %v", needUpdate, tt.wantNeedUpdate)
+			}
+			if d := cmp.Diff(tt.wantDefaultSnatStatus, updateClusterRequest.GetUpdate().GetDesiredDefaultSnatStatus(), protocmp.Transform()); d != "" {
+				t.Errorf("checkDiffAndPrepareUpdate() DesiredDefaultSnatStatus mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestConvertToSdkAutopilotWorkloadPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *infrav1exp.AutopilotWorkloadPolicy
+		want   *containerpb.WorkloadPolicyConfig
+	}{
+		{
+			name:   "workload policy not specified",
+			policy: nil,
+			want:   nil,
+		},
+		{
+			name:   "workload policy allowing NET_ADMIN",
+			policy: &infrav1exp.AutopilotWorkloadPolicy{AllowNetAdmin: true},
+			want:   &containerpb.WorkloadPolicyConfig{AllowNetAdmin: ptr.To(true)},
+		},
+		{
+			name:   "workload policy disallowing NET_ADMIN",
+			policy: &infrav1exp.AutopilotWorkloadPolicy{},
+			want:   &containerpb.WorkloadPolicyConfig{AllowNetAdmin: ptr.To(false)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := infrav1exp.ConvertToSdkAutopilotWorkloadPolicy(tt.policy)
+			if d := cmp.Diff(tt.want, got, protocmp.Transform()); d != "" {
+				t.Errorf("ConvertToSdkAutopilotWorkloadPolicy() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestCheckDiffAndPrepareUpdateAutopilotWorkloadPolicy(t *testing.T) {
+	existingAutopilot := func(cluster *containerpb.Cluster) {
+		cluster.Autopilot = &containerpb.Autopilot{
+			Enabled:              true,
+			WorkloadPolicyConfig: &containerpb.WorkloadPolicyConfig{AllowNetAdmin: ptr.To(false)},
+		}
+	}
+
+	tests := []struct {
+		name               string
+		policy             *infrav1exp.AutopilotWorkloadPolicy
+		wantNeedUpdate     bool
+		wantWorkloadPolicy *containerpb.WorkloadPolicyConfig
+	}{
+		{
+			name: "workload policy not specified keeps the existing policy",
+		},
+		{
+			name:   "matching workload policy",
+			policy: &infrav1exp.AutopilotWorkloadPolicy{AllowNetAdmin: false},
+		},
+		{
+			name:               "allowing NET_ADMIN",
+			policy:             &infrav1exp.AutopilotWorkloadPolicy{AllowNetAdmin: true},
+			wantNeedUpdate:     true,
+			wantWorkloadPolicy: &containerpb.WorkloadPolicyConfig{AllowNetAdmin: ptr.To(true)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := logr.Discard()
+			s := newTestService(infrav1exp.GCPManagedControlPlaneSpec{
+				EnableAutopilot:         true,
+				AutopilotWorkloadPolicy: tt.policy,
+			})
+
+			needUpdate, updateClusterRequest := s.checkDiffAndPrepareUpdate(newTestCluster(existingAutopilot), &log)
+			if needUpdate != tt.wantNeedUpdate {
+				t.Errorf("checkDiffAndPrepareUpdate() needUpdate = %v, want %v", needUpdate, tt.wantNeedUpdate)
+			}
+			if d := cmp.Diff(tt.wantWorkloadPolicy, updateClusterRequest.GetUpdate().GetDesiredAutopilotWorkloadPolicyConfig(), protocmp.Transform()); d != "" {
+				t.Errorf("checkDiffAndPrepareUpdate() DesiredAutopilotWorkloadPolicyConfig mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestSpecChangeDuringReconcilingIsApplied(t *testing.T) {
+	fake := &fakeClusterManager{
+		cluster: newTestCluster(func(cluster *containerpb.Cluster) {
+			cluster.Status = containerpb.Cluster_RECONCILING
+		}),
+	}
+	s := newFakeClusterManagerService(t, fake, infrav1exp.GCPManagedControlPlaneSpec{
+		LoggingService:    ptr.To[infrav1exp.LoggingService]("logging.googleapis.com/kubernetes"),
+		MonitoringService: ptr.To[infrav1exp.MonitoringService]("monitoring.googleapis.com/kubernetes"),
+		AddonsConfig: &infrav1exp.AddonsConfig{
+			HorizontalPodAutoscaling: &infrav1exp.HorizontalPodAutoscaling{Enabled: false},
+		},
+	})
+	ctx := context.Background()
+
+	// The change is not applied while the cluster is reconciling, but a requeue is scheduled to apply it.
+	result, err := s.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter == 0 {
+		t.Errorf("Reconcile() RequeueAfter = 0, want a requeue")
+	}
+	if !conditions.IsTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneUpdatingCondition) {
+		t.Errorf("Reconcile() did not mark the cluster as updating")
+	}
+	if len(fake.updateClusterRequests) != 0 {
+		t.Fatalf("Reconcile() sent %d update requests while the cluster is reconciling, want none", len(fake.updateClusterRequests))
+	}
+
+	// Once the cluster is running again, the change is applied.
+	fake.setCluster(newTestCluster(func(cluster *containerpb.Cluster) {
+		cluster.Status = containerpb.Cluster_RUNNING
+	}))
+	result, err = s.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter == 0 {
+		t.Errorf("Reconcile() RequeueAfter = 0, want a requeue")
+	}
+	if len(fake.updateClusterRequests) != 1 {
+		t.Fatalf("Reconcile() sent %d update requests, want 1", len(fake.updateClusterRequests))
+	}
+	want := &containerpb.AddonsConfig{HorizontalPodAutoscaling: &containerpb.HorizontalPodAutoscaling{Disabled: true}}
+	if d := cmp.Diff(want, fake.updateClusterRequests[0].GetUpdate().GetDesiredAddonsConfig(), protocmp.Transform()); d != "" {
+		t.Errorf("Reconcile() DesiredAddonsConfig mismatch (-want +got):\n%s", d)
+	}
+}
+
+func TestCheckDiffAndPrepareUpdateAdoptedCluster(t *testing.T) {
+	// An existing cluster whose configuration differs from the defaults of every field which is not specified.
+	existing := newTestCluster(func(cluster *containerpb.Cluster) {
+		cluster.ReleaseChannel = &containerpb.ReleaseChannel{Channel: containerpb.ReleaseChannel_STABLE}
+		cluster.LoggingService = "none"
+		cluster.MonitoringService = "none"
+		cluster.CurrentMasterVersion = "1.30.5-gke.1014001"
+		cluster.ControlPlaneEndpointsConfig.IpEndpointsConfig.AuthorizedNetworksConfig = &containerpb.MasterAuthorizedNetworksConfig{
+			Enabled:    true,
+			CidrBlocks: []*containerpb.MasterAuthorizedNetworksConfig_CidrBlock{{CidrBlock: "10.0.0.0/8"}},
+		}
+		cluster.IdentityServiceConfig = &containerpb.IdentityServiceConfig{Enabled: true}
+		cluster.AddonsConfig = &containerpb.AddonsConfig{HttpLoadBalancing: &containerpb.HttpLoadBalancing{Disabled: true}}
+	})
+
+	tests := []struct {
+		name              string
+		spec              infrav1exp.GCPManagedControlPlaneSpec
+		wantNeedUpdate    bool
+		wantClusterUpdate *containerpb.ClusterUpdate
+	}{
+		{
+			name: "unspecified fields are not changed",
+		},
+		{
+			name: "specified fields are reconciled",
+			spec: infrav1exp.GCPManagedControlPlaneSpec{
+				ReleaseChannel:      ptr.To(infrav1exp.Regular),
+				ControlPlaneVersion: ptr.To("1.30.5"),
+			},
+			wantNeedUpdate: true,
+			wantClusterUpdate: &containerpb.ClusterUpdate{
+				DesiredReleaseChannel: &containerpb.ReleaseChannel{Channel: containerpb.ReleaseChannel_REGULAR},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := logr.Discard()
+			s := New(&scope.ManagedControlPlaneScope{
+				GCPManagedControlPlane: &infrav1exp.GCPManagedControlPlane{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{infrav1exp.AdoptAnnotation: "true"},
+					},
+					Spec: tt.spec,
+				},
+			})
+
+			needUpdate, updateClusterRequest := s.checkDiffAndPrepareUpdate(existing, &log)
+			if needUpdate != tt.wantNeedUpdate {
+				t.Errorf("checkDiffAndPrepareUpdate() needUpdate = %v, want %v: %v", needUpdate, tt.wantNeedUpdate, updateClusterRequest)
+			}
+			if !tt.wantNeedUpdate {
+				return
+			}
+			if d := cmp.Diff(tt.wantClusterUpdate, updateClusterRequest.GetUpdate(), protocmp.Transform()); d != "" {
+				t.Errorf("checkDiffAndPrepareUpdate() Update mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestCheckDiffAndPrepareUpdateOneChangePerUpdate(t *testing.T) {
+	log := logr.Discard()
+	maxThroughput := infrav1exp.MaxThroughputLoggingVariant
+	s := newTestService(infrav1exp.GCPManagedControlPlaneSpec{
+		NodePoolDefaults: &infrav1exp.NodePoolDefaults{
+			NodeConfigDefaults: &infrav1exp.NodeConfigDefaults{
+				LoggingVariant: &maxThroughput,
+				GcfsConfig:     &infrav1exp.GcfsConfig{Enabled: true},
+			},
+		},
+	})
+	existing := newTestCluster(func(cluster *containerpb.Cluster) {
+		cluster.NodePoolDefaults = &containerpb.NodePoolDefaults{
+			NodeConfigDefaults: &containerpb.NodeConfigDefaults{},
+		}
+	})
+
+	// Both the logging variant and the image streaming differ, only the first one is updated.
+	needUpdate, updateClusterRequest := s.checkDiffAndPrepareUpdate(existing, &log)
+	if !needUpdate {
+		t.Fatalf("checkDiffAndPrepareUpdate() needUpdate = false, want true")
+	}
+	wantClusterUpdate := &containerpb.ClusterUpdate{
+		DesiredNodePoolLoggingConfig: &containerpb.NodePoolLoggingConfig{
+			VariantConfig: &containerpb.LoggingVariantConfig{Variant: containerpb.LoggingVariantConfig_MAX_THROUGHPUT},
+		},
+	}
+	if d := cmp.Diff(wantClusterUpdate, updateClusterRequest.GetUpdate(), protocmp.Transform()); d != "" {
+		t.Fatalf("checkDiffAndPrepareUpdate() first Update mismatch (-want +got):\n%s", d)
+	}
+
+	// The remaining difference is updated by the next reconciliation.
+	existing.NodePoolDefaults.NodeConfigDefaults.LoggingConfig = updateClusterRequest.GetUpdate().GetDesiredNodePoolLoggingConfig()
+	needUpdate, updateClusterRequest = s.checkDiffAndPrepareUpdate(existing, &log)
+	if !needUpdate {
+		t.Fatalf("checkDiffAndPrepareUpdate() needUpdate = false, want true")
+	}
+	wantClusterUpdate = &containerpb.ClusterUpdate{
+		DesiredGcfsConfig: &containerpb.GcfsConfig{Enabled: true},
+	}
+	if d := cmp.Diff(wantClusterUpdate, updateClusterRequest.GetUpdate(), protocmp.Transform()); d != "" {
+		t.Fatalf("checkDiffAndPrepareUpdate() second Update mismatch (-want +got):\n%s", d)
+	}
+
+	existing.NodePoolDefaults.NodeConfigDefaults.GcfsConfig = updateClusterRequest.GetUpdate().GetDesiredGcfsConfig()
+	if needUpdate, updateClusterRequest = s.checkDiffAndPrepareUpdate(existing, &log); needUpdate {
+		t.Errorf("checkDiffAndPrepareUpdate() needUpdate = true, want false: %v", updateClusterRequest)
+	}
+}
+
+func TestGetNetworkAndSubnetwork(t *testing.T) {
+	tests := []struct {
+		name           string
+		hostProject    *string
+		wantNetwork    string
+		wantSubnetwork string
+	}{
+		{
+			name:           "network in the cluster project",
+			wantNetwork:    "my-network",
+			wantSubnetwork: "my-subnet",
+		},
+		{
+			name:           "network in a host project",
+			hostProject:    ptr.To("my-host-proj"),
+			wantNetwork:    "projects/my-host-proj/global/networks/my-network",
+			wantSubnetwork: "projects/my-host-proj/regions/us-central1/subnetworks/my-subnet",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				scope: &scope.ManagedControlPlaneScope{
+					GCPManagedCluster: &infrav1exp.GCPManagedCluster{
+						Spec: infrav1exp.GCPManagedClusterSpec{
+							Network: infrav1.NetworkSpec{
+								Name:        ptr.To("my-network"),
+								HostProject: tt.hostProject,
+								Subnets: infrav1.Subnets{
+									{Name: "other-subnet", Region: "europe-west1"},
+									{Name: "my-subnet", Region: "us-central1"},
+								},
+							},
+						},
+					},
+					GCPManagedControlPlane: &infrav1exp.GCPManagedControlPlane{
+						Spec: infrav1exp.GCPManagedControlPlaneSpec{
+							Project:  "my-proj",
+							Location: "us-central1",
+						},
+					},
+				},
+			}
+			if got := s.getNetwork(); got != tt.wantNetwork {
+				t.Errorf("getNetwork() = %q, want %q", got, tt.wantNetwork)
+			}
+			if got := s.getSubnetworkInClusterRegion(); got != tt.wantSubnetwork {
+				t.Errorf("getSubnetworkInClusterRegion() = %q, want %q", got, tt.wantSubnetwork)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/go-logr/logr"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+// resolveDefaultVersion returns the control plane version GKE deploys by default in the release channel of
+// the cluster, as reported by the server config of the cluster location.
+func (s *Service) resolveDefaultVersion(ctx context.Context, log *logr.Logger) (string, error) {
+	getServerConfigRequest := &containerpb.GetServerConfigRequest{
+		Name: s.scope.ClusterLocation(),
+	}
+	serverConfig, err := s.scope.ManagedControlPlaneClient().GetServerConfig(ctx, getServerConfigRequest)
+	if err != nil {
+		log.Error(err, "Error getting GKE server config", "location", s.scope.ClusterLocation())
+		return "", err
+	}
+
+	return defaultVersion(serverConfig, s.scope.GCPManagedControlPlane.Spec.ReleaseChannel), nil
+}
+
+// defaultVersion returns the default control plane version of the release channel in the server config, or the
+// default version of the location when no release channel is specified. An empty version is returned when the
+// release channel is not listed, as GKE does not deploy the default version of the location in that channel.
+// The GKE patch suffix is trimmed, as for the current version of the cluster.
+func defaultVersion(serverConfig *containerpb.ServerConfig, releaseChannel *infrav1exp.ReleaseChannel) string {
+	version := serverConfig.GetDefaultClusterVersion()
+	if channel := convertToSdkReleaseChannel(releaseChannel); channel != containerpb.ReleaseChannel_UNSPECIFIED {
+		version = ""
+		for _, channelConfig := range serverConfig.GetChannels() {
+			if channelConfig.GetChannel() == channel {
+				version = channelConfig.GetDefaultVersion()
+				break
+			}
+		}
+	}
+	if version == "" {
+		return ""
+	}
+
+	return convertToSdkMasterVersion(version)
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/utils/ptr"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+func TestDefaultVersion(t *testing.T) {
+	serverConfig := &containerpb.ServerConfig{
+		DefaultClusterVersion: "1.31.5-gke.1023000",
+		Channels: []*containerpb.ServerConfig_ReleaseChannelConfig{
+			{
+				Channel:        containerpb.ReleaseChannel_RAPID,
+				DefaultVersion: "1.32.2-gke.1182000",
+			},
+			{
+				Channel:        containerpb.ReleaseChannel_REGULAR,
+				DefaultVersion: "1.31.5-gke.1023000",
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		serverConfig   *containerpb.ServerConfig
+		releaseChannel *infrav1exp.ReleaseChannel
+		want           string
+	}{
+		{
+			name:         "no release channel uses the default version of the location",
+			serverConfig: serverConfig,
+			want:         "1.31.5",
+		},
+		{
+			name:           "default version of the release channel",
+			serverConfig:   serverConfig,
+			releaseChannel: ptr.To(infrav1exp.Rapid),
+			want:           "1.32.2",
+		},
+		{
+			name:           "release channel not listed in the server config",
+			serverConfig:   serverConfig,
+			releaseChannel: ptr.To(infrav1exp.Stable),
+			want:           "",
+		},
+		{
+			name:         "empty server config",
+			serverConfig: &containerpb.ServerConfig{},
+			want:         "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := defaultVersion(tt.serverConfig, tt.releaseChannel); got != tt.want {
+				t.Errorf("defaultVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileRecordsDefaultVersion(t *testing.T) {
+	serverConfig := &containerpb.ServerConfig{
+		DefaultClusterVersion: "1.31.5-gke.1023000",
+		Channels: []*containerpb.ServerConfig_ReleaseChannelConfig{
+			{
+				Channel:        containerpb.ReleaseChannel_RAPID,
+				DefaultVersion: "1.32.2-gke.1182000",
+			},
+		},
+	}
+
+	tests := []struct {
+		name                      string
+		controlPlaneVersion       *string
+		releaseChannel            *infrav1exp.ReleaseChannel
+		serverConfigErr           error
+		wantDefaultVersion        string
+		wantInitialClusterVersion string
+	}{
+		{
+			name:               "default version of the release channel is recorded without being pinned",
+			releaseChannel:     ptr.To(infrav1exp.Rapid),
+			wantDefaultVersion: "1.32.2",
+		},
+		{
+			name:           "release channel not listed in the server config",
+			releaseChannel: ptr.To(infrav1exp.Stable),
+		},
+		{
+			name:            "server config error does not block the creation",
+			releaseChannel:  ptr.To(infrav1exp.Rapid),
+			serverConfigErr: status.Error(codes.Internal, "internal error"),
+		},
+		{
+			name:                      "control plane version is pinned without resolving the default version",
+			controlPlaneVersion:       ptr.To("v1.31.6"),
+			releaseChannel:            ptr.To(infrav1exp.Rapid),
+			wantInitialClusterVersion: "1.31.6",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClusterManager := &fakeClusterManager{
+				serverConfig:    serverConfig,
+				serverConfigErr: tt.serverConfigErr,
+			}
+			s := newFakeClusterManagerService(t, fakeClusterManager, infrav1exp.GCPManagedControlPlaneSpec{
+				EnableAutopilot:     true,
+				ControlPlaneVersion: tt.controlPlaneVersion,
+				ReleaseChannel:      tt.releaseChannel,
+			})
+
+			if _, err := s.Reconcile(context.Background()); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if got := s.scope.GCPManagedControlPlane.Status.DefaultVersion; got != tt.wantDefaultVersion {
+				t.Errorf("Status.DefaultVersion = %q, want %q", got, tt.wantDefaultVersion)
+			}
+			if len(fakeClusterManager.createClusterRequests) != 1 {
+				t.Fatalf("got %d create cluster requests, want 1", len(fakeClusterManager.createClusterRequests))
+			}
+			if got := fakeClusterManager.createClusterRequests[0].GetCluster().GetInitialClusterVersion(); got != tt.wantInitialClusterVersion {
+				t.Errorf("InitialClusterVersion = %q, want %q", got, tt.wantInitialClusterVersion)
+			}
+		})
+	}
+}
+
+func TestCheckDiffAndPrepareUpdateMasterVersion(t *testing.T) {
+	tests := []struct {
+		name                     string
+		controlPlaneVersion      *string
+		defaultVersion           string
+		currentMasterVersion     string
+		wantNeedUpdate           bool
+		wantDesiredMasterVersion string
+	}{
+		{
+			name:                 "default version matching the current version",
+			defaultVersion:       "1.31.5",
+			currentMasterVersion: "1.31.5-gke.1023000",
+		},
+		{
+			name:                 "control plane upgraded past the default version by GKE",
+			defaultVersion:       "1.31.5",
+			currentMasterVersion: "1.31.6-gke.1020000",
+		},
+		{
+			name:                 "control plane version matching the current version",
+			controlPlaneVersion:  ptr.To("v1.31.6"),
+			currentMasterVersion: "1.31.6-gke.1020000",
+		},
+		{
+			name:                     "control plane version upgrade",
+			controlPlaneVersion:      ptr.To("1.32.2"),
+			defaultVersion:           "1.31.5",
+			currentMasterVersion:     "1.31.6-gke.1020000",
+			wantNeedUpdate:           true,
+			wantDesiredMasterVersion: "1.32.2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := logr.Discard()
+			s := newTestService(infrav1exp.GCPManagedControlPlaneSpec{ControlPlaneVersion: tt.controlPlaneVersion})
+			s.scope.GCPManagedControlPlane.Status.DefaultVersion = tt.defaultVersion
+
+			needUpdate, updateClusterRequest := s.checkDiffAndPrepareUpdate(newTestCluster(func(cluster *containerpb.Cluster) {
+				cluster.CurrentMasterVersion = tt.currentMasterVersion
+			}), &log)
+			if needUpdate != tt.wantNeedUpdate {
+				t.Errorf("checkDiffAndPrepareUpdate() needUpdate = %v, want %v", needUpdate, tt.wantNeedUpdate)
+			}
+			if got := updateClusterRequest.GetUpdate().GetDesiredMasterVersion(); got != tt.wantDesiredMasterVersion {
+				t.Errorf("checkDiffAndPrepareUpdate() DesiredMasterVersion = %q, want %q", got, tt.wantDesiredMasterVersion)
+			}
+		})
+	}
+}
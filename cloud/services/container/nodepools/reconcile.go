@@ -70,6 +70,10 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 	}
 	if nodePool == nil {
 		log.Info("Node pool not found, creating", "cluster", s.scope.Cluster.Name)
+		if shared.SkipDryRun(ctx, s.scope, "create", "GKE node pool", s.scope.NodePoolName()) {
+			return ctrl.Result{}, nil
+		}
+
 		if err = s.createNodePool(ctx, &log); err != nil {
 			conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEMachinePoolReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
 			conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolReadyCondition, infrav1exp.GKEMachinePoolReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
@@ -144,8 +148,13 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		return ctrl.Result{}, nil
 	}
 
+	if err := shared.NodeLocationsPreflightCheck(s.scope.GCPManagedMachinePool, s.scope.Region()); err != nil {
+		conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEMachinePoolReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return ctrl.Result{}, err
+	}
+
 	needUpdateConfig, nodePoolUpdateConfigRequest := s.checkDiffAndPrepareUpdateConfig(nodePool)
-	if needUpdateConfig {
+	if needUpdateConfig && !shared.SkipDryRun(ctx, s.scope, "update", "GKE node pool", s.scope.NodePoolName()) {
 		log.Info("Node pool config update required", "request", nodePoolUpdateConfigRequest)
 		err = s.updateNodePoolConfig(ctx, nodePoolUpdateConfigRequest)
 		if err != nil {
@@ -158,7 +167,7 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 	}
 
 	needUpdateAutoscaling, setNodePoolAutoscalingRequest := s.checkDiffAndPrepareUpdateAutoscaling(nodePool)
-	if needUpdateAutoscaling {
+	if needUpdateAutoscaling && !shared.SkipDryRun(ctx, s.scope, "update autoscaling of", "GKE node pool", s.scope.NodePoolName()) {
 		log.Info("Auto scaling update required")
 		err = s.updateNodePoolAutoscaling(ctx, setNodePoolAutoscalingRequest)
 		if err != nil {
@@ -170,7 +179,7 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 	}
 
 	needUpdateSize, setNodePoolSizeRequest := s.checkDiffAndPrepareUpdateSize(nodePool)
-	if needUpdateSize {
+	if needUpdateSize && !shared.SkipDryRun(ctx, s.scope, "resize", "GKE node pool", s.scope.NodePoolName()) {
 		log.Info("Size update required")
 		err = s.updateNodePoolSize(ctx, setNodePoolSizeRequest)
 		if err != nil {
@@ -181,7 +190,13 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
 	}
 
-	conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpdatingCondition, infrav1exp.GKEMachinePoolUpdatedReason, clusterv1.ConditionSeverityInfo, "")
+	// A node version breaking the version skew policy is not applied, the rest of the node pool is still reconciled.
+	if err := s.scope.ValidateNodePoolVersion(); err != nil {
+		log.Error(err, "Node pool version update skipped")
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpdatingCondition, infrav1exp.GKEMachinePoolReconciliationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+	} else {
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpdatingCondition, infrav1exp.GKEMachinePoolUpdatedReason, clusterv1.ConditionSeverityInfo, "")
+	}
 
 	s.scope.SetReplicas(int32(len(s.scope.GCPManagedMachinePool.Spec.ProviderIDList)))
 	log.Info("Node pool reconciled")
@@ -226,6 +241,10 @@ func (s *Service) Delete(ctx context.Context) (ctrl.Result, error) {
 		break
 	}
 
+	if shared.SkipDryRun(ctx, s.scope, "delete", "GKE node pool", s.scope.NodePoolName()) {
+		return ctrl.Result{}, nil
+	}
+
 	if err = s.deleteNodePool(ctx); err != nil {
 		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolDeletingCondition, infrav1exp.GKEMachinePoolReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
 		return ctrl.Result{}, err
@@ -291,6 +310,17 @@ func (s *Service) createNodePool(ctx context.Context, log *logr.Logger) error {
 	if err := shared.ManagedMachinePoolPreflightCheck(s.scope.GCPManagedMachinePool, s.scope.MachinePool, s.scope.Region()); err != nil {
 		return fmt.Errorf("preflight checks on machine pool before creating: %w", err)
 	}
+	if s.scope.GCPManagedControlPlane.IsConfidentialNodesEnabled() {
+		if err := shared.ConfidentialNodesPreflightCheck(s.scope.GCPManagedMachinePool); err != nil {
+			return fmt.Errorf("preflight checks on machine pool before creating: %w", err)
+		}
+	}
+	if s.scope.GCPManagedControlPlane.Spec.ClusterNetwork != nil && s.scope.GCPManagedControlPlane.Spec.ClusterNetwork.UseIPAliases {
+		subnets := s.scope.GCPManagedCluster.Spec.Network.Subnets.FilterByRegion(s.scope.Region())
+		if err := shared.PodRangePreflightCheck(s.scope.GCPManagedMachinePool, subnets); err != nil {
+			return fmt.Errorf("preflight checks on machine pool before creating: %w", err)
+		}
+	}
 
 	isRegional := shared.IsRegional(s.scope.Region())
 
@@ -354,10 +384,10 @@ func (s *Service) checkDiffAndPrepareUpdateConfig(existingNodePool *containerpb.
 	isRegional := shared.IsRegional(s.scope.Region())
 	desiredNodePool := scope.ConvertToSdkNodePool(*s.scope.GCPManagedMachinePool, *s.scope.MachinePool, isRegional, s.scope.GCPManagedControlPlane.Spec.ClusterName)
 
-	// Node version
+	// Node version, not updated while it breaks the version skew policy
 	if s.scope.NodePoolVersion() != nil {
 		desiredNodePoolVersion := infrav1exp.ConvertFromSdkNodeVersion(*s.scope.NodePoolVersion())
-		if desiredNodePoolVersion != infrav1exp.ConvertFromSdkNodeVersion(existingNodePool.GetVersion()) {
+		if desiredNodePoolVersion != infrav1exp.ConvertFromSdkNodeVersion(existingNodePool.GetVersion()) && s.scope.ValidateNodePoolVersion() == nil {
 			needUpdate = true
 			updateNodePoolRequest.NodeVersion = desiredNodePoolVersion
 		}
@@ -409,6 +439,12 @@ func (s *Service) checkDiffAndPrepareUpdateConfig(existingNodePool *containerpb.
 		needUpdate = true
 		updateNodePoolRequest.LinuxNodeConfig = desiredLinuxNodeConfig
 	}
+	// GcfsConfig
+	desiredGcfsConfig := desiredNodePool.GetConfig().GetGcfsConfig()
+	if desiredGcfsConfig != nil && desiredGcfsConfig.GetEnabled() != existingNodePool.GetConfig().GetGcfsConfig().GetEnabled() {
+		needUpdate = true
+		updateNodePoolRequest.GcfsConfig = desiredGcfsConfig
+	}
 
 	return needUpdate, &updateNodePoolRequest
 }
@@ -0,0 +1,363 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+)
+
+// newTestService returns a node pool service for the given GCPManagedMachinePool spec, in a zonal cluster.
+func newTestService(spec infrav1exp.GCPManagedMachinePoolSpec) *Service {
+	spec.NodePoolName = "test-pool"
+	return New(&scope.ManagedMachinePoolScope{
+		MachinePool: &clusterv1exp.MachinePool{
+			Spec: clusterv1exp.MachinePoolSpec{
+				Replicas: ptr.To[int32](1),
+			},
+		},
+		GCPManagedControlPlane: &infrav1exp.GCPManagedControlPlane{
+			Spec: infrav1exp.GCPManagedControlPlaneSpec{
+				ClusterName: "test-cluster",
+				Project:     "test-project",
+				Location:    "us-central1-a",
+			},
+		},
+		GCPManagedMachinePool: &infrav1exp.GCPManagedMachinePool{
+			Spec: spec,
+		},
+	})
+}
+
+func TestCheckDiffAndPrepareUpdateConfigImageType(t *testing.T) {
+	tests := []struct {
+		name           string
+		imageType      *string
+		existing       string
+		wantNeedUpdate bool
+		wantImageType  string
+	}{
+		{
+			name:     "image type not specified keeps the existing image type",
+			existing: "UBUNTU_CONTAINERD",
+		},
+		{
+			name:      "matching image type in a different case",
+			imageType: ptr.To("cos_containerd"),
+			existing:  "COS_CONTAINERD",
+		},
+		{
+			name:           "changing the image type",
+			imageType:      ptr.To("ubuntu_containerd"),
+			existing:       "COS_CONTAINERD",
+			wantNeedUpdate: true,
+			wantImageType:  "ubuntu_containerd",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(infrav1exp.GCPManagedMachinePoolSpec{ImageType: tt.imageType})
+			existingNodePool := scope.ConvertToSdkNodePool(*s.scope.GCPManagedMachinePool, *s.scope.MachinePool, false, "test-cluster")
+			existingNodePool.Config.ImageType = tt.existing
+			existingNodePool.Config.LinuxNodeConfig = &containerpb.LinuxNodeConfig{}
+
+			needUpdate, updateNodePoolRequest := s.checkDiffAndPrepareUpdateConfig(existingNodePool)
+			if needUpdate != tt.wantNeedUpdate {
+				t.Errorf("checkDiffAndPrepareUpdateConfig() needUpdate = %v, want %v", needUpdate, tt.wantNeedUpdate)
+			}
+			if got := updateNodePoolRequest.GetImageType(); got != tt.wantImageType {
+				t.Errorf("checkDiffAndPrepareUpdateConfig() ImageType = %q, want %q", got, tt.wantImageType)
+			}
+		})
+	}
+}
+
+func TestCheckDiffAndPrepareUpdateConfigVersion(t *testing.T) {
+	tests := []struct {
+		name               string
+		nodeVersion        *string
+		machinePoolVersion string
+		wantNeedUpdate     bool
+		wantNodeVersion    string
+	}{
+		{
+			name:               "machine pool version ahead of the control plane during an upgrade",
+			machinePoolVersion: "v1.31.1",
+			wantNeedUpdate:     true,
+			wantNodeVersion:    "1.31.1",
+		},
+		{
+			name:               "node version within the version skew policy",
+			nodeVersion:        ptr.To("1.29.8"),
+			machinePoolVersion: "v1.30.5",
+			wantNeedUpdate:     true,
+			wantNodeVersion:    "1.29.8",
+		},
+		{
+			name:               "node version newer than the control plane is not applied",
+			nodeVersion:        ptr.To("1.31.1"),
+			machinePoolVersion: "v1.30.5",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(infrav1exp.GCPManagedMachinePoolSpec{NodeVersion: tt.nodeVersion})
+			s.scope.GCPManagedControlPlane.Status.CurrentVersion = "1.30.5"
+			existingNodePool := scope.ConvertToSdkNodePool(*s.scope.GCPManagedMachinePool, *s.scope.MachinePool, false, "test-cluster")
+			existingNodePool.Version = "1.30.5-gke.1014001"
+			existingNodePool.Config.LinuxNodeConfig = &containerpb.LinuxNodeConfig{}
+			s.scope.MachinePool.Spec.Template.Spec.Version = ptr.To(tt.machinePoolVersion)
+
+			needUpdate, updateNodePoolRequest := s.checkDiffAndPrepareUpdateConfig(existingNodePool)
+			if needUpdate != tt.wantNeedUpdate {
+				t.Errorf("checkDiffAndPrepareUpdateConfig() needUpdate = %v, want %v", needUpdate, tt.wantNeedUpdate)
+			}
+			if got := updateNodePoolRequest.GetNodeVersion(); got != tt.wantNodeVersion {
+				t.Errorf("checkDiffAndPrepareUpdateConfig() NodeVersion = %q, want %q", got, tt.wantNodeVersion)
+			}
+		})
+	}
+}
+
+func TestCheckDiffAndPrepareUpdateConfigResourceLabels(t *testing.T) {
+	tests := []struct {
+		name               string
+		spec               infrav1exp.GCPManagedMachinePoolSpec
+		wantNeedUpdate     bool
+		wantLabels         *containerpb.NodeLabels
+		wantResourceLabels *containerpb.ResourceLabels
+	}{
+		{
+			name: "no labels keeps the existing resource labels",
+		},
+		{
+			name: "adding a resource label",
+			spec: infrav1exp.GCPManagedMachinePoolSpec{
+				AdditionalLabels: infrav1.Labels{"cost-center": "platform"},
+			},
+			wantNeedUpdate: true,
+			wantResourceLabels: &containerpb.ResourceLabels{
+				Labels: map[string]string{
+					"cost-center":                         "platform",
+					infrav1.ClusterTagKey("test-cluster"): string(infrav1.ResourceLifecycleOwned),
+				},
+			},
+		},
+		{
+			name: "adding a Kubernetes label keeps the existing resource labels",
+			spec: infrav1exp.GCPManagedMachinePoolSpec{
+				KubernetesLabels: infrav1.Labels{"workload": "batch"},
+			},
+			wantNeedUpdate: true,
+			wantLabels: &containerpb.NodeLabels{
+				Labels: map[string]string{"workload": "batch"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := newTestService(infrav1exp.GCPManagedMachinePoolSpec{})
+			existingNodePool := scope.ConvertToSdkNodePool(*existing.scope.GCPManagedMachinePool, *existing.scope.MachinePool, false, "test-cluster")
+			existingNodePool.Config.LinuxNodeConfig = &containerpb.LinuxNodeConfig{}
+
+			s := newTestService(tt.spec)
+			needUpdate, updateNodePoolRequest := s.checkDiffAndPrepareUpdateConfig(existingNodePool)
+			if needUpdate != tt.wantNeedUpdate {
+				t.Errorf("checkDiffAndPrepareUpdateConfig() needUpdate = %v, want %v", needUpdate, tt.wantNeedUpdate)
+			}
+			if d := cmp.Diff(tt.wantLabels, updateNodePoolRequest.GetLabels(), protocmp.Transform()); d != "" {
+				t.Errorf("checkDiffAndPrepareUpdateConfig() Labels mismatch (-want +got):\n%s", d)
+			}
+			if d := cmp.Diff(tt.wantResourceLabels, updateNodePoolRequest.GetResourceLabels(), protocmp.Transform()); d != "" {
+				t.Errorf("checkDiffAndPrepareUpdateConfig() ResourceLabels mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestCheckDiffAndPrepareUpdateConfigLocations(t *testing.T) {
+	tests := []struct {
+		name           string
+		nodeLocations  []string
+		wantNeedUpdate bool
+		wantLocations  []string
+	}{
+		{
+			name: "node locations not specified keeps the existing zones",
+		},
+		{
+			name:          "matching node locations",
+			nodeLocations: []string{"us-central1-a", "us-central1-b"},
+		},
+		{
+			name:           "adding a third zone",
+			nodeLocations:  []string{"us-central1-a", "us-central1-b", "us-central1-c"},
+			wantNeedUpdate: true,
+			wantLocations:  []string{"us-central1-a", "us-central1-b", "us-central1-c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(infrav1exp.GCPManagedMachinePoolSpec{NodeLocations: tt.nodeLocations})
+			existingNodePool := scope.ConvertToSdkNodePool(*s.scope.GCPManagedMachinePool, *s.scope.MachinePool, false, "test-cluster")
+			existingNodePool.Config.LinuxNodeConfig = &containerpb.LinuxNodeConfig{}
+			existingNodePool.Locations = []string{"us-central1-a", "us-central1-b"}
+
+			needUpdate, updateNodePoolRequest := s.checkDiffAndPrepareUpdateConfig(existingNodePool)
+			if needUpdate != tt.wantNeedUpdate {
+				t.Errorf("checkDiffAndPrepareUpdateConfig() needUpdate = %v, want %v", needUpdate, tt.wantNeedUpdate)
+			}
+			if d := cmp.Diff(tt.wantLocations, updateNodePoolRequest.GetLocations()); d != "" {
+				t.Errorf("checkDiffAndPrepareUpdateConfig() Locations mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestConvertToSdkAutoscaling(t *testing.T) {
+	tests := []struct {
+		name        string
+		autoscaling *infrav1exp.NodePoolAutoScaling
+		want        *containerpb.NodePoolAutoscaling
+	}{
+		{
+			name: "autoscaling not specified",
+			want: &containerpb.NodePoolAutoscaling{
+				Enabled:           true,
+				TotalMaxNodeCount: 1,
+				LocationPolicy:    containerpb.NodePoolAutoscaling_BALANCED,
+			},
+		},
+		{
+			name: "total node counts",
+			autoscaling: &infrav1exp.NodePoolAutoScaling{
+				MinCount: ptr.To[int32](3),
+				MaxCount: ptr.To[int32](9),
+			},
+			want: &containerpb.NodePoolAutoscaling{
+				Enabled:           true,
+				TotalMinNodeCount: 3,
+				TotalMaxNodeCount: 9,
+				LocationPolicy:    containerpb.NodePoolAutoscaling_BALANCED,
+			},
+		},
+		{
+			name: "per zone node counts",
+			autoscaling: &infrav1exp.NodePoolAutoScaling{
+				MinCountPerZone: ptr.To[int32](1),
+				MaxCountPerZone: ptr.To[int32](3),
+				LocationPolicy:  ptr.To(infrav1exp.ManagedNodePoolLocationPolicyAny),
+			},
+			want: &containerpb.NodePoolAutoscaling{
+				Enabled:        true,
+				MinNodeCount:   1,
+				MaxNodeCount:   3,
+				LocationPolicy: containerpb.NodePoolAutoscaling_ANY,
+			},
+		},
+		{
+			name: "per zone minimum node count only",
+			autoscaling: &infrav1exp.NodePoolAutoScaling{
+				MinCountPerZone: ptr.To[int32](2),
+			},
+			want: &containerpb.NodePoolAutoscaling{
+				Enabled:        true,
+				MinNodeCount:   2,
+				MaxNodeCount:   2,
+				LocationPolicy: containerpb.NodePoolAutoscaling_BALANCED,
+			},
+		},
+		{
+			name: "per zone maximum node count only",
+			autoscaling: &infrav1exp.NodePoolAutoScaling{
+				MaxCountPerZone: ptr.To[int32](3),
+			},
+			want: &containerpb.NodePoolAutoscaling{
+				Enabled:        true,
+				MaxNodeCount:   3,
+				LocationPolicy: containerpb.NodePoolAutoscaling_BALANCED,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := infrav1exp.ConvertToSdkAutoscaling(tt.autoscaling)
+			if d := cmp.Diff(tt.want, got, protocmp.Transform()); d != "" {
+				t.Errorf("ConvertToSdkAutoscaling() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestCheckDiffAndPrepareUpdateAutoscaling(t *testing.T) {
+	existingAutoscaling := &containerpb.NodePoolAutoscaling{
+		Enabled:           true,
+		TotalMinNodeCount: 3,
+		TotalMaxNodeCount: 9,
+		LocationPolicy:    containerpb.NodePoolAutoscaling_BALANCED,
+	}
+
+	tests := []struct {
+		name            string
+		autoscaling     *infrav1exp.NodePoolAutoScaling
+		wantNeedUpdate  bool
+		wantAutoscaling *containerpb.NodePoolAutoscaling
+	}{
+		{
+			name: "matching total node counts",
+			autoscaling: &infrav1exp.NodePoolAutoScaling{
+				MinCount: ptr.To[int32](3),
+				MaxCount: ptr.To[int32](9),
+			},
+		},
+		{
+			name: "switching to per zone node counts",
+			autoscaling: &infrav1exp.NodePoolAutoScaling{
+				MinCountPerZone: ptr.To[int32](1),
+				MaxCountPerZone: ptr.To[int32](3),
+			},
+			wantNeedUpdate: true,
+			wantAutoscaling: &containerpb.NodePoolAutoscaling{
+				Enabled:        true,
+				MinNodeCount:   1,
+				MaxNodeCount:   3,
+				LocationPolicy: containerpb.NodePoolAutoscaling_BALANCED,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(infrav1exp.GCPManagedMachinePoolSpec{Scaling: tt.autoscaling})
+
+			needUpdate, setNodePoolAutoscalingRequest := s.checkDiffAndPrepareUpdateAutoscaling(&containerpb.NodePool{Autoscaling: existingAutoscaling})
+			if needUpdate != tt.wantNeedUpdate {
+				t.Errorf("checkDiffAndPrepareUpdateAutoscaling() needUpdate = %v, want %v", needUpdate, tt.wantNeedUpdate)
+			}
+			if d := cmp.Diff(tt.wantAutoscaling, setNodePoolAutoscalingRequest.GetAutoscaling(), protocmp.Transform()); d != "" {
+				t.Errorf("checkDiffAndPrepareUpdateAutoscaling() Autoscaling mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
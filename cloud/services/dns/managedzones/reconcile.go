@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package managedzones
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"google.golang.org/api/dns/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
+)
+
+// Reconcile reconciles the cluster Cloud DNS private zone and the record of the control plane endpoint.
+func (s *Service) Reconcile(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	if s.scope.DNSSpec() == nil {
+		return nil
+	}
+	log.Info("Reconciling DNS resources")
+
+	if s.scope.ControlPlaneEndpoint().Host == "" {
+		log.V(2).Info("Waiting for the control plane endpoint before creating the DNS record")
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1.DNSReadyCondition, infrav1.WaitingForControlPlaneEndpointReason, clusterv1.ConditionSeverityInfo, "")
+		return nil
+	}
+
+	zone, err := s.createOrGetManagedZone(ctx)
+	if err != nil {
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1.DNSReadyCondition, infrav1.DNSReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return err
+	}
+
+	if err := s.createOrUpdateRecordSet(ctx, zone.Name); err != nil {
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1.DNSReadyCondition, infrav1.DNSReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return err
+	}
+
+	conditions.MarkTrue(s.scope.ConditionSetter(), infrav1.DNSReadyCondition)
+	return nil
+}
+
+// Delete deletes the cluster Cloud DNS private zone and its records.
+func (s *Service) Delete(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	if s.scope.DNSSpec() == nil {
+		return nil
+	}
+	log.Info("Deleting DNS resources")
+
+	spec := s.scope.ManagedZoneSpec()
+	zone, err := s.managedZones.Get(ctx, s.scope.Project(), spec.Name)
+	if err != nil {
+		return gcperrors.IgnoreNotFound(err)
+	}
+	if !infrav1.Labels(zone.Labels).HasOwned(s.scope.Name()) {
+		log.V(2).Info("DNS managed zone is not owned by the cluster, skipping deletion", "name", zone.Name)
+		return nil
+	}
+
+	// A managed zone can only be deleted once it holds no records besides its NS and SOA records.
+	recordSet := s.scope.APIServerRecordSetSpec()
+	if shared.SkipDryRun(ctx, s.scope, "delete", "DNS managed zone", zone.Name) {
+		return nil
+	}
+
+	log.V(2).Info("Deleting DNS record", "name", recordSet.Name)
+	if err := s.recordSets.Delete(ctx, s.scope.Project(), zone.Name, recordSet.Name, recordSet.Type); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting DNS record", "name", recordSet.Name)
+		return err
+	}
+
+	log.V(2).Info("Deleting DNS managed zone", "name", zone.Name)
+	if err := s.managedZones.Delete(ctx, s.scope.Project(), zone.Name); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting DNS managed zone", "name", zone.Name)
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) createOrGetManagedZone(ctx context.Context) (*dns.ManagedZone, error) {
+	log := log.FromContext(ctx)
+	spec := s.scope.ManagedZoneSpec()
+	log.V(2).Info("Looking for DNS managed zone", "name", spec.Name)
+	zone, err := s.managedZones.Get(ctx, s.scope.Project(), spec.Name)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for DNS managed zone", "name", spec.Name)
+			return nil, err
+		}
+
+		if shared.SkipDryRun(ctx, s.scope, "create", "DNS managed zone", spec.Name) {
+			return nil, shared.ErrDryRun
+		}
+
+		log.V(2).Info("Creating DNS managed zone", "name", spec.Name)
+		if err := s.managedZones.Create(ctx, s.scope.Project(), spec); err != nil {
+			log.Error(err, "Error creating DNS managed zone", "name", spec.Name)
+			return nil, err
+		}
+
+		return spec, nil
+	}
+
+	if !infrav1.Labels(zone.Labels).HasOwned(s.scope.Name()) {
+		return nil, fmt.Errorf("DNS managed zone %s already exists and is not owned by the cluster", spec.Name)
+	}
+
+	return zone, nil
+}
+
+func (s *Service) createOrUpdateRecordSet(ctx context.Context, zone string) error {
+	log := log.FromContext(ctx)
+	spec := s.scope.APIServerRecordSetSpec()
+	log.V(2).Info("Looking for DNS record", "name", spec.Name)
+	recordSet, err := s.recordSets.Get(ctx, s.scope.Project(), zone, spec.Name, spec.Type)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for DNS record", "name", spec.Name)
+			return err
+		}
+
+		if shared.SkipDryRun(ctx, s.scope, "create", "DNS record", spec.Name) {
+			return nil
+		}
+
+		log.V(2).Info("Creating DNS record", "name", spec.Name, "rrdatas", spec.Rrdatas)
+		return s.recordSets.Create(ctx, s.scope.Project(), zone, spec)
+	}
+
+	if recordSet.Ttl == spec.Ttl && slices.Equal(recordSet.Rrdatas, spec.Rrdatas) {
+		return nil
+	}
+
+	if shared.SkipDryRun(ctx, s.scope, "update", "DNS record", spec.Name) {
+		return nil
+	}
+
+	log.V(2).Info("Updating DNS record", "name", spec.Name, "rrdatas", spec.Rrdatas)
+	return s.recordSets.Patch(ctx, s.scope.Project(), zone, spec)
+}
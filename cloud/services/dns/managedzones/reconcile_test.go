@@ -0,0 +1,321 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package managedzones
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/dns/v1"
+	"google.golang.org/api/googleapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+}
+
+var (
+	notFoundErr = &googleapi.Error{Code: http.StatusNotFound}
+
+	ownedLabels = map[string]string{infrav1.ClusterTagKey("my-cluster"): string(infrav1.ResourceLifecycleOwned)}
+)
+
+type fakeManagedZones struct {
+	zones map[string]*dns.ManagedZone
+}
+
+func (f *fakeManagedZones) Get(_ context.Context, _, zone string) (*dns.ManagedZone, error) {
+	if z, ok := f.zones[zone]; ok {
+		return z, nil
+	}
+	return nil, notFoundErr
+}
+
+func (f *fakeManagedZones) Create(_ context.Context, _ string, zone *dns.ManagedZone) error {
+	f.zones[zone.Name] = zone
+	return nil
+}
+
+func (f *fakeManagedZones) Delete(_ context.Context, _, zone string) error {
+	if _, ok := f.zones[zone]; !ok {
+		return notFoundErr
+	}
+	delete(f.zones, zone)
+	return nil
+}
+
+type fakeRecordSets struct {
+	recordSets map[string]*dns.ResourceRecordSet
+}
+
+func (f *fakeRecordSets) Get(_ context.Context, _, _, name, recordType string) (*dns.ResourceRecordSet, error) {
+	if rs, ok := f.recordSets[name+recordType]; ok {
+		return rs, nil
+	}
+	return nil, notFoundErr
+}
+
+func (f *fakeRecordSets) Create(_ context.Context, _, _ string, recordSet *dns.ResourceRecordSet) error {
+	f.recordSets[recordSet.Name+recordSet.Type] = recordSet
+	return nil
+}
+
+func (f *fakeRecordSets) Patch(_ context.Context, _, _ string, recordSet *dns.ResourceRecordSet) error {
+	f.recordSets[recordSet.Name+recordSet.Type] = recordSet
+	return nil
+}
+
+func (f *fakeRecordSets) Delete(_ context.Context, _, _, name, recordType string) error {
+	if _, ok := f.recordSets[name+recordType]; !ok {
+		return notFoundErr
+	}
+	delete(f.recordSets, name+recordType)
+	return nil
+}
+
+func getBaseClusterScope() (*scope.ClusterScope, error) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	fakeCluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: clusterv1.ClusterSpec{},
+	}
+
+	fakeGCPCluster := &infrav1.GCPCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: infrav1.GCPClusterSpec{
+			Project: "my-proj",
+			Region:  "us-central1",
+			ControlPlaneEndpoint: clusterv1.APIEndpoint{
+				Host: "10.0.0.10",
+			},
+			DNS: &infrav1.DNSSpec{
+				Domain: "my-cluster.internal",
+			},
+		},
+	}
+
+	return scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+			DNS:     &dns.Service{},
+		},
+	})
+}
+
+func TestService_Reconcile(t *testing.T) {
+	tests := []struct {
+		name           string
+		scope          func(s *scope.ClusterScope)
+		zones          map[string]*dns.ManagedZone
+		recordSets     map[string]*dns.ResourceRecordSet
+		wantErr        bool
+		wantRecordSets map[string]*dns.ResourceRecordSet
+		wantCondition  *clusterv1.Condition
+	}{
+		{
+			name:           "DNS is not configured (should do nothing)",
+			scope:          func(s *scope.ClusterScope) { s.GCPCluster.Spec.DNS = nil },
+			wantRecordSets: map[string]*dns.ResourceRecordSet{},
+		},
+		{
+			name:           "control plane endpoint is not set (should wait for it)",
+			scope:          func(s *scope.ClusterScope) { s.GCPCluster.Spec.ControlPlaneEndpoint.Host = "" },
+			wantRecordSets: map[string]*dns.ResourceRecordSet{},
+			wantCondition:  conditions.FalseCondition(infrav1.DNSReadyCondition, infrav1.WaitingForControlPlaneEndpointReason, clusterv1.ConditionSeverityInfo, ""),
+		},
+		{
+			name: "zone and record don't exist (should create the record pointing at the load balancer address)",
+			wantRecordSets: map[string]*dns.ResourceRecordSet{
+				"api.my-cluster.internal.A": {Name: "api.my-cluster.internal.", Type: "A", Ttl: 300, Rrdatas: []string{"10.0.0.10"}},
+			},
+			wantCondition: conditions.TrueCondition(infrav1.DNSReadyCondition),
+		},
+		{
+			name: "load balancer address changed (should update the record)",
+			zones: map[string]*dns.ManagedZone{
+				"my-cluster-private-zone": {Name: "my-cluster-private-zone", Labels: ownedLabels},
+			},
+			recordSets: map[string]*dns.ResourceRecordSet{
+				"api.my-cluster.internal.A": {Name: "api.my-cluster.internal.", Type: "A", Ttl: 300, Rrdatas: []string{"10.0.0.2"}},
+			},
+			wantRecordSets: map[string]*dns.ResourceRecordSet{
+				"api.my-cluster.internal.A": {Name: "api.my-cluster.internal.", Type: "A", Ttl: 300, Rrdatas: []string{"10.0.0.10"}},
+			},
+			wantCondition: conditions.TrueCondition(infrav1.DNSReadyCondition),
+		},
+		{
+			name: "zone exists and is not owned by the cluster (should return an error)",
+			zones: map[string]*dns.ManagedZone{
+				"my-cluster-private-zone": {Name: "my-cluster-private-zone"},
+			},
+			wantErr:        true,
+			wantRecordSets: map[string]*dns.ResourceRecordSet{},
+			wantCondition:  conditions.FalseCondition(infrav1.DNSReadyCondition, infrav1.DNSReconciliationFailedReason, clusterv1.ConditionSeverityError, "DNS managed zone my-cluster-private-zone already exists and is not owned by the cluster"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			clusterScope, err := getBaseClusterScope()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.scope != nil {
+				tt.scope(clusterScope)
+			}
+			zones := &fakeManagedZones{zones: map[string]*dns.ManagedZone{}}
+			for name, zone := range tt.zones {
+				zones.zones[name] = zone
+			}
+			recordSets := &fakeRecordSets{recordSets: map[string]*dns.ResourceRecordSet{}}
+			for key, recordSet := range tt.recordSets {
+				recordSets.recordSets[key] = recordSet
+			}
+			s := New(clusterScope)
+			s.managedZones = zones
+			s.recordSets = recordSets
+
+			err = s.Reconcile(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service s.Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if d := cmp.Diff(tt.wantRecordSets, recordSets.recordSets); d != "" {
+				t.Errorf("Service s.Reconcile() record sets mismatch (-want +got):\n%s", d)
+			}
+			got := conditions.Get(clusterScope.GCPCluster, infrav1.DNSReadyCondition)
+			if d := cmp.Diff(tt.wantCondition, got, cmp.FilterPath(func(p cmp.Path) bool { return p.Last().String() == ".LastTransitionTime" }, cmp.Ignore())); d != "" {
+				t.Errorf("Service s.Reconcile() DNSReady condition mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestService_ReconcileCreatesOwnedPrivateZone(t *testing.T) {
+	clusterScope, err := getBaseClusterScope()
+	if err != nil {
+		t.Fatal(err)
+	}
+	zones := &fakeManagedZones{zones: map[string]*dns.ManagedZone{}}
+	s := New(clusterScope)
+	s.managedZones = zones
+	s.recordSets = &fakeRecordSets{recordSets: map[string]*dns.ResourceRecordSet{}}
+
+	if err := s.Reconcile(context.TODO()); err != nil {
+		t.Fatalf("Service s.Reconcile() error = %v", err)
+	}
+
+	zone, ok := zones.zones["my-cluster-private-zone"]
+	if !ok {
+		t.Fatalf("Service s.Reconcile() did not create the managed zone")
+	}
+	if zone.DnsName != "my-cluster.internal." || zone.Visibility != "private" {
+		t.Errorf("Service s.Reconcile() created zone with dnsName %q and visibility %q, want a private zone for my-cluster.internal.", zone.DnsName, zone.Visibility)
+	}
+	if !infrav1.Labels(zone.Labels).HasOwned("my-cluster") {
+		t.Errorf("Service s.Reconcile() created zone with labels %v, want the zone owned by the cluster", zone.Labels)
+	}
+	wantNetwork := "https://www.googleapis.com/compute/v1/projects/my-proj/global/networks/default"
+	if got := zone.PrivateVisibilityConfig.Networks[0].NetworkUrl; got != wantNetwork {
+		t.Errorf("Service s.Reconcile() created zone visible from %q, want %q", got, wantNetwork)
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	tests := []struct {
+		name       string
+		zones      map[string]*dns.ManagedZone
+		wantZones  map[string]*dns.ManagedZone
+		wantErr    bool
+		recordSets map[string]*dns.ResourceRecordSet
+	}{
+		{
+			name:      "zone doesn't exist (should do nothing)",
+			wantZones: map[string]*dns.ManagedZone{},
+		},
+		{
+			name: "zone is owned by the cluster (should delete the record and the zone)",
+			zones: map[string]*dns.ManagedZone{
+				"my-cluster-private-zone": {Name: "my-cluster-private-zone", Labels: ownedLabels},
+			},
+			recordSets: map[string]*dns.ResourceRecordSet{
+				"api.my-cluster.internal.A": {Name: "api.my-cluster.internal.", Type: "A", Ttl: 300, Rrdatas: []string{"10.0.0.10"}},
+			},
+			wantZones: map[string]*dns.ManagedZone{},
+		},
+		{
+			name: "zone is not owned by the cluster (should keep the zone)",
+			zones: map[string]*dns.ManagedZone{
+				"my-cluster-private-zone": {Name: "my-cluster-private-zone"},
+			},
+			wantZones: map[string]*dns.ManagedZone{
+				"my-cluster-private-zone": {Name: "my-cluster-private-zone"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterScope, err := getBaseClusterScope()
+			if err != nil {
+				t.Fatal(err)
+			}
+			zones := &fakeManagedZones{zones: map[string]*dns.ManagedZone{}}
+			for name, zone := range tt.zones {
+				zones.zones[name] = zone
+			}
+			recordSets := &fakeRecordSets{recordSets: map[string]*dns.ResourceRecordSet{}}
+			for key, recordSet := range tt.recordSets {
+				recordSets.recordSets[key] = recordSet
+			}
+			s := New(clusterScope)
+			s.managedZones = zones
+			s.recordSets = recordSets
+
+			err = s.Delete(context.TODO())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service s.Delete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if d := cmp.Diff(tt.wantZones, zones.zones); d != "" {
+				t.Errorf("Service s.Delete() zones mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package managedzones
+
+import (
+	"context"
+
+	"google.golang.org/api/dns/v1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+)
+
+type managedZonesInterface interface {
+	Get(ctx context.Context, project, zone string) (*dns.ManagedZone, error)
+	Create(ctx context.Context, project string, zone *dns.ManagedZone) error
+	Delete(ctx context.Context, project, zone string) error
+}
+
+type recordSetsInterface interface {
+	Get(ctx context.Context, project, zone, name, recordType string) (*dns.ResourceRecordSet, error)
+	Create(ctx context.Context, project, zone string, recordSet *dns.ResourceRecordSet) error
+	Patch(ctx context.Context, project, zone string, recordSet *dns.ResourceRecordSet) error
+	Delete(ctx context.Context, project, zone, name, recordType string) error
+}
+
+// Scope is an interfaces that hold used methods.
+type Scope interface {
+	cloud.ClusterGetter
+	ConditionSetter() conditions.Setter
+	DNSService() *dns.Service
+	DNSSpec() *infrav1.DNSSpec
+	ManagedZoneSpec() *dns.ManagedZone
+	APIServerRecordSetSpec() *dns.ResourceRecordSet
+}
+
+// Service implements the Cloud DNS private zone reconciler.
+type Service struct {
+	scope        Scope
+	managedZones managedZonesInterface
+	recordSets   recordSetsInterface
+}
+
+var _ cloud.Reconciler = &Service{}
+
+// New returns Service from given scope.
+func New(scope Scope) *Service {
+	s := &Service{
+		scope: scope,
+	}
+	if svc := scope.DNSService(); svc != nil {
+		s.managedZones = &managedZones{service: svc.ManagedZones}
+		s.recordSets = &recordSets{service: svc.ResourceRecordSets}
+	}
+
+	return s
+}
+
+// managedZones implements managedZonesInterface with the Cloud DNS API.
+type managedZones struct {
+	service *dns.ManagedZonesService
+}
+
+func (m *managedZones) Get(ctx context.Context, project, zone string) (*dns.ManagedZone, error) {
+	return m.service.Get(project, zone).Context(ctx).Do()
+}
+
+func (m *managedZones) Create(ctx context.Context, project string, zone *dns.ManagedZone) error {
+	_, err := m.service.Create(project, zone).Context(ctx).Do()
+	return err
+}
+
+func (m *managedZones) Delete(ctx context.Context, project, zone string) error {
+	return m.service.Delete(project, zone).Context(ctx).Do()
+}
+
+// recordSets implements recordSetsInterface with the Cloud DNS API.
+type recordSets struct {
+	service *dns.ResourceRecordSetsService
+}
+
+func (r *recordSets) Get(ctx context.Context, project, zone, name, recordType string) (*dns.ResourceRecordSet, error) {
+	return r.service.Get(project, zone, name, recordType).Context(ctx).Do()
+}
+
+func (r *recordSets) Create(ctx context.Context, project, zone string, recordSet *dns.ResourceRecordSet) error {
+	_, err := r.service.Create(project, zone, recordSet).Context(ctx).Do()
+	return err
+}
+
+func (r *recordSets) Patch(ctx context.Context, project, zone string, recordSet *dns.ResourceRecordSet) error {
+	_, err := r.service.Patch(project, zone, recordSet.Name, recordSet.Type, recordSet).Context(ctx).Do()
+	return err
+}
+
+func (r *recordSets) Delete(ctx context.Context, project, zone, name, recordType string) error {
+	_, err := r.service.Delete(project, zone, name, recordType).Context(ctx).Do()
+	return err
+}
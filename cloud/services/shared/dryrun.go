@@ -0,0 +1,41 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import (
+	"context"
+	"errors"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ErrDryRun is returned when reconciliation cannot go further in dry-run mode, because the next changes depend on
+// resources which would have been created.
+var ErrDryRun = errors.New("dry-run: further changes depend on resources which would have been created")
+
+// SkipDryRun reports whether the change to the GCP resource must be skipped because the scope is in dry-run mode,
+// in which case the change is logged and recorded instead.
+func SkipDryRun(ctx context.Context, s cloud.DryRunner, action, kind, name string) bool {
+	if !s.DryRun() {
+		return false
+	}
+
+	log.FromContext(ctx).Info("Dry-run mode enabled, skipping change", "action", action, "kind", kind, "name", name)
+	s.RecordDryRun(action, kind, name)
+	return true
+}
@@ -19,20 +19,35 @@ package shared
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
+	"k8s.io/utils/ptr"
 	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/location"
 )
 
+// Confidential GKE Nodes supports Compute Engine machine types in the following series:
+// reference: https://cloud.google.com/kubernetes-engine/docs/how-to/confidential-gke-nodes#availability
+var confidentialNodesSupportedMachineSeries = []string{"n2d", "c2d", "c3d"}
+
+// defaultManagedMachinePoolMachineType is the machine type used by GKE when none is specified.
+const defaultManagedMachinePoolMachineType = "e2-medium"
+
 // ManagedMachinePoolPreflightCheck will perform checks against the machine pool before its created.
 func ManagedMachinePoolPreflightCheck(managedPool *infrav1exp.GCPManagedMachinePool, machinePool *clusterv1exp.MachinePool, location string) error {
 	if machinePool.Spec.Template.Spec.InfrastructureRef.Name != managedPool.Name {
 		return fmt.Errorf("expect machinepool infraref (%s) to match managed machine pool name (%s)", machinePool.Spec.Template.Spec.InfrastructureRef.Name, managedPool.Name)
 	}
 
+	if err := NodeLocationsPreflightCheck(managedPool, location); err != nil {
+		return err
+	}
+
 	if IsRegional(location) {
 		var numRegionsPerZone int32
 		if len(managedPool.Spec.NodeLocations) != 0 {
@@ -66,6 +81,115 @@ func ManagedMachinePoolsPreflightCheck(managedPools []infrav1exp.GCPManagedMachi
 	return nil
 }
 
+// ManagedMachinePoolsPreflightResult is the result of the preflight checks against a slice of machine pools.
+type ManagedMachinePoolsPreflightResult struct {
+	// ManagedPools are the managed machine pools that passed the checks.
+	ManagedPools []infrav1exp.GCPManagedMachinePool
+	// MachinePools are the machine pools that passed the checks, in the same order as ManagedPools.
+	MachinePools []clusterv1exp.MachinePool
+	// Warnings are the failures of the machine pools that did not pass the checks.
+	Warnings []error
+}
+
+// ManagedMachinePoolsPartialPreflightCheck will perform the same checks as ManagedMachinePoolsPreflightCheck along with
+// the given additional checks, but report the machine pools that fail a check as warnings instead of failing.
+// An error is returned when the machine pools cannot be matched, or when no machine pool passes the checks.
+func ManagedMachinePoolsPartialPreflightCheck(managedPools []infrav1exp.GCPManagedMachinePool, machinePools []clusterv1exp.MachinePool, location string, checks ...func(*infrav1exp.GCPManagedMachinePool) error) (*ManagedMachinePoolsPreflightResult, error) {
+	if len(machinePools) != len(managedPools) {
+		return nil, errors.New("each machinepool must have a matching gcpmanagedmachinepool")
+	}
+
+	result := &ManagedMachinePoolsPreflightResult{}
+	for i := range machinePools {
+		machinePool := machinePools[i]
+		managedPool := managedPools[i]
+
+		err := ManagedMachinePoolPreflightCheck(&managedPool, &machinePool, location)
+		for _, check := range checks {
+			if err != nil {
+				break
+			}
+			err = check(&managedPool)
+		}
+		if err != nil {
+			result.Warnings = append(result.Warnings, err)
+			continue
+		}
+		result.ManagedPools = append(result.ManagedPools, managedPool)
+		result.MachinePools = append(result.MachinePools, machinePool)
+	}
+
+	if len(machinePools) > 0 && len(result.MachinePools) == 0 {
+		return nil, fmt.Errorf("no machine pool passed the preflight checks: %w", errors.Join(result.Warnings...))
+	}
+
+	return result, nil
+}
+
+// ConfidentialNodesPreflightCheck will check that the machine pool uses a machine type supported by Confidential GKE Nodes.
+func ConfidentialNodesPreflightCheck(managedPool *infrav1exp.GCPManagedMachinePool) error {
+	machineType := defaultManagedMachinePoolMachineType
+	if managedPool.Spec.InstanceType != nil {
+		machineType = *managedPool.Spec.InstanceType
+	} else if managedPool.Spec.MachineType != nil {
+		machineType = *managedPool.Spec.MachineType
+	}
+
+	machineSeries := strings.Split(machineType, "-")[0]
+	if !slices.Contains(confidentialNodesSupportedMachineSeries, machineSeries) {
+		return fmt.Errorf("machine pool (%s) uses machine type %s which does not support confidential nodes; supported machine series are: %s", managedPool.Name, machineType, strings.Join(confidentialNodesSupportedMachineSeries, ","))
+	}
+
+	return nil
+}
+
+// PodRangePreflightCheck will check that the existing secondary range the machine pool assigns pod IPs from is
+// declared on one of the given subnets. Subnets that don't declare any secondary range are not managed by CAPG,
+// so the check is skipped when none of them declares one.
+func PodRangePreflightCheck(managedPool *infrav1exp.GCPManagedMachinePool, subnets infrav1.Subnets) error {
+	podRange := managedPool.Spec.NodeNetwork.PodRangeName
+	if podRange == nil || ptr.Deref(managedPool.Spec.NodeNetwork.CreatePodRange, false) {
+		return nil
+	}
+
+	var declaredRanges []string
+	for _, subnet := range subnets {
+		for rangeName := range subnet.SecondaryCidrBlocks {
+			if rangeName == *podRange {
+				return nil
+			}
+			declaredRanges = append(declaredRanges, rangeName)
+		}
+	}
+	if len(declaredRanges) == 0 {
+		return nil
+	}
+
+	slices.Sort(declaredRanges)
+	return fmt.Errorf("machine pool (%s) uses pod range %s which is not a secondary range of the cluster subnet; declared secondary ranges are: %s", managedPool.Name, *podRange, strings.Join(declaredRanges, ","))
+}
+
+// NodeLocationsPreflightCheck will check that the zones the machine pool places its nodes in are zones of the region
+// of the cluster location.
+func NodeLocationsPreflightCheck(managedPool *infrav1exp.GCPManagedMachinePool, clusterLocation string) error {
+	if len(managedPool.Spec.NodeLocations) == 0 {
+		return nil
+	}
+
+	cluster, err := location.Parse(clusterLocation)
+	if err != nil {
+		return fmt.Errorf("parsing cluster location %s: %w", clusterLocation, err)
+	}
+	for _, zone := range managedPool.Spec.NodeLocations {
+		nodeLocation, err := location.Parse(zone)
+		if err != nil || nodeLocation.Zone == nil || nodeLocation.Region != cluster.Region {
+			return fmt.Errorf("machine pool (%s) uses node location %s which is not a zone of the cluster region %s", managedPool.Name, zone, cluster.Region)
+		}
+	}
+
+	return nil
+}
+
 // IsRegional will check if a given location is a region (if not its a zone).
 func IsRegional(location string) bool {
 	return strings.Count(location, "-") == 1
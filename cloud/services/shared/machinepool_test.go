@@ -0,0 +1,228 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+)
+
+func newTestMachinePools(name string, replicas int32, machineType string) (infrav1exp.GCPManagedMachinePool, clusterv1exp.MachinePool) {
+	managedPool := infrav1exp.GCPManagedMachinePool{
+		ObjectMeta: metav1.ObjectMeta{Name: name + "-mmp"},
+		Spec: infrav1exp.GCPManagedMachinePoolSpec{
+			MachineType: ptr.To(machineType),
+		},
+	}
+	machinePool := clusterv1exp.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: clusterv1exp.MachinePoolSpec{
+			Replicas: ptr.To(replicas),
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					InfrastructureRef: corev1.ObjectReference{Name: name + "-mmp"},
+				},
+			},
+		},
+	}
+	return managedPool, machinePool
+}
+
+func TestManagedMachinePoolsPartialPreflightCheck(t *testing.T) {
+	validManagedPool, validMachinePool := newTestMachinePools("valid", 3, "n2d-standard-2")
+	// replicas must be a multiple of the number of zones in a regional cluster.
+	invalidManagedPool, invalidMachinePool := newTestMachinePools("invalid", 2, "n2d-standard-2")
+	nonConfidentialManagedPool, nonConfidentialMachinePool := newTestMachinePools("non-confidential", 3, "e2-medium")
+
+	tests := []struct {
+		name             string
+		managedPools     []infrav1exp.GCPManagedMachinePool
+		machinePools     []clusterv1exp.MachinePool
+		checks           []func(*infrav1exp.GCPManagedMachinePool) error
+		wantErr          bool
+		wantMachinePools []string
+		wantWarnings     int
+	}{
+		{
+			name:             "all machine pools pass",
+			managedPools:     []infrav1exp.GCPManagedMachinePool{validManagedPool},
+			machinePools:     []clusterv1exp.MachinePool{validMachinePool},
+			wantMachinePools: []string{"valid"},
+		},
+		{
+			name:             "no machine pools",
+			wantMachinePools: nil,
+		},
+		{
+			name:             "failing machine pool is reported as a warning",
+			managedPools:     []infrav1exp.GCPManagedMachinePool{validManagedPool, invalidManagedPool},
+			machinePools:     []clusterv1exp.MachinePool{validMachinePool, invalidMachinePool},
+			wantMachinePools: []string{"valid"},
+			wantWarnings:     1,
+		},
+		{
+			name:             "failing additional check is reported as a warning",
+			managedPools:     []infrav1exp.GCPManagedMachinePool{validManagedPool, nonConfidentialManagedPool},
+			machinePools:     []clusterv1exp.MachinePool{validMachinePool, nonConfidentialMachinePool},
+			checks:           []func(*infrav1exp.GCPManagedMachinePool) error{ConfidentialNodesPreflightCheck},
+			wantMachinePools: []string{"valid"},
+			wantWarnings:     1,
+		},
+		{
+			name:         "all machine pools failing is fatal",
+			managedPools: []infrav1exp.GCPManagedMachinePool{invalidManagedPool},
+			machinePools: []clusterv1exp.MachinePool{invalidMachinePool},
+			wantErr:      true,
+		},
+		{
+			name:         "unmatched machine pools is fatal",
+			managedPools: []infrav1exp.GCPManagedMachinePool{validManagedPool, invalidManagedPool},
+			machinePools: []clusterv1exp.MachinePool{validMachinePool},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ManagedMachinePoolsPartialPreflightCheck(tt.managedPools, tt.machinePools, "us-central1", tt.checks...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ManagedMachinePoolsPartialPreflightCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(result.Warnings) != tt.wantWarnings {
+				t.Errorf("ManagedMachinePoolsPartialPreflightCheck() warnings = %v, want %d warnings", result.Warnings, tt.wantWarnings)
+			}
+			var machinePools []string
+			for i := range result.MachinePools {
+				machinePools = append(machinePools, result.MachinePools[i].Name)
+				if result.ManagedPools[i].Name != result.MachinePools[i].Spec.Template.Spec.InfrastructureRef.Name {
+					t.Errorf("ManagedMachinePoolsPartialPreflightCheck() managed pool %s does not match machine pool %s", result.ManagedPools[i].Name, result.MachinePools[i].Name)
+				}
+			}
+			if d := cmp.Diff(tt.wantMachinePools, machinePools); d != "" {
+				t.Errorf("ManagedMachinePoolsPartialPreflightCheck() machine pools mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestManagedMachinePoolPreflightCheckNodeLocations(t *testing.T) {
+	tests := []struct {
+		name          string
+		nodeLocations []string
+		replicas      int32
+		wantErr       bool
+	}{
+		{
+			name:     "default zones of the region",
+			replicas: 3,
+		},
+		{
+			name:          "two of the three zones of the region",
+			nodeLocations: []string{"us-central1-a", "us-central1-b"},
+			replicas:      4,
+		},
+		{
+			name:          "replicas not a multiple of the number of zones",
+			nodeLocations: []string{"us-central1-a", "us-central1-b"},
+			replicas:      3,
+			wantErr:       true,
+		},
+		{
+			name:          "zone of another region",
+			nodeLocations: []string{"us-central1-a", "us-east1-b"},
+			replicas:      2,
+			wantErr:       true,
+		},
+		{
+			name:          "region instead of a zone",
+			nodeLocations: []string{"us-central1"},
+			replicas:      1,
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			managedPool, machinePool := newTestMachinePools("pool", tt.replicas, "e2-medium")
+			managedPool.Spec.NodeLocations = tt.nodeLocations
+
+			if err := ManagedMachinePoolPreflightCheck(&managedPool, &machinePool, "us-central1"); (err != nil) != tt.wantErr {
+				t.Errorf("ManagedMachinePoolPreflightCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPodRangePreflightCheck(t *testing.T) {
+	subnets := infrav1.Subnets{
+		{
+			Name:                "cluster-subnet",
+			SecondaryCidrBlocks: map[string]string{"pods-a": "10.100.0.0/16", "pods-b": "10.101.0.0/16"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		nodeNetwork infrav1exp.NodeNetworkConfig
+		subnets     infrav1.Subnets
+		wantErr     bool
+	}{
+		{
+			name: "no pod range",
+		},
+		{
+			name:        "existing pod range declared on the subnet",
+			nodeNetwork: infrav1exp.NodeNetworkConfig{PodRangeName: ptr.To("pods-b")},
+			subnets:     subnets,
+		},
+		{
+			name:        "existing pod range not declared on the subnet",
+			nodeNetwork: infrav1exp.NodeNetworkConfig{PodRangeName: ptr.To("pods-c")},
+			subnets:     subnets,
+			wantErr:     true,
+		},
+		{
+			name:        "pod range created with the node pool",
+			nodeNetwork: infrav1exp.NodeNetworkConfig{PodRangeName: ptr.To("pods-c"), CreatePodRange: ptr.To(true), PodRangeCidrBlock: ptr.To("10.102.0.0/16")},
+			subnets:     subnets,
+		},
+		{
+			name:        "subnet without declared secondary ranges",
+			nodeNetwork: infrav1exp.NodeNetworkConfig{PodRangeName: ptr.To("pods-c")},
+			subnets:     infrav1.Subnets{{Name: "existing-subnet"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			managedPool, _ := newTestMachinePools("pool", 3, "e2-medium")
+			managedPool.Spec.NodeNetwork = tt.nodeNetwork
+
+			if err := PodRangePreflightCheck(&managedPool, tt.subnets); (err != nil) != tt.wantErr {
+				t.Errorf("PodRangePreflightCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import (
+	"google.golang.org/api/compute/v1"
+	"k8s.io/utils/ptr"
+)
+
+// Keys of the instance metadata items set from the settings of a GCPMachine.
+const (
+	EnableOSLoginMetadataKey           = "enable-oslogin"
+	BlockProjectSSHKeysMetadataKey     = "block-project-ssh-keys"
+	SerialPortLoggingEnableMetadataKey = "serial-port-logging-enable"
+)
+
+// ManagedMetadataKeys are the keys of the instance metadata items owned by the controller, which are removed
+// from the instance when their setting is unset.
+var ManagedMetadataKeys = []string{
+	EnableOSLoginMetadataKey,
+	BlockProjectSSHKeysMetadataKey,
+	SerialPortLoggingEnableMetadataKey,
+}
+
+// SetMetadataItem sets an instance metadata item, replacing any existing item with the same key.
+func SetMetadataItem(metadata *compute.Metadata, key, value string) {
+	item := &compute.MetadataItems{
+		Key:   key,
+		Value: ptr.To(value),
+	}
+	for i := range metadata.Items {
+		if metadata.Items[i].Key == key {
+			metadata.Items[i] = item
+			return
+		}
+	}
+	metadata.Items = append(metadata.Items, item)
+}
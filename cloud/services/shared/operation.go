@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	k8scloud "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+)
+
+// OperationWaiter waits on an operation and returns its latest state, which may not be done yet.
+type OperationWaiter func(ctx context.Context, op *compute.Operation) (*compute.Operation, error)
+
+// ZoneOperationWaiter returns an OperationWaiter for the zonal operations of the project.
+func ZoneOperationWaiter(svc *compute.Service, project string) OperationWaiter {
+	return func(ctx context.Context, op *compute.Operation) (*compute.Operation, error) {
+		return svc.ZoneOperations.Wait(project, lastSegment(op.Zone), op.Name).Context(ctx).Do()
+	}
+}
+
+// RegionOperationWaiter returns an OperationWaiter for the regional operations of the project.
+func RegionOperationWaiter(svc *compute.Service, project string) OperationWaiter {
+	return func(ctx context.Context, op *compute.Operation) (*compute.Operation, error) {
+		return svc.RegionOperations.Wait(project, lastSegment(op.Region), op.Name).Context(ctx).Do()
+	}
+}
+
+// GlobalOperationWaiter returns an OperationWaiter for the global operations of the project.
+func GlobalOperationWaiter(svc *compute.Service, project string) OperationWaiter {
+	return func(ctx context.Context, op *compute.Operation) (*compute.Operation, error) {
+		return svc.GlobalOperations.Wait(project, op.Name).Context(ctx).Do()
+	}
+}
+
+// WaitForOperation waits until a Compute operation is done, or the context is done, and returns the error
+// of the operation if it failed. Compute calls made outside of the k8s-cloud-provider wrappers, which
+// already wait on their operations, return an operation that may still be running.
+func WaitForOperation(ctx context.Context, op *compute.Operation, wait OperationWaiter) error {
+	for op.Status != "DONE" {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("waiting for operation %s: %w", op.Name, err)
+		}
+		next, err := wait(ctx, op)
+		if err != nil {
+			return fmt.Errorf("waiting for operation %s: %w", op.Name, err)
+		}
+		op = next
+	}
+
+	if op.Error != nil && len(op.Error.Errors) > 0 {
+		// The error is built like the ones of the operations waited on by k8s-cloud-provider, so that
+		// gcperrors reports it the same way.
+		messages := make([]string, 0, len(op.Error.Errors))
+		items := make([]googleapi.ErrorItem, 0, len(op.Error.Errors))
+		for _, e := range op.Error.Errors {
+			messages = append(messages, fmt.Sprintf("%v - %v", e.Code, e.Message))
+			items = append(items, googleapi.ErrorItem{Reason: e.Code, Message: e.Message})
+		}
+		return &googleapi.Error{
+			Code:    int(op.HttpErrorStatusCode),
+			Message: strings.Join(messages, "; "),
+			Errors:  items,
+		}
+	}
+
+	return nil
+}
+
+// DoOperation performs a Compute call made outside of the k8s-cloud-provider wrappers and waits for its
+// operation to complete. The call goes through the rate limiter like the calls of the wrappers.
+func DoOperation(ctx context.Context, rl k8scloud.RateLimiter, key *k8scloud.RateLimitKey, wait OperationWaiter, call func() (*compute.Operation, error)) error {
+	if err := rl.Accept(ctx, key); err != nil {
+		return err
+	}
+
+	op, err := call()
+	if err == nil {
+		err = WaitForOperation(ctx, op, wait)
+	}
+	rl.Observe(ctx, err, key)
+
+	return err
+}
+
+// lastSegment returns the name of a zone or region from its link, as operations return them as URLs.
+func lastSegment(link string) string {
+	return link[strings.LastIndex(link, "/")+1:]
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shared
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	k8scloud "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+)
+
+// newTestWaiter returns an OperationWaiter returning the given operations in order, and the number of
+// times it was called.
+func newTestWaiter(ops ...*compute.Operation) (OperationWaiter, *int) {
+	calls := 0
+	return func(_ context.Context, _ *compute.Operation) (*compute.Operation, error) {
+		calls++
+		if calls > len(ops) {
+			return nil, errors.New("unexpected wait")
+		}
+		return ops[calls-1], nil
+	}, &calls
+}
+
+func TestWaitForOperation(t *testing.T) {
+	pending := &compute.Operation{Name: "op", Status: "RUNNING"}
+	done := &compute.Operation{Name: "op", Status: "DONE"}
+	failed := &compute.Operation{
+		Name:   "op",
+		Status: "DONE",
+		Error: &compute.OperationError{
+			Errors: []*compute.OperationErrorErrors{{Message: "quota exceeded"}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		op        *compute.Operation
+		waits     []*compute.Operation
+		wantCalls int
+		wantErr   bool
+	}{
+		{
+			name:      "operation already done",
+			op:        done,
+			wantCalls: 0,
+		},
+		{
+			name:      "pending operation is waited on until done",
+			op:        pending,
+			waits:     []*compute.Operation{pending, pending, done},
+			wantCalls: 3,
+		},
+		{
+			name:      "failed operation returns its error",
+			op:        pending,
+			waits:     []*compute.Operation{failed},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "error waiting on the operation",
+			op:        pending,
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, calls := newTestWaiter(tt.waits...)
+			err := WaitForOperation(context.Background(), tt.op, wait)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WaitForOperation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if *calls != tt.wantCalls {
+				t.Errorf("WaitForOperation() waited %d times, want %d", *calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestWaitForOperationContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pending := &compute.Operation{Name: "op", Status: "RUNNING"}
+	wait := func(_ context.Context, op *compute.Operation) (*compute.Operation, error) {
+		cancel()
+		return op, nil
+	}
+
+	if err := WaitForOperation(ctx, pending, wait); !errors.Is(err, context.Canceled) {
+		t.Errorf("WaitForOperation() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestWaitForOperationQuotaExceeded(t *testing.T) {
+	failed := &compute.Operation{
+		Name:                "op",
+		Status:              "DONE",
+		HttpErrorStatusCode: http.StatusForbidden,
+		Error: &compute.OperationError{
+			Errors: []*compute.OperationErrorErrors{{
+				Code:    "QUOTA_EXCEEDED",
+				Message: "Quota 'CPUS' exceeded. Limit: 24.0 in region us-central1.",
+			}},
+		},
+	}
+
+	err := WaitForOperation(context.Background(), failed, nil)
+	if !gcperrors.IsQuotaExceeded(err) {
+		t.Errorf("WaitForOperation() error = %v, want a quota exceeded error", err)
+	}
+	if !gcperrors.IsTerminal(err) {
+		t.Errorf("WaitForOperation() error = %v, want a terminal error", err)
+	}
+}
+
+func TestZoneOperationWaiter(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_ = json.NewEncoder(w).Encode(&compute.Operation{Name: "op", Status: "DONE"})
+	}))
+	defer srv.Close()
+
+	svc, err := compute.NewService(context.Background(), option.WithEndpoint(srv.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	op := &compute.Operation{
+		Name:   "op",
+		Status: "RUNNING",
+		Zone:   "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-a",
+	}
+	if err := WaitForOperation(context.Background(), op, ZoneOperationWaiter(svc, "my-proj")); err != nil {
+		t.Fatalf("WaitForOperation() error = %v", err)
+	}
+	if want := "/projects/my-proj/zones/us-central1-a/operations/op/wait"; gotPath != want {
+		t.Errorf("ZoneOperationWaiter() requested %q, want %q", gotPath, want)
+	}
+}
+
+// fakeRateLimiter records the calls accepted and observed by the rate limiter.
+type fakeRateLimiter struct {
+	acceptErr error
+	calls     []string
+	observed  error
+}
+
+func (f *fakeRateLimiter) Accept(_ context.Context, key *k8scloud.RateLimitKey) error {
+	f.calls = append(f.calls, "accept "+key.Operation)
+	return f.acceptErr
+}
+
+func (f *fakeRateLimiter) Observe(_ context.Context, err error, key *k8scloud.RateLimitKey) {
+	f.calls = append(f.calls, "observe "+key.Operation)
+	f.observed = err
+}
+
+func TestDoOperation(t *testing.T) {
+	pending := &compute.Operation{Name: "op", Status: "RUNNING"}
+	failed := &compute.Operation{
+		Name:   "op",
+		Status: "DONE",
+		Error: &compute.OperationError{
+			Errors: []*compute.OperationErrorErrors{{Message: "quota exceeded"}},
+		},
+	}
+	key := &k8scloud.RateLimitKey{Service: "Instances", Operation: "SetTags"}
+
+	tests := []struct {
+		name         string
+		acceptErr    error
+		callErr      error
+		waits        []*compute.Operation
+		wantCalls    []string
+		wantCalled   bool
+		wantErr      bool
+		wantObserved bool
+	}{
+		{
+			name:       "operation is accepted, waited on and observed",
+			waits:      []*compute.Operation{{Name: "op", Status: "DONE"}},
+			wantCalls:  []string{"accept SetTags", "observe SetTags"},
+			wantCalled: true,
+		},
+		{
+			name:         "failed operation is observed with its error",
+			waits:        []*compute.Operation{failed},
+			wantCalls:    []string{"accept SetTags", "observe SetTags"},
+			wantCalled:   true,
+			wantErr:      true,
+			wantObserved: true,
+		},
+		{
+			name:         "call error is observed",
+			callErr:      errors.New("bad request"),
+			wantCalls:    []string{"accept SetTags", "observe SetTags"},
+			wantCalled:   true,
+			wantErr:      true,
+			wantObserved: true,
+		},
+		{
+			name:      "call is not performed when not accepted",
+			acceptErr: context.Canceled,
+			wantCalls: []string{"accept SetTags"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl := &fakeRateLimiter{acceptErr: tt.acceptErr}
+			wait, _ := newTestWaiter(tt.waits...)
+			called := false
+			err := DoOperation(context.Background(), rl, key, wait, func() (*compute.Operation, error) {
+				called = true
+				return pending, tt.callErr
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoOperation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if called != tt.wantCalled {
+				t.Errorf("DoOperation() performed the call = %v, want %v", called, tt.wantCalled)
+			}
+			if (rl.observed != nil) != tt.wantObserved {
+				t.Errorf("DoOperation() observed error = %v, want an error %v", rl.observed, tt.wantObserved)
+			}
+			if len(rl.calls) != len(tt.wantCalls) {
+				t.Fatalf("DoOperation() rate limiter calls = %v, want %v", rl.calls, tt.wantCalls)
+			}
+			for i := range rl.calls {
+				if rl.calls[i] != tt.wantCalls[i] {
+					t.Errorf("DoOperation() rate limiter calls = %v, want %v", rl.calls, tt.wantCalls)
+					break
+				}
+			}
+		})
+	}
+}
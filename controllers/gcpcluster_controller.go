@@ -18,11 +18,13 @@ package controllers
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
@@ -30,7 +32,10 @@ import (
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/firewalls"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/loadbalancers"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/networks"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/routers"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/subnets"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/dns/managedzones"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
 	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
@@ -52,6 +57,8 @@ type GCPClusterReconciler struct {
 	client.Client
 	ReconcileTimeout time.Duration
 	WatchFilterValue string
+	// DryRun makes the controller report the changes to GCP resources without applying them.
+	DryRun bool
 }
 
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
@@ -138,6 +145,7 @@ func (r *GCPClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		Client:     r.Client,
 		Cluster:    cluster,
 		GCPCluster: gcpCluster,
+		DryRun:     r.DryRun,
 	})
 	if err != nil {
 		return ctrl.Result{}, errors.Errorf("failed to create scope: %+v", err)
@@ -178,35 +186,25 @@ func (r *GCPClusterReconciler) reconcile(ctx context.Context, clusterScope *scop
 		return ctrl.Result{}, err
 	}
 
-	failureDomains := make(clusterv1.FailureDomains, len(zones))
-	for _, zone := range zones {
-		if len(clusterScope.GCPCluster.Spec.FailureDomains) > 0 {
-			for _, fd := range clusterScope.GCPCluster.Spec.FailureDomains {
-				if fd == zone.Name {
-					failureDomains[zone.Name] = clusterv1.FailureDomainSpec{
-						ControlPlane: true,
-					}
-				}
-			}
-		} else {
-			failureDomains[zone.Name] = clusterv1.FailureDomainSpec{
-				ControlPlane: true,
-			}
-		}
-	}
-
-	clusterScope.SetFailureDomains(failureDomains)
+	clusterScope.SetFailureDomains(failureDomains(zones, clusterScope.GCPCluster.Spec.FailureDomains))
 
 	reconcilers := []cloud.Reconciler{
 		networks.New(clusterScope),
 		firewalls.New(clusterScope),
 		// Reconcile subnets before loadbalancers since subnet is needed for internal LB
 		subnets.New(clusterScope),
+		routers.New(clusterScope),
 		loadbalancers.New(clusterScope),
+		// Reconcile the DNS record after the loadbalancers, which set the control-plane endpoint
+		managedzones.New(clusterScope),
 	}
 
 	for _, r := range reconcilers {
 		if err := r.Reconcile(ctx); err != nil {
+			if errors.Is(err, shared.ErrDryRun) {
+				log.Info("Dry-run mode enabled, stopping before the changes which depend on resources that would have been created")
+				return ctrl.Result{}, nil
+			}
 			log.Error(err, "Reconcile error")
 			record.Warnf(clusterScope.GCPCluster, "GCPClusterReconcile", "Reconcile error - %v", err)
 			return ctrl.Result{}, err
@@ -226,12 +224,37 @@ func (r *GCPClusterReconciler) reconcile(ctx context.Context, clusterScope *scop
 	return ctrl.Result{}, nil
 }
 
+// failureDomains returns the failure domains for the zones of the region, restricted to the allowed
+// zones when any are set.
+func failureDomains(zones []*compute.Zone, allowed []string) clusterv1.FailureDomains {
+	fd := make(clusterv1.FailureDomains, len(zones))
+	for _, zone := range zones {
+		if len(allowed) > 0 && !slices.Contains(allowed, zone.Name) {
+			continue
+		}
+		fd[zone.Name] = clusterv1.FailureDomainSpec{
+			ControlPlane: true,
+		}
+	}
+	return fd
+}
+
 func (r *GCPClusterReconciler) reconcileDelete(ctx context.Context, clusterScope *scope.ClusterScope) error {
 	log := log.FromContext(ctx)
 	log.Info("Reconciling Delete GCPCluster")
 
+	if clusterScope.DryRun() {
+		// The resources are not deleted in dry-run mode, so the status referencing them is kept.
+		status := clusterScope.GCPCluster.Status.DeepCopy()
+		defer func() {
+			clusterScope.GCPCluster.Status = *status
+		}()
+	}
+
 	reconcilers := []cloud.Reconciler{
+		managedzones.New(clusterScope),
 		loadbalancers.New(clusterScope),
+		routers.New(clusterScope),
 		subnets.New(clusterScope),
 		firewalls.New(clusterScope),
 		networks.New(clusterScope),
@@ -245,6 +268,11 @@ func (r *GCPClusterReconciler) reconcileDelete(ctx context.Context, clusterScope
 		}
 	}
 
+	if clusterScope.DryRun() {
+		log.Info("Dry-run mode enabled, keeping the finalizer")
+		return nil
+	}
+
 	controllerutil.RemoveFinalizer(clusterScope.GCPCluster, infrav1.ClusterFinalizer)
 	record.Event(clusterScope.GCPCluster, "GCPClusterReconcile", "Reconciled")
 	return nil
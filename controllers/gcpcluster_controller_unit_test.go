@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/api/compute/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestFailureDomains(t *testing.T) {
+	zones := []*compute.Zone{
+		{Name: "us-central1-a"},
+		{Name: "us-central1-b"},
+		{Name: "us-central1-c"},
+	}
+
+	tests := []struct {
+		name    string
+		allowed []string
+		want    clusterv1.FailureDomains
+	}{
+		{
+			name: "all the zones of the region without allowed zones",
+			want: clusterv1.FailureDomains{
+				"us-central1-a": clusterv1.FailureDomainSpec{ControlPlane: true},
+				"us-central1-b": clusterv1.FailureDomainSpec{ControlPlane: true},
+				"us-central1-c": clusterv1.FailureDomainSpec{ControlPlane: true},
+			},
+		},
+		{
+			name:    "only the allowed zones",
+			allowed: []string{"us-central1-a", "us-central1-c"},
+			want: clusterv1.FailureDomains{
+				"us-central1-a": clusterv1.FailureDomainSpec{ControlPlane: true},
+				"us-central1-c": clusterv1.FailureDomainSpec{ControlPlane: true},
+			},
+		},
+		{
+			name:    "allowed zones that are not in the region are ignored",
+			allowed: []string{"us-central1-b", "us-central1-f"},
+			want: clusterv1.FailureDomains{
+				"us-central1-b": clusterv1.FailureDomainSpec{ControlPlane: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(failureDomains(zones, tt.allowed)).To(Equal(tt.want))
+		})
+	}
+}
@@ -24,10 +24,13 @@ import (
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/instances"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
 	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/predicates"
@@ -46,6 +49,8 @@ type GCPMachineReconciler struct {
 	client.Client
 	ReconcileTimeout time.Duration
 	WatchFilterValue string
+	// DryRun makes the controller report the changes to GCP resources without applying them.
+	DryRun bool
 }
 
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
@@ -183,6 +188,7 @@ func (r *GCPMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		Client:     r.Client,
 		Cluster:    cluster,
 		GCPCluster: gcpCluster,
+		DryRun:     r.DryRun,
 	})
 	if err != nil {
 		return ctrl.Result{}, err
@@ -224,9 +230,23 @@ func (r *GCPMachineReconciler) reconcile(ctx context.Context, machineScope *scop
 		return ctrl.Result{}, err
 	}
 
+	if err := machineScope.ValidateZone(); err != nil {
+		log.Error(err, "Invalid zone for GCPMachine")
+		record.Warnf(machineScope.GCPMachine, "GCPMachineReconcile", "Reconcile error - %v", err)
+		return ctrl.Result{}, err
+	}
+
 	if err := instances.New(machineScope).Reconcile(ctx); err != nil {
+		if errors.Is(err, shared.ErrDryRun) {
+			log.Info("Dry-run mode enabled, stopping before the changes which depend on the instance")
+			return ctrl.Result{}, nil
+		}
 		log.Error(err, "Error reconciling instance resources")
 		record.Warnf(machineScope.GCPMachine, "GCPMachineReconcile", "Reconcile error - %v", err)
+		if setTerminalFailure(machineScope, err) {
+			// Requeueing won't resolve a terminal error.
+			return ctrl.Result{}, nil
+		}
 		return ctrl.Result{}, err
 	}
 
@@ -249,6 +269,27 @@ func (r *GCPMachineReconciler) reconcile(ctx context.Context, machineScope *scop
 	}
 }
 
+// setTerminalFailure sets the failure reason and message of the GCPMachine if err is a terminal GCP error creating
+// the instance, and reports whether it did. The errors reconciling an existing instance are always retried.
+func setTerminalFailure(machineScope *scope.MachineScope, err error) bool {
+	var createErr *instances.CreateError
+	if !errors.As(err, &createErr) {
+		return false
+	}
+
+	switch {
+	case gcperrors.IsQuotaExceeded(err):
+		machineScope.SetFailureReason(string(capierrors.InsufficientResourcesMachineError))
+	case gcperrors.IsTerminal(err):
+		machineScope.SetFailureReason(string(capierrors.InvalidConfigurationMachineError))
+	default:
+		return false
+	}
+
+	machineScope.SetFailureMessage(err)
+	return true
+}
+
 func (r *GCPMachineReconciler) reconcileDelete(ctx context.Context, machineScope *scope.MachineScope) error {
 	log := log.FromContext(ctx)
 	log.Info("Reconciling Delete GCPMachine")
@@ -258,6 +299,11 @@ func (r *GCPMachineReconciler) reconcileDelete(ctx context.Context, machineScope
 		return err
 	}
 
+	if machineScope.DryRun() {
+		log.Info("Dry-run mode enabled, keeping the finalizer")
+		return nil
+	}
+
 	controllerutil.RemoveFinalizer(machineScope.GCPMachine, infrav1.MachineFinalizer)
 	record.Event(machineScope.GCPMachine, "GCPMachineReconcile", "Reconciled")
 	return nil
@@ -18,13 +18,19 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"testing"
 
 	. "github.com/onsi/gomega"
+	"google.golang.org/api/googleapi"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/instances"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -103,3 +109,65 @@ func TestGCPMachineReconciler_GCPClusterToGCPMachines(t *testing.T) {
 	})
 	g.Expect(rr).To(HaveLen(2))
 }
+
+func TestSetTerminalFailure(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantTerminal bool
+		wantReason   *string
+	}{
+		{
+			name:         "forbidden error creating the instance is terminal",
+			err:          &instances.CreateError{Err: &googleapi.Error{Code: http.StatusForbidden, Message: "permission denied"}},
+			wantTerminal: true,
+			wantReason:   ptr.To("InvalidConfiguration"),
+		},
+		{
+			name:         "exhausted quota creating the instance is terminal",
+			err:          fmt.Errorf("reconciling instance: %w", &instances.CreateError{Err: &googleapi.Error{Code: http.StatusForbidden, Message: "QUOTA_EXCEEDED - Quota 'CPUS' exceeded."}}),
+			wantTerminal: true,
+			wantReason:   ptr.To("InsufficientResources"),
+		},
+		{
+			name: "rate limited request creating the instance is transient",
+			err: &instances.CreateError{Err: &googleapi.Error{
+				Code:   http.StatusForbidden,
+				Errors: []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}},
+			}},
+			wantTerminal: false,
+		},
+		{
+			name:         "service unavailable error creating the instance is transient",
+			err:          &instances.CreateError{Err: &googleapi.Error{Code: http.StatusServiceUnavailable, Message: "backend unavailable"}},
+			wantTerminal: false,
+		},
+		{
+			name: "rate limited request updating an existing instance is transient",
+			err: &googleapi.Error{
+				Code:   http.StatusForbidden,
+				Errors: []googleapi.ErrorItem{{Reason: "userRateLimitExceeded"}},
+			},
+			wantTerminal: false,
+		},
+		{
+			name:         "forbidden error updating an existing instance is transient",
+			err:          &googleapi.Error{Code: http.StatusForbidden, Message: "permission denied"},
+			wantTerminal: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			machineScope := &scope.MachineScope{GCPMachine: &infrav1.GCPMachine{}}
+			g.Expect(setTerminalFailure(machineScope, tt.err)).To(Equal(tt.wantTerminal))
+			g.Expect(machineScope.GCPMachine.Status.FailureReason).To(Equal(tt.wantReason))
+			if tt.wantTerminal {
+				g.Expect(machineScope.GCPMachine.Status.FailureMessage).To(Equal(ptr.To(tt.err.Error())))
+			} else {
+				g.Expect(machineScope.GCPMachine.Status.FailureMessage).To(BeNil())
+			}
+		})
+	}
+}
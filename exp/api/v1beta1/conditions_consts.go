@@ -27,6 +27,9 @@ const (
 	GKEControlPlaneUpdatingCondition clusterv1.ConditionType = "GKEControlPlaneUpdating"
 	// GKEControlPlaneDeletingCondition condition reports on whether the GKE control plane is deleting.
 	GKEControlPlaneDeletingCondition clusterv1.ConditionType = "GKEControlPlaneDeleting"
+	// GKEControlPlaneNodePoolsPreflightCondition condition reports on whether all node pools passed the preflight checks
+	// before the GKE control plane creation.
+	GKEControlPlaneNodePoolsPreflightCondition clusterv1.ConditionType = "GKEControlPlaneNodePoolsPreflight"
 
 	// GKEControlPlaneCreatingReason used to report GKE control plane being created.
 	GKEControlPlaneCreatingReason = "GKEControlPlaneCreating"
@@ -44,6 +47,9 @@ const (
 	GKEControlPlaneReconciliationFailedReason = "GKEControlPlaneReconciliationFailed"
 	// GKEControlPlaneRequiresAtLeastOneNodePoolReason used to report that no node pool is specified for the GKE control plane.
 	GKEControlPlaneRequiresAtLeastOneNodePoolReason = "GKEControlPlaneRequiresAtLeastOneNodePool"
+	// GKEControlPlaneNodePoolsPreflightFailedReason used to report node pools that failed the preflight checks and were
+	// not created with the GKE control plane.
+	GKEControlPlaneNodePoolsPreflightFailedReason = "GKEControlPlaneNodePoolsPreflightFailed"
 
 	// GKEMachinePoolReadyCondition condition reports on the successful reconciliation of GKE node pool.
 	GKEMachinePoolReadyCondition clusterv1.ConditionType = "GKEMachinePoolReady"
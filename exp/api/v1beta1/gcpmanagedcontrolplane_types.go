@@ -29,6 +29,11 @@ const (
 	// ManagedControlPlaneFinalizer allows Reconcile to clean up GCP resources associated with the GCPManagedControlPlane before
 	// removing it from the apiserver.
 	ManagedControlPlaneFinalizer = "gcpmanagedcontrolplane.infrastructure.cluster.x-k8s.io"
+
+	// AdoptAnnotation is the annotation which, when set to "true", makes the GCPManagedControlPlane adopt an existing
+	// GKE cluster instead of creating one. Only the fields which are explicitly specified are reconciled on an adopted
+	// cluster, the others are left as they are.
+	AdoptAnnotation = "infrastructure.cluster.x-k8s.io/adopt"
 )
 
 // PrivateCluster defines a private Cluster.
@@ -47,9 +52,15 @@ type PrivateCluster struct {
 
 	// ControlPlaneCidrBlock is the IP range in CIDR notation to use for the hosted master network. This range must not
 	// overlap with any other ranges in use within the cluster's network. Honored when enabled is true.
+	// GKE only honors it at cluster creation, later changes are ignored.
 	// +optional
 	ControlPlaneCidrBlock string `json:"controlPlaneCidrBlock,omitempty"`
 
+	// PrivateEndpointSubnetwork is the subnetwork in which the private endpoint of the control plane is provisioned,
+	// in projects/*/regions/*/subnetworks/* format. GKE only accepts it at cluster creation, so the field is immutable.
+	// +optional
+	PrivateEndpointSubnetwork string `json:"privateEndpointSubnetwork,omitempty"`
+
 	// ControlPlaneGlobalAccess is whenever master is accessible globally or not. Honored when enabled is true.
 	// +optional
 	ControlPlaneGlobalAccess bool `json:"controlPlaneGlobalAccess,omitempty"`
@@ -113,6 +124,205 @@ type AuthenticatorGroupConfig struct {
 	SecurityGroups string `json:"securityGroups,omitempty"`
 }
 
+// IdentityServiceConfig configures the Identity Service component, which allows authenticating to the cluster
+// with external OpenID Connect (OIDC) identity providers.
+type IdentityServiceConfig struct {
+	// Enabled indicates whether the Identity Service component is enabled.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// AutopilotWorkloadPolicy configures the policy of the workloads allowed to run on an autopilot GKE cluster.
+type AutopilotWorkloadPolicy struct {
+	// AllowNetAdmin indicates whether the workloads can use the NET_ADMIN capability, which is required by some
+	// service meshes and network tools.
+	// +optional
+	AllowNetAdmin bool `json:"allowNetAdmin,omitempty"`
+}
+
+// DefaultSnatStatus configures the default source NAT rules of the GKE cluster, which masquerade the traffic of the
+// pods to destinations outside of the cluster behind the IP addresses of the nodes.
+type DefaultSnatStatus struct {
+	// Disabled indicates whether the default source NAT rules are disabled, which is required when the pods reach
+	// external destinations using a custom NAT or the non-masquerade egress of the VPC network.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// ConfidentialNodes configures the Confidential GKE Nodes feature, which encrypts the memory of the nodes
+// of the cluster using Confidential VMs.
+type ConfidentialNodes struct {
+	// Enabled indicates whether Confidential Nodes are enabled for all the nodes of the cluster.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// AddonsConfig defines the configuration of the addons of the GKE cluster.
+type AddonsConfig struct {
+	// NetworkPolicyConfig represents the configuration of the NetworkPolicy addon, which enforces Kubernetes
+	// network policies in the cluster. If not specified, the GKE default (disabled) is used.
+	// +optional
+	NetworkPolicyConfig *NetworkPolicyConfig `json:"networkPolicyConfig,omitempty"`
+
+	// HTTPLoadBalancing represents the configuration of the HTTP (L7) load balancing controller addon, which
+	// makes it easy to set up HTTP load balancers for services in the cluster. It can be disabled when using a
+	// custom ingress controller. If not specified, the GKE default (enabled) is used.
+	// +optional
+	HTTPLoadBalancing *HTTPLoadBalancing `json:"httpLoadBalancing,omitempty"`
+
+	// HorizontalPodAutoscaling represents the configuration of the horizontal pod autoscaling addon, which scales
+	// the replicas of workloads based on metrics. If not specified, the GKE default (enabled) is used.
+	// +optional
+	HorizontalPodAutoscaling *HorizontalPodAutoscaling `json:"horizontalPodAutoscaling,omitempty"`
+}
+
+// HorizontalPodAutoscaling configures the horizontal pod autoscaling addon.
+type HorizontalPodAutoscaling struct {
+	// Enabled indicates whether the horizontal pod autoscaling addon is enabled.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// HTTPLoadBalancing configures the HTTP (L7) load balancing controller addon.
+type HTTPLoadBalancing struct {
+	// Enabled indicates whether the HTTP load balancing controller addon is enabled.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// NetworkPolicyProvider is the implementation used to enforce network policies.
+// +kubebuilder:validation:Enum=calico
+type NetworkPolicyProvider string
+
+const (
+	// CalicoNetworkPolicyProvider enforces network policies using Tigera Calico.
+	CalicoNetworkPolicyProvider NetworkPolicyProvider = "calico"
+)
+
+// NetworkPolicyConfig configures the enforcement of network policies in the GKE cluster.
+type NetworkPolicyConfig struct {
+	// Enabled indicates whether network policies are enforced in the cluster.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Provider is the implementation used to enforce network policies.
+	// +kubebuilder:default=calico
+	// +optional
+	Provider NetworkPolicyProvider `json:"provider,omitempty"`
+}
+
+// ClusterAutoscaling configures the cluster-wide autoscaling of the GKE cluster, including node auto-provisioning.
+type ClusterAutoscaling struct {
+	// EnableNodeAutoprovisioning indicates whether node auto-provisioning is enabled, which creates and deletes
+	// node pools based on the resource requests of the pending pods.
+	// +optional
+	EnableNodeAutoprovisioning bool `json:"enableNodeAutoprovisioning,omitempty"`
+
+	// ResourceLimits are the limits on the total amount of resources of the cluster that node auto-provisioning
+	// can scale to. Limits for cpu and memory are required when node auto-provisioning is enabled.
+	// +listType=map
+	// +listMapKey=resourceType
+	// +optional
+	ResourceLimits []ResourceLimit `json:"resourceLimits,omitempty"`
+
+	// AutoprovisioningNodePoolDefaults are the defaults of the node pools created by node auto-provisioning.
+	// Defaults that are not specified are left to GKE.
+	// +optional
+	AutoprovisioningNodePoolDefaults *AutoprovisioningNodePoolDefaults `json:"autoprovisioningNodePoolDefaults,omitempty"`
+}
+
+// ResourceLimit is the limit on the total amount of a resource in the cluster.
+type ResourceLimit struct {
+	// ResourceType is the type of the resource, such as cpu, memory or an accelerator type like nvidia-tesla-t4.
+	// +kubebuilder:validation:MinLength=1
+	ResourceType string `json:"resourceType"`
+
+	// Minimum is the minimum amount of the resource in the cluster. Memory is specified in GB.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Minimum int64 `json:"minimum,omitempty"`
+
+	// Maximum is the maximum amount of the resource in the cluster. Memory is specified in GB.
+	// +kubebuilder:validation:Minimum=0
+	Maximum int64 `json:"maximum"`
+}
+
+// AutoprovisioningNodePoolDefaults defines the defaults of the node pools created by node auto-provisioning.
+type AutoprovisioningNodePoolDefaults struct {
+	// ServiceAccount specifies the identity of the nodes of the auto-provisioned node pools.
+	// +optional
+	ServiceAccount *ServiceAccountConfig `json:"serviceAccount,omitempty"`
+
+	// DiskSizeGb is the size of the disk attached to each node, specified in GB.
+	// +kubebuilder:validation:Minimum:=10
+	// +optional
+	DiskSizeGb *int32 `json:"diskSizeGb,omitempty"`
+
+	// DiskType is the type of the disk attached to each node.
+	// +optional
+	DiskType *DiskType `json:"diskType,omitempty"`
+
+	// ImageType is the image type of the nodes.
+	// +optional
+	ImageType *string `json:"imageType,omitempty"`
+
+	// EnableSecureBoot defines whether the nodes have Secure Boot enabled.
+	// +optional
+	EnableSecureBoot *bool `json:"enableSecureBoot,omitempty"`
+
+	// EnableIntegrityMonitoring defines whether the nodes have integrity monitoring enabled.
+	// +optional
+	EnableIntegrityMonitoring *bool `json:"enableIntegrityMonitoring,omitempty"`
+}
+
+// ResourceUsageExportConfig configures the export of the resource usage of the GKE cluster to a BigQuery dataset.
+type ResourceUsageExportConfig struct {
+	// BigQueryDatasetID is the ID of the BigQuery dataset, in the project of the cluster, that the resource
+	// usage is exported to.
+	// +kubebuilder:validation:MinLength=1
+	BigQueryDatasetID string `json:"bigQueryDatasetID"`
+
+	// EnableNetworkEgressMetering indicates whether the network egress traffic of the cluster is metered.
+	// +optional
+	EnableNetworkEgressMetering bool `json:"enableNetworkEgressMetering,omitempty"`
+
+	// EnableConsumptionMetering indicates whether the actual resource consumption of the pods is metered, in
+	// addition to their resource requests.
+	// +optional
+	EnableConsumptionMetering bool `json:"enableConsumptionMetering,omitempty"`
+}
+
+// LoggingVariant is the logging agent variant of the nodes.
+// +kubebuilder:validation:Enum=default;maxThroughput
+type LoggingVariant string
+
+const (
+	// DefaultLoggingVariant uses the default logging agent configuration.
+	DefaultLoggingVariant LoggingVariant = "default"
+	// MaxThroughputLoggingVariant uses a logging agent configuration with a higher throughput, at the cost of
+	// additional node resources.
+	MaxThroughputLoggingVariant LoggingVariant = "maxThroughput"
+)
+
+// NodePoolDefaults specifies the defaults inherited by the node pools of the GKE cluster.
+type NodePoolDefaults struct {
+	// NodeConfigDefaults specifies the default configuration of the nodes.
+	// +optional
+	NodeConfigDefaults *NodeConfigDefaults `json:"nodeConfigDefaults,omitempty"`
+}
+
+// NodeConfigDefaults specifies the default configuration of the nodes of the GKE cluster.
+type NodeConfigDefaults struct {
+	// LoggingVariant is the default logging agent variant of the nodes.
+	// +optional
+	LoggingVariant *LoggingVariant `json:"loggingVariant,omitempty"`
+
+	// GcfsConfig specifies the default Google Container File System configuration of the nodes, which enables
+	// image streaming.
+	// +optional
+	GcfsConfig *GcfsConfig `json:"gcfsConfig,omitempty"`
+}
+
 // GCPManagedControlPlaneSpec defines the desired state of GCPManagedControlPlane.
 type GCPManagedControlPlaneSpec struct {
 	// ClusterName allows you to specify the name of the GKE cluster.
@@ -137,9 +347,27 @@ type GCPManagedControlPlaneSpec struct {
 	// EnableAutopilot indicates whether to enable autopilot for this GKE cluster.
 	// +optional
 	EnableAutopilot bool `json:"enableAutopilot"`
+	// AutopilotWorkloadPolicy represents the policy of the workloads allowed to run on the autopilot GKE cluster.
+	// Can only be set when autopilot is enabled. If not specified, the existing policy of the cluster is left
+	// unchanged.
+	// +optional
+	AutopilotWorkloadPolicy *AutopilotWorkloadPolicy `json:"autopilotWorkloadPolicy,omitempty"`
 	// EnableIdentityService indicates whether to enable Identity Service component for this GKE cluster.
+	//
+	// Deprecated: use IdentityServiceConfig instead. This field is only honored when IdentityServiceConfig is not set.
 	// +optional
 	EnableIdentityService bool `json:"enableIdentityService"`
+	// IdentityServiceConfig represents the configuration of the Identity Service component of the GKE cluster,
+	// used to authenticate against the cluster with external OIDC identity providers. Takes precedence over
+	// EnableIdentityService. If not specified, the Identity Service component is enabled according to
+	// EnableIdentityService, and is disabled by default.
+	// +optional
+	IdentityServiceConfig *IdentityServiceConfig `json:"identityServiceConfig,omitempty"`
+	// ConfidentialNodes represents the configuration of the Confidential GKE Nodes feature of the GKE cluster.
+	// When enabled, all node pools must use a machine type supported by Confidential VMs (N2D, C2D or C3D).
+	// This field is immutable.
+	// +optional
+	ConfidentialNodes *ConfidentialNodes `json:"confidentialNodes,omitempty"`
 	// ReleaseChannel represents the release channel of the GKE cluster.
 	// +optional
 	ReleaseChannel *ReleaseChannel `json:"releaseChannel,omitempty"`
@@ -165,6 +393,35 @@ type GCPManagedControlPlaneSpec struct {
 	// Value is ignored when enableAutopilot = true.
 	// +optional
 	MonitoringService *MonitoringService `json:"monitoringService,omitempty"`
+	// AllowPartialNodePoolCreation indicates whether the GKE cluster is created with the node pools that passed the
+	// preflight checks when some node pools fail them. The failures are reported in the
+	// GKEControlPlaneNodePoolsPreflight condition instead of failing the cluster creation.
+	// +optional
+	AllowPartialNodePoolCreation bool `json:"allowPartialNodePoolCreation,omitempty"`
+	// AddonsConfig represents the configuration of the addons of the GKE cluster.
+	// Addons that are not specified are left to the GKE defaults.
+	// +optional
+	AddonsConfig *AddonsConfig `json:"addonsConfig,omitempty"`
+	// ClusterAutoscaling represents the cluster-wide autoscaling configuration of the GKE cluster, including the
+	// resource limits and node pool defaults of node auto-provisioning. If not specified, the GKE default
+	// (node auto-provisioning disabled) is used. Can't be set when autopilot is enabled.
+	// +optional
+	ClusterAutoscaling *ClusterAutoscaling `json:"clusterAutoscaling,omitempty"`
+	// ResourceUsageExportConfig represents the configuration of the export of the resource usage of the GKE
+	// cluster to BigQuery, also known as GKE usage metering. If not specified, the existing configuration of the
+	// cluster is left unchanged.
+	// +optional
+	ResourceUsageExportConfig *ResourceUsageExportConfig `json:"resourceUsageExportConfig,omitempty"`
+	// NodePoolDefaults specifies the defaults inherited by the node pools of the GKE cluster, which are applied
+	// when the cluster is created. The logging variant and the image streaming configuration are also updated
+	// on existing clusters. If not specified, the GKE defaults are used.
+	// +optional
+	NodePoolDefaults *NodePoolDefaults `json:"nodePoolDefaults,omitempty"`
+	// DefaultSnatStatus represents the configuration of the default source NAT rules of the VPC-native GKE
+	// cluster. It takes precedence over the DisableDefaultSNAT field of the private cluster. If not specified,
+	// the existing configuration of the cluster is left unchanged.
+	// +optional
+	DefaultSnatStatus *DefaultSnatStatus `json:"defaultSnatStatus,omitempty"`
 }
 
 // GCPManagedControlPlaneStatus defines the observed state of GCPManagedControlPlane.
@@ -185,6 +442,27 @@ type GCPManagedControlPlaneStatus struct {
 	// CurrentVersion shows the current version of the GKE control plane.
 	// +optional
 	CurrentVersion string `json:"currentVersion,omitempty"`
+
+	// DefaultVersion is the default control plane version of the release channel, resolved from the GKE server
+	// config before the cluster is created when ControlPlaneVersion is not specified. It is only informative, as
+	// GKE picks the version when creating the cluster and the default may change in the meantime.
+	// +optional
+	DefaultVersion string `json:"defaultVersion,omitempty"`
+
+	// NextMaintenanceWindow is the next occurrence of the GKE maintenance window, computed
+	// from the daily or recurring maintenance policy of the cluster. A window currently in
+	// progress is reported until it ends.
+	// +optional
+	NextMaintenanceWindow *MaintenanceWindowOccurrence `json:"nextMaintenanceWindow,omitempty"`
+}
+
+// MaintenanceWindowOccurrence is a single occurrence of a maintenance window.
+type MaintenanceWindowOccurrence struct {
+	// StartTime is the time at which the maintenance window starts.
+	StartTime metav1.Time `json:"startTime"`
+
+	// EndTime is the time at which the maintenance window ends.
+	EndTime metav1.Time `json:"endTime"`
 }
 
 // +kubebuilder:object:root=true
@@ -286,6 +564,16 @@ func (m MonitoringService) String() string {
 	return string(m)
 }
 
+// IsAdopted returns true if the GCPManagedControlPlane adopts an existing GKE cluster.
+func (r *GCPManagedControlPlane) IsAdopted() bool {
+	return r.GetAnnotations()[AdoptAnnotation] == "true"
+}
+
+// IsConfidentialNodesEnabled returns true if Confidential GKE Nodes are enabled for the cluster.
+func (r *GCPManagedControlPlane) IsConfidentialNodesEnabled() bool {
+	return r.Spec.ConfidentialNodes != nil && r.Spec.ConfidentialNodes.Enabled
+}
+
 // GetConditions returns the control planes conditions.
 func (r *GCPManagedControlPlane) GetConditions() clusterv1.Conditions {
 	return r.Status.Conditions
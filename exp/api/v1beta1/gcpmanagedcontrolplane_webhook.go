@@ -18,6 +18,8 @@ package v1beta1
 
 import (
 	"fmt"
+	"net"
+	"regexp"
 	"strings"
 
 	"github.com/google/go-cmp/cmp"
@@ -34,10 +36,15 @@ import (
 )
 
 const (
-	maxClusterNameLength = 40
-	resourcePrefix       = "capg-"
+	maxClusterNameLength       = 40
+	maxBigQueryDatasetIDLength = 1024
+	resourcePrefix             = "capg-"
 )
 
+// BigQuery dataset IDs contain up to 1024 letters, numbers and underscores.
+// reference: https://cloud.google.com/bigquery/docs/datasets#dataset-naming
+var bigQueryDatasetIDRegex = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
 // log is for logging in this package.
 var gcpmanagedcontrolplanelog = logf.Log.WithName("gcpmanagedcontrolplane-resource")
 
@@ -98,11 +105,17 @@ func (r *GCPManagedControlPlane) ValidateCreate() (admission.Warnings, error) {
 			r.Spec.LoggingService, "can't be set when autopilot is enabled"))
 	}
 
+	allErrs = append(allErrs, r.validateClusterAutoscaling()...)
+	allErrs = append(allErrs, r.validateAutopilotWorkloadPolicy()...)
+	allErrs = append(allErrs, r.validateResourceUsageExportConfig()...)
+	allErrs = append(allErrs, r.validateClusterNetworkCidrs()...)
+
+	warnings := r.masterAuthorizedNetworksWarnings()
 	if len(allErrs) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(GroupVersion.WithKind("GCPManagedControlPlane").GroupKind(), r.Name, allErrs)
+	return warnings, apierrors.NewInvalid(GroupVersion.WithKind("GCPManagedControlPlane").GroupKind(), r.Name, allErrs)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
@@ -139,6 +152,20 @@ func (r *GCPManagedControlPlane) ValidateUpdate(oldRaw runtime.Object) (admissio
 		)
 	}
 
+	if r.IsConfidentialNodesEnabled() != old.IsConfidentialNodesEnabled() {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "ConfidentialNodes"),
+				r.Spec.ConfidentialNodes, "field is immutable"),
+		)
+	}
+
+	if r.privateEndpointSubnetwork() != old.privateEndpointSubnetwork() {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "ClusterNetwork", "PrivateCluster", "PrivateEndpointSubnetwork"),
+				r.privateEndpointSubnetwork(), "field is immutable"),
+		)
+	}
+
 	if old.Spec.EnableAutopilot && r.Spec.LoggingService != nil {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "LoggingService"),
 			r.Spec.LoggingService, "can't be set when autopilot is enabled"))
@@ -165,11 +192,153 @@ func (r *GCPManagedControlPlane) ValidateUpdate(oldRaw runtime.Object) (admissio
 		}
 	}
 
+	allErrs = append(allErrs, r.validateClusterAutoscaling()...)
+	allErrs = append(allErrs, r.validateAutopilotWorkloadPolicy()...)
+	allErrs = append(allErrs, r.validateResourceUsageExportConfig()...)
+	allErrs = append(allErrs, r.validateClusterNetworkCidrs()...)
+
+	warnings := r.masterAuthorizedNetworksWarnings()
 	if len(allErrs) == 0 {
-		return nil, nil
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(GroupVersion.WithKind("GCPManagedControlPlane").GroupKind(), r.Name, allErrs)
+}
+
+// privateEndpointSubnetwork returns the private endpoint subnetwork of the private cluster, or an empty string if
+// it is not set.
+func (r *GCPManagedControlPlane) privateEndpointSubnetwork() string {
+	if r.Spec.ClusterNetwork == nil || r.Spec.ClusterNetwork.PrivateCluster == nil {
+		return ""
+	}
+	return r.Spec.ClusterNetwork.PrivateCluster.PrivateEndpointSubnetwork
+}
+
+// validateClusterAutoscaling validates that the cluster autoscaling config is valid.
+func (r *GCPManagedControlPlane) validateClusterAutoscaling() field.ErrorList {
+	var allErrs field.ErrorList
+	clusterAutoscaling := r.Spec.ClusterAutoscaling
+	if clusterAutoscaling == nil {
+		return nil
+	}
+	path := field.NewPath("spec", "clusterAutoscaling")
+
+	if r.Spec.EnableAutopilot {
+		allErrs = append(allErrs, field.Forbidden(path, "can't be set when autopilot is enabled"))
+	}
+
+	resourceTypes := map[string]bool{}
+	for i, limit := range clusterAutoscaling.ResourceLimits {
+		resourceTypes[limit.ResourceType] = true
+		if limit.Maximum < limit.Minimum {
+			allErrs = append(allErrs, field.Invalid(path.Child("resourceLimits").Index(i).Child("maximum"),
+				limit.Maximum, "must be greater than or equal to minimum"))
+		}
+	}
+	if clusterAutoscaling.EnableNodeAutoprovisioning {
+		for _, resourceType := range []string{"cpu", "memory"} {
+			if !resourceTypes[resourceType] {
+				allErrs = append(allErrs, field.Required(path.Child("resourceLimits"),
+					fmt.Sprintf("a %s limit is required when node auto-provisioning is enabled", resourceType)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// masterAuthorizedNetworksWarnings warns about the master authorized networks that allow access to the control plane
+// from any IP address, which is allowed but defeats the purpose of the feature.
+func (r *GCPManagedControlPlane) masterAuthorizedNetworksWarnings() admission.Warnings {
+	if r.Spec.MasterAuthorizedNetworksConfig == nil {
+		return nil
+	}
+
+	var warnings admission.Warnings
+	path := field.NewPath("spec", "master_authorized_networks_config", "cidr_blocks")
+	for i, cidrBlock := range r.Spec.MasterAuthorizedNetworksConfig.CidrBlocks {
+		if cidrBlock == nil {
+			continue
+		}
+		if cidrBlock.CidrBlock == "0.0.0.0/0" || cidrBlock.CidrBlock == "::/0" {
+			warnings = append(warnings, fmt.Sprintf("%s: %s allows access to the control plane from any IP address",
+				path.Index(i).Child("cidr_block"), cidrBlock.CidrBlock))
+		}
+	}
+
+	return warnings
+}
+
+// validateAutopilotWorkloadPolicy validates that the autopilot workload policy is only set on autopilot clusters.
+func (r *GCPManagedControlPlane) validateAutopilotWorkloadPolicy() field.ErrorList {
+	if r.Spec.AutopilotWorkloadPolicy == nil || r.Spec.EnableAutopilot {
+		return nil
+	}
+
+	return field.ErrorList{
+		field.Forbidden(field.NewPath("spec", "autopilotWorkloadPolicy"), "can only be set when autopilot is enabled"),
+	}
+}
+
+// validateResourceUsageExportConfig validates that the resource usage export config is valid.
+func (r *GCPManagedControlPlane) validateResourceUsageExportConfig() field.ErrorList {
+	config := r.Spec.ResourceUsageExportConfig
+	if config == nil {
+		return nil
+	}
+
+	if len(config.BigQueryDatasetID) > maxBigQueryDatasetIDLength || !bigQueryDatasetIDRegex.MatchString(config.BigQueryDatasetID) {
+		return field.ErrorList{field.Invalid(field.NewPath("spec", "resourceUsageExportConfig", "bigQueryDatasetID"),
+			config.BigQueryDatasetID, "must contain only letters, numbers and underscores, and be at most 1024 characters")}
+	}
+
+	return nil
+}
+
+// validateClusterNetworkCidrs validates that the pod, service and control plane ranges do not overlap,
+// which GKE only reports once the cluster creation fails.
+func (r *GCPManagedControlPlane) validateClusterNetworkCidrs() field.ErrorList {
+	var allErrs field.ErrorList
+	clusterNetwork := r.Spec.ClusterNetwork
+	if clusterNetwork == nil {
+		return nil
+	}
+	path := field.NewPath("spec", "clusterNetwork")
+
+	type cidrField struct {
+		path  *field.Path
+		value string
+	}
+	var cidrs []cidrField
+	if clusterNetwork.Pod != nil {
+		cidrs = append(cidrs, cidrField{path.Child("pod", "cidrBlock"), clusterNetwork.Pod.CidrBlock})
+	}
+	if clusterNetwork.Service != nil {
+		cidrs = append(cidrs, cidrField{path.Child("service", "cidrBlock"), clusterNetwork.Service.CidrBlock})
+	}
+	if clusterNetwork.PrivateCluster != nil {
+		cidrs = append(cidrs, cidrField{path.Child("privateCluster", "controlPlaneCidrBlock"), clusterNetwork.PrivateCluster.ControlPlaneCidrBlock})
+	}
+
+	for i := range cidrs {
+		// Ranges given as a netmask only, such as /14, are chosen by GKE and cannot overlap.
+		_, a, err := net.ParseCIDR(cidrs[i].value)
+		if err != nil {
+			continue
+		}
+		for j := i + 1; j < len(cidrs); j++ {
+			_, b, err := net.ParseCIDR(cidrs[j].value)
+			if err != nil {
+				continue
+			}
+			if a.Contains(b.IP) || b.Contains(a.IP) {
+				allErrs = append(allErrs, field.Invalid(cidrs[j].path, cidrs[j].value,
+					fmt.Sprintf("overlaps with %s %s", cidrs[i].path, cidrs[i].value)))
+			}
+		}
 	}
 
-	return nil, apierrors.NewInvalid(GroupVersion.WithKind("GCPManagedControlPlane").GroupKind(), r.Name, allErrs)
+	return allErrs
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
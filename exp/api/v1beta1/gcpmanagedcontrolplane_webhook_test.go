@@ -22,6 +22,7 @@ import (
 
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 var (
@@ -118,9 +119,10 @@ func TestGCPManagedControlPlaneDefaultingWebhook(t *testing.T) {
 
 func TestGCPManagedControlPlaneValidatingWebhookCreate(t *testing.T) {
 	tests := []struct {
-		name        string
-		expectError bool
-		spec        GCPManagedControlPlaneSpec
+		name         string
+		expectError  bool
+		spec         GCPManagedControlPlaneSpec
+		wantWarnings admission.Warnings
 	}{
 		{
 			name:        "cluster name too long should cause an error",
@@ -147,6 +149,154 @@ func TestGCPManagedControlPlaneValidatingWebhookCreate(t *testing.T) {
 				ReleaseChannel:  &releaseChannel,
 			},
 		},
+		{
+			name:        "node auto-provisioning with cpu, memory and GPU limits",
+			expectError: false,
+			spec: GCPManagedControlPlaneSpec{
+				ClusterAutoscaling: &ClusterAutoscaling{
+					EnableNodeAutoprovisioning: true,
+					ResourceLimits: []ResourceLimit{
+						{ResourceType: "cpu", Minimum: 1, Maximum: 64},
+						{ResourceType: "memory", Minimum: 1, Maximum: 256},
+						{ResourceType: "nvidia-tesla-t4", Maximum: 4},
+					},
+				},
+			},
+		},
+		{
+			name:        "node auto-provisioning without a memory limit should cause an error",
+			expectError: true,
+			spec: GCPManagedControlPlaneSpec{
+				ClusterAutoscaling: &ClusterAutoscaling{
+					EnableNodeAutoprovisioning: true,
+					ResourceLimits: []ResourceLimit{
+						{ResourceType: "cpu", Minimum: 1, Maximum: 64},
+					},
+				},
+			},
+		},
+		{
+			name:        "resource limit with a maximum lower than the minimum should cause an error",
+			expectError: true,
+			spec: GCPManagedControlPlaneSpec{
+				ClusterAutoscaling: &ClusterAutoscaling{
+					ResourceLimits: []ResourceLimit{
+						{ResourceType: "cpu", Minimum: 8, Maximum: 4},
+					},
+				},
+			},
+		},
+		{
+			name:        "cluster autoscaling with autopilot enabled should cause an error",
+			expectError: true,
+			spec: GCPManagedControlPlaneSpec{
+				EnableAutopilot:    true,
+				ReleaseChannel:     &releaseChannel,
+				ClusterAutoscaling: &ClusterAutoscaling{},
+			},
+		},
+		{
+			name:        "master authorized networks restricted to a private range",
+			expectError: false,
+			spec: GCPManagedControlPlaneSpec{
+				MasterAuthorizedNetworksConfig: &MasterAuthorizedNetworksConfig{
+					CidrBlocks: []*MasterAuthorizedNetworksConfigCidrBlock{{CidrBlock: "10.0.0.0/8"}},
+				},
+			},
+		},
+		{
+			name:        "master authorized networks allowing any IP address should return a warning",
+			expectError: false,
+			spec: GCPManagedControlPlaneSpec{
+				MasterAuthorizedNetworksConfig: &MasterAuthorizedNetworksConfig{
+					CidrBlocks: []*MasterAuthorizedNetworksConfigCidrBlock{
+						{CidrBlock: "10.0.0.0/8"},
+						{DisplayName: "everyone", CidrBlock: "0.0.0.0/0"},
+						{CidrBlock: "::/0"},
+					},
+				},
+			},
+			wantWarnings: admission.Warnings{
+				"spec.master_authorized_networks_config.cidr_blocks[1].cidr_block: 0.0.0.0/0 allows access to the control plane from any IP address",
+				"spec.master_authorized_networks_config.cidr_blocks[2].cidr_block: ::/0 allows access to the control plane from any IP address",
+			},
+		},
+		{
+			name:        "autopilot workload policy on an autopilot cluster",
+			expectError: false,
+			spec: GCPManagedControlPlaneSpec{
+				EnableAutopilot:         true,
+				ReleaseChannel:          &releaseChannel,
+				AutopilotWorkloadPolicy: &AutopilotWorkloadPolicy{AllowNetAdmin: true},
+			},
+		},
+		{
+			name:        "autopilot workload policy on a standard cluster should cause an error",
+			expectError: true,
+			spec: GCPManagedControlPlaneSpec{
+				AutopilotWorkloadPolicy: &AutopilotWorkloadPolicy{AllowNetAdmin: true},
+			},
+		},
+		{
+			name:        "resource usage export to a valid BigQuery dataset",
+			expectError: false,
+			spec: GCPManagedControlPlaneSpec{
+				ResourceUsageExportConfig: &ResourceUsageExportConfig{
+					BigQueryDatasetID:         "gke_usage_metering",
+					EnableConsumptionMetering: true,
+				},
+			},
+		},
+		{
+			name:        "resource usage export to an invalid BigQuery dataset should cause an error",
+			expectError: true,
+			spec: GCPManagedControlPlaneSpec{
+				ResourceUsageExportConfig: &ResourceUsageExportConfig{
+					BigQueryDatasetID: "gke-usage-metering",
+				},
+			},
+		},
+		{
+			name:        "non-overlapping pod, service and control plane ranges",
+			expectError: false,
+			spec: GCPManagedControlPlaneSpec{
+				ClusterNetwork: &ClusterNetwork{
+					Pod:            &ClusterNetworkPod{CidrBlock: "10.0.0.0/14"},
+					Service:        &ClusterNetworkService{CidrBlock: "10.4.0.0/20"},
+					PrivateCluster: &PrivateCluster{ControlPlaneCidrBlock: "172.16.0.0/28"},
+				},
+			},
+		},
+		{
+			name:        "netmask-only pod and service ranges",
+			expectError: false,
+			spec: GCPManagedControlPlaneSpec{
+				ClusterNetwork: &ClusterNetwork{
+					Pod:     &ClusterNetworkPod{CidrBlock: "/14"},
+					Service: &ClusterNetworkService{CidrBlock: "/20"},
+				},
+			},
+		},
+		{
+			name:        "overlapping pod and service ranges should cause an error",
+			expectError: true,
+			spec: GCPManagedControlPlaneSpec{
+				ClusterNetwork: &ClusterNetwork{
+					Pod:     &ClusterNetworkPod{CidrBlock: "10.0.0.0/14"},
+					Service: &ClusterNetworkService{CidrBlock: "10.2.0.0/20"},
+				},
+			},
+		},
+		{
+			name:        "control plane range overlapping the service range should cause an error",
+			expectError: true,
+			spec: GCPManagedControlPlaneSpec{
+				ClusterNetwork: &ClusterNetwork{
+					Service:        &ClusterNetworkService{CidrBlock: "172.16.0.0/20"},
+					PrivateCluster: &PrivateCluster{ControlPlaneCidrBlock: "172.16.0.0/28"},
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -163,17 +313,17 @@ func TestGCPManagedControlPlaneValidatingWebhookCreate(t *testing.T) {
 			} else {
 				g.Expect(err).ToNot(HaveOccurred())
 			}
-			// Nothing emits warnings yet
-			g.Expect(warn).To(BeEmpty())
+			g.Expect(warn).To(Equal(tc.wantWarnings))
 		})
 	}
 }
 
 func TestGCPManagedControlPlaneValidatingWebhookUpdate(t *testing.T) {
 	tests := []struct {
-		name        string
-		expectError bool
-		spec        GCPManagedControlPlaneSpec
+		name         string
+		expectError  bool
+		spec         GCPManagedControlPlaneSpec
+		wantWarnings admission.Warnings
 	}{
 		{
 			name:        "request to change cluster name should cause an error",
@@ -206,6 +356,26 @@ func TestGCPManagedControlPlaneValidatingWebhookUpdate(t *testing.T) {
 				EnableAutopilot: true,
 			},
 		},
+		{
+			name:        "request to enable confidential nodes should cause an error",
+			expectError: true,
+			spec: GCPManagedControlPlaneSpec{
+				ClusterName: "default_cluster1",
+				ConfidentialNodes: &ConfidentialNodes{
+					Enabled: true,
+				},
+			},
+		},
+		{
+			name:        "request to set confidential nodes to disabled should not cause an error",
+			expectError: false,
+			spec: GCPManagedControlPlaneSpec{
+				ClusterName: "default_cluster1",
+				ConfidentialNodes: &ConfidentialNodes{
+					Enabled: false,
+				},
+			},
+		},
 		{
 			name:        "request to change network should not cause an error",
 			expectError: false,
@@ -218,6 +388,45 @@ func TestGCPManagedControlPlaneValidatingWebhookUpdate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:        "request to set the private endpoint subnetwork should cause an error",
+			expectError: true,
+			spec: GCPManagedControlPlaneSpec{
+				ClusterName: "default_cluster1",
+				ClusterNetwork: &ClusterNetwork{
+					PrivateCluster: &PrivateCluster{
+						EnablePrivateEndpoint:     true,
+						PrivateEndpointSubnetwork: "projects/my-project/regions/us-central1/subnetworks/my-subnet",
+					},
+				},
+			},
+		},
+		{
+			name:        "request to allow any IP address in the master authorized networks should return a warning",
+			expectError: false,
+			spec: GCPManagedControlPlaneSpec{
+				ClusterName: "default_cluster1",
+				MasterAuthorizedNetworksConfig: &MasterAuthorizedNetworksConfig{
+					CidrBlocks: []*MasterAuthorizedNetworksConfigCidrBlock{{CidrBlock: "0.0.0.0/0"}},
+				},
+			},
+			wantWarnings: admission.Warnings{
+				"spec.master_authorized_networks_config.cidr_blocks[0].cidr_block: 0.0.0.0/0 allows access to the control plane from any IP address",
+			},
+		},
+		{
+			name:        "request to change the control plane global access should not cause an error",
+			expectError: false,
+			spec: GCPManagedControlPlaneSpec{
+				ClusterName: "default_cluster1",
+				ClusterNetwork: &ClusterNetwork{
+					PrivateCluster: &PrivateCluster{
+						EnablePrivateEndpoint:    true,
+						ControlPlaneGlobalAccess: true,
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -245,8 +454,7 @@ func TestGCPManagedControlPlaneValidatingWebhookUpdate(t *testing.T) {
 			} else {
 				g.Expect(err).ToNot(HaveOccurred())
 			}
-			// Nothing emits warnings yet
-			g.Expect(warn).To(BeEmpty())
+			g.Expect(warn).To(Equal(tc.wantWarnings))
 		})
 	}
 }
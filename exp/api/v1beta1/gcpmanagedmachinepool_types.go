@@ -61,14 +61,22 @@ type GCPManagedMachinePoolSpec struct {
 	// LocalSsdCount is the number of local SSD disks to be attached to the node.
 	// +optional
 	LocalSsdCount *int32 `json:"localSsdCount,omitempty"`
+	// EphemeralStorageLocalSsdConfig specifies the local SSDs backing the ephemeral storage of the nodes, which
+	// is used by the kubelet and the container runtime.
+	// +optional
+	EphemeralStorageLocalSsdConfig *EphemeralStorageLocalSsdConfig `json:"ephemeralStorageLocalSsdConfig,omitempty"`
 	// Scaling specifies scaling for the node pool
 	// +optional
 	Scaling *NodePoolAutoScaling `json:"scaling,omitempty"`
 	// NodeLocations is the list of zones in which the NodePool's
-	// nodes should be located.
+	// nodes should be located. The zones must be in the region of the cluster, which allows placing the
+	// node pool of a regional cluster in a subset of its zones. Zones can be added or removed on an
+	// existing node pool.
 	// +optional
 	NodeLocations []string `json:"nodeLocations,omitempty"`
-	// ImageType is image type to use for this nodepool.
+	// ImageType is image type to use for this nodepool, one of cos_containerd (the GKE default),
+	// ubuntu_containerd or windows_ltsc_containerd. The image type is matched case-insensitively and can be
+	// changed on an existing node pool, which recreates its nodes.
 	// +optional
 	ImageType *string `json:"imageType,omitempty"`
 	// InstanceType is name of Compute Engine machine type.
@@ -83,7 +91,9 @@ type GCPManagedMachinePoolSpec struct {
 	// +optional
 	DiskSizeGB *int64 `json:"diskSizeGB,omitempty"`
 	// MaxPodsPerNode is constraint enforced on the max num of
-	// pods per node.
+	// pods per node. It overrides the cluster-wide default for the
+	// nodes of this node pool and, like it, can only be set when the
+	// node pool is created.
 	// +kubebuilder:validation:Minimum:=8
 	// +kubebuilder:validation:Maximum:=256
 	// +optional
@@ -95,14 +105,16 @@ type GCPManagedMachinePoolSpec struct {
 	// NodeSecurity specifies the node security options.
 	// +optional
 	NodeSecurity NodeSecurityConfig `json:"nodeSecurity,omitempty"`
-	// KubernetesLabels specifies the labels to apply to the nodes of the node pool.
+	// KubernetesLabels specifies the labels to apply to the nodes of the node pool, which can be used in node
+	// selectors. They are not applied to the underlying Compute Engine resources, see AdditionalLabels.
 	// +optional
 	KubernetesLabels infrav1.Labels `json:"kubernetesLabels,omitempty"`
 	// KubernetesTaints specifies the taints to apply to the nodes of the node pool.
 	// +optional
 	KubernetesTaints Taints `json:"kubernetesTaints,omitempty"`
 	// AdditionalLabels is an optional set of tags to add to GCP resources managed by the GCP provider, in addition to the
-	// ones added by default.
+	// ones added by default. They are applied as resource labels to the Compute Engine instances and disks of the
+	// node pool, e.g. for billing, and are updated in place on an existing node pool.
 	// +optional
 	AdditionalLabels infrav1.Labels `json:"additionalLabels,omitempty"`
 	// Management specifies the node pool management options.
@@ -111,6 +123,17 @@ type GCPManagedMachinePoolSpec struct {
 	// LinuxNodeConfig specifies the settings for Linux agent nodes.
 	// +optional
 	LinuxNodeConfig *LinuxNodeConfig `json:"linuxNodeConfig,omitempty"`
+	// GcfsConfig specifies the Google Container File System configuration of the nodes, which enables
+	// image streaming. Image streaming requires the cos_containerd image type.
+	// +optional
+	GcfsConfig *GcfsConfig `json:"gcfsConfig,omitempty"`
+	// NodeVersion is the Kubernetes version of the nodes of the node pool, e.g. 1.30.5. It takes precedence
+	// over the version of the MachinePool, which allows pinning the nodes to a version older than the control
+	// plane for staged upgrades. The version must comply with the GKE version skew policy, the nodes can't be
+	// newer than the control plane, nor more than two minor versions older.
+	// +optional
+	NodeVersion *string `json:"nodeVersion,omitempty"`
+
 	// ProviderIDList are the provider IDs of instances in the
 	// managed instance group corresponding to the nodegroup represented by this
 	// machine pool
@@ -208,12 +231,22 @@ type GCPManagedMachinePoolList struct {
 
 // NodePoolAutoScaling specifies scaling options.
 type NodePoolAutoScaling struct {
-	// MinCount specifies the minimum number of nodes in the node pool
+	// MinCount specifies the minimum number of nodes in the node pool, in total across all of its zones.
+	// Can't be set along with MinCountPerZone or MaxCountPerZone.
 	// +optional
 	MinCount *int32 `json:"minCount,omitempty"`
-	// MaxCount specifies the maximum number of nodes in the node pool
+	// MaxCount specifies the maximum number of nodes in the node pool, in total across all of its zones.
+	// Can't be set along with MinCountPerZone or MaxCountPerZone.
 	// +optional
 	MaxCount *int32 `json:"maxCount,omitempty"`
+	// MinCountPerZone specifies the minimum number of nodes in each zone of the node pool, instead of in total.
+	// Can't be set along with MinCount or MaxCount.
+	// +optional
+	MinCountPerZone *int32 `json:"minCountPerZone,omitempty"`
+	// MaxCountPerZone specifies the maximum number of nodes in each zone of the node pool, instead of in total.
+	// Defaults to MinCountPerZone, or 1 if it is lower. Can't be set along with MinCount or MaxCount.
+	// +optional
+	MaxCountPerZone *int32 `json:"maxCountPerZone,omitempty"`
 	// Is autoscaling enabled for this node pool. If unspecified, the default value is true.
 	// +optional
 	EnableAutoscaling *bool `json:"enableAutoscaling,omitempty"`
@@ -249,6 +282,21 @@ type LinuxNodeConfig struct {
 	CgroupMode *ManagedNodePoolCgroupMode `json:"cgroupMode,omitempty"`
 }
 
+// EphemeralStorageLocalSsdConfig specifies the local SSDs backing the ephemeral storage of the nodes.
+type EphemeralStorageLocalSsdConfig struct {
+	// LocalSsdCount is the number of local SSDs backing the ephemeral storage of each node. Zero uses the
+	// default number of local SSDs of the machine type, which is required by machine types with bundled
+	// local SSDs.
+	// +kubebuilder:validation:Minimum:=0
+	LocalSsdCount int32 `json:"localSsdCount"`
+}
+
+// GcfsConfig specifies the Google Container File System configuration of the nodes.
+type GcfsConfig struct {
+	// Enabled specifies whether image streaming is enabled on the nodes.
+	Enabled bool `json:"enabled"`
+}
+
 // SysctlConfig specifies the sysctl settings for Linux nodes.
 type SysctlConfig struct {
 	// Parameter specifies sysctl parameter name.
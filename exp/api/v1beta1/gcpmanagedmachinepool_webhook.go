@@ -18,11 +18,16 @@ package v1beta1
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/google/go-cmp/cmp"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/utils/ptr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -31,8 +36,18 @@ import (
 
 const (
 	maxNodePoolNameLength = 40
+
+	// gcfsImageType is the image type required by image streaming, which is also the GKE default.
+	gcfsImageType = "cos_containerd"
+
+	// defaultMachineType is the machine type used by GKE when none is specified.
+	defaultMachineType = "e2-medium"
 )
 
+// Image types that can be used by the nodes of a node pool, matched case-insensitively.
+// reference: https://cloud.google.com/kubernetes-engine/docs/concepts/node-images#available_node_images
+var supportedImageTypes = []string{"cos_containerd", "ubuntu_containerd", "windows_ltsc_containerd"}
+
 // log is for logging in this package.
 var gcpmanagedmachinepoollog = logf.Log.WithName("gcpmanagedmachinepool-resource")
 
@@ -75,22 +90,83 @@ func (r *GCPManagedMachinePool) validateSpec() field.ErrorList {
 		allErrs = append(allErrs, errs...)
 	}
 
+	if r.Spec.NodeVersion != nil {
+		if _, err := version.ParseGeneric(*r.Spec.NodeVersion); err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "nodeVersion"),
+					*r.Spec.NodeVersion, "must be a valid Kubernetes version such as 1.30.5"),
+			)
+		}
+	}
+
+	allErrs = append(allErrs, r.validateEphemeralStorageLocalSsdConfig()...)
+
+	if r.Spec.GcfsConfig != nil && r.Spec.GcfsConfig.Enabled && r.Spec.ImageType != nil && !strings.EqualFold(*r.Spec.ImageType, gcfsImageType) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "imageType"),
+				*r.Spec.ImageType, fmt.Sprintf("image streaming requires the %s image type", gcfsImageType)),
+		)
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
 	return allErrs
 }
 
+// validateImageType validates that the image type is supported. It is only run on creation or when the image type
+// changes, so that node pools using an image type which is no longer supported can still be updated.
+func (r *GCPManagedMachinePool) validateImageType() field.ErrorList {
+	if r.Spec.ImageType != nil && !slices.ContainsFunc(supportedImageTypes, func(imageType string) bool {
+		return strings.EqualFold(imageType, *r.Spec.ImageType)
+	}) {
+		return field.ErrorList{
+			field.NotSupported(field.NewPath("spec", "imageType"), *r.Spec.ImageType, supportedImageTypes),
+		}
+	}
+
+	return nil
+}
+
+// validateEphemeralStorageLocalSsdConfig validates that the local SSDs backing the ephemeral storage of the nodes,
+// along with the other local SSDs, are within the limit of the machine type.
+func (r *GCPManagedMachinePool) validateEphemeralStorageLocalSsdConfig() field.ErrorList {
+	if r.Spec.EphemeralStorageLocalSsdConfig == nil || r.Spec.EphemeralStorageLocalSsdConfig.LocalSsdCount <= 0 {
+		return nil
+	}
+
+	machineType := defaultMachineType
+	if r.Spec.InstanceType != nil {
+		machineType = *r.Spec.InstanceType
+	} else if r.Spec.MachineType != nil {
+		machineType = *r.Spec.MachineType
+	}
+
+	countField := field.NewPath("spec", "ephemeralStorageLocalSsdConfig", "localSsdCount")
+	count := int(r.Spec.EphemeralStorageLocalSsdConfig.LocalSsdCount + ptr.Deref(r.Spec.LocalSsdCount, 0))
+	// The numbers of local SSDs are only validated for the machine types they are known for.
+	counts, ok := infrav1.LocalSSDCountsForMachineType(machineType)
+	if !ok || slices.Contains(counts, count) {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(countField, r.Spec.EphemeralStorageLocalSsdConfig.LocalSsdCount,
+		fmt.Sprintf("machine type %s supports %v local SSDs, including localSsdCount", machineType, counts))}
+}
+
 // validateScaling validates that the GCPManagedMachinePool autoscaling spec is valid.
 func (r *GCPManagedMachinePool) validateScaling() field.ErrorList {
 	var allErrs field.ErrorList
 	if r.Spec.Scaling != nil {
 		minField := field.NewPath("spec", "scaling", "minCount")
 		maxField := field.NewPath("spec", "scaling", "maxCount")
+		minPerZoneField := field.NewPath("spec", "scaling", "minCountPerZone")
+		maxPerZoneField := field.NewPath("spec", "scaling", "maxCountPerZone")
 		locationPolicyField := field.NewPath("spec", "scaling", "locationPolicy")
 
 		minCount := r.Spec.Scaling.MinCount
 		maxCount := r.Spec.Scaling.MaxCount
+		minCountPerZone := r.Spec.Scaling.MinCountPerZone
+		maxCountPerZone := r.Spec.Scaling.MaxCountPerZone
 		locationPolicy := r.Spec.Scaling.LocationPolicy
 
 		// cannot specify autoscaling config if autoscaling is disabled
@@ -104,6 +180,32 @@ func (r *GCPManagedMachinePool) validateScaling() field.ErrorList {
 			if locationPolicy != nil {
 				allErrs = append(allErrs, field.Forbidden(locationPolicyField, "locationPolicy cannot be specified when autoscaling is disabled"))
 			}
+			if minCountPerZone != nil {
+				allErrs = append(allErrs, field.Forbidden(minPerZoneField, "minCountPerZone cannot be specified when autoscaling is disabled"))
+			}
+			if maxCountPerZone != nil {
+				allErrs = append(allErrs, field.Forbidden(maxPerZoneField, "maxCountPerZone cannot be specified when autoscaling is disabled"))
+			}
+		}
+
+		// the total and the per zone node counts are mutually exclusive
+		if (minCount != nil || maxCount != nil) && (minCountPerZone != nil || maxCountPerZone != nil) {
+			perZoneField := minPerZoneField
+			if minCountPerZone == nil {
+				perZoneField = maxPerZoneField
+			}
+			allErrs = append(allErrs, field.Forbidden(perZoneField, "per zone node counts cannot be specified along with minCount or maxCount"))
+		}
+
+		if minCountPerZone != nil {
+			// validates min >= 0
+			if *minCountPerZone < 0 {
+				allErrs = append(allErrs, field.Invalid(minPerZoneField, *minCountPerZone, "must be greater or equal zero"))
+			}
+			// validates min <= max
+			if maxCountPerZone != nil && *maxCountPerZone < *minCountPerZone {
+				allErrs = append(allErrs, field.Invalid(maxPerZoneField, *maxCountPerZone, "must be greater than field "+minPerZoneField.String()))
+			}
 		}
 
 		if minCount != nil {
@@ -159,6 +261,7 @@ func (r *GCPManagedMachinePool) validateImmutable(old *GCPManagedMachinePool) fi
 	appendErrorIfMutated(old.Spec.DiskSizeGb, r.Spec.DiskSizeGb, "diskSizeGb", &allErrs)
 	appendErrorIfMutated(old.Spec.DiskType, r.Spec.DiskType, "diskType", &allErrs)
 	appendErrorIfMutated(old.Spec.LocalSsdCount, r.Spec.LocalSsdCount, "localSsdCount", &allErrs)
+	appendErrorIfMutated(old.Spec.EphemeralStorageLocalSsdConfig, r.Spec.EphemeralStorageLocalSsdConfig, "ephemeralStorageLocalSsdConfig", &allErrs)
 	appendErrorIfMutated(old.Spec.Management, r.Spec.Management, "management", &allErrs)
 	appendErrorIfMutated(old.Spec.MaxPodsPerNode, r.Spec.MaxPodsPerNode, "maxPodsPerNode", &allErrs)
 	appendErrorIfMutated(old.Spec.NodeNetwork.PodRangeName, r.Spec.NodeNetwork.PodRangeName, "podRangeName", &allErrs)
@@ -178,6 +281,8 @@ func (r *GCPManagedMachinePool) ValidateCreate() (admission.Warnings, error) {
 		allErrs = append(allErrs, errs...)
 	}
 
+	allErrs = append(allErrs, r.validateImageType()...)
+
 	if len(allErrs) == 0 {
 		return nil, nil
 	}
@@ -199,6 +304,10 @@ func (r *GCPManagedMachinePool) ValidateUpdate(oldRaw runtime.Object) (admission
 		allErrs = append(allErrs, errs...)
 	}
 
+	if !cmp.Equal(old.Spec.ImageType, r.Spec.ImageType) {
+		allErrs = append(allErrs, r.validateImageType()...)
+	}
+
 	if len(allErrs) == 0 {
 		return nil, nil
 	}
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 )
 
@@ -90,6 +91,39 @@ func TestGCPManagedMachinePoolValidatingWebhookCreate(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "scaling with valid per zone min/max count",
+			spec: GCPManagedMachinePoolSpec{
+				NodePoolName: "nodepool1",
+				Scaling: &NodePoolAutoScaling{
+					MinCountPerZone: &minCount,
+					MaxCountPerZone: &maxCount,
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "scaling with per zone max < min count",
+			spec: GCPManagedMachinePoolSpec{
+				NodePoolName: "nodepool1",
+				Scaling: &NodePoolAutoScaling{
+					MinCountPerZone: &maxCount,
+					MaxCountPerZone: &minCount,
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "scaling with both total and per zone counts",
+			spec: GCPManagedMachinePoolSpec{
+				NodePoolName: "nodepool1",
+				Scaling: &NodePoolAutoScaling{
+					MinCount:        &minCount,
+					MaxCountPerZone: &maxCount,
+				},
+			},
+			expectError: true,
+		},
 		{
 			name: "autoscaling disabled and min/max provided",
 			spec: GCPManagedMachinePoolSpec{
@@ -122,6 +156,109 @@ func TestGCPManagedMachinePoolValidatingWebhookCreate(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "valid node version",
+			spec: GCPManagedMachinePoolSpec{
+				NodePoolName: "nodepool1",
+				NodeVersion:  ptr.To("1.30.5"),
+			},
+			expectError: false,
+		},
+		{
+			name: "invalid node version",
+			spec: GCPManagedMachinePoolSpec{
+				NodePoolName: "nodepool1",
+				NodeVersion:  ptr.To("latest"),
+			},
+			expectError: true,
+		},
+		{
+			name: "ephemeral storage on local SSDs within the machine type limit",
+			spec: GCPManagedMachinePoolSpec{
+				NodePoolName:                   "nodepool1",
+				MachineType:                    ptr.To("n2-standard-8"),
+				EphemeralStorageLocalSsdConfig: &EphemeralStorageLocalSsdConfig{LocalSsdCount: 2},
+			},
+			expectError: false,
+		},
+		{
+			name: "ephemeral storage on the default local SSDs of the machine type",
+			spec: GCPManagedMachinePoolSpec{
+				NodePoolName:                   "nodepool1",
+				MachineType:                    ptr.To("c3-standard-8-lssd"),
+				EphemeralStorageLocalSsdConfig: &EphemeralStorageLocalSsdConfig{LocalSsdCount: 0},
+			},
+			expectError: false,
+		},
+		{
+			name: "ephemeral storage on more local SSDs than the machine type supports",
+			spec: GCPManagedMachinePoolSpec{
+				NodePoolName:                   "nodepool1",
+				MachineType:                    ptr.To("c2-standard-8"),
+				LocalSsdCount:                  ptr.To[int32](4),
+				EphemeralStorageLocalSsdConfig: &EphemeralStorageLocalSsdConfig{LocalSsdCount: 5},
+			},
+			expectError: true,
+		},
+		{
+			name: "ephemeral storage on fewer local SSDs than the machine type supports",
+			spec: GCPManagedMachinePoolSpec{
+				NodePoolName:                   "nodepool1",
+				MachineType:                    ptr.To("n2-standard-32"),
+				EphemeralStorageLocalSsdConfig: &EphemeralStorageLocalSsdConfig{LocalSsdCount: 2},
+			},
+			expectError: true,
+		},
+		{
+			name: "ephemeral storage on local SSDs with the default machine type of unknown limits",
+			spec: GCPManagedMachinePoolSpec{
+				NodePoolName:                   "nodepool1",
+				EphemeralStorageLocalSsdConfig: &EphemeralStorageLocalSsdConfig{LocalSsdCount: 1},
+			},
+			expectError: false,
+		},
+		{
+			name: "ubuntu_containerd image type",
+			spec: GCPManagedMachinePoolSpec{
+				NodePoolName: "nodepool1",
+				ImageType:    ptr.To("UBUNTU_CONTAINERD"),
+			},
+			expectError: false,
+		},
+		{
+			name: "unsupported image type",
+			spec: GCPManagedMachinePoolSpec{
+				NodePoolName: "nodepool1",
+				ImageType:    ptr.To("cos"),
+			},
+			expectError: true,
+		},
+		{
+			name: "image streaming with the cos_containerd image type",
+			spec: GCPManagedMachinePoolSpec{
+				NodePoolName: "nodepool1",
+				ImageType:    ptr.To("COS_CONTAINERD"),
+				GcfsConfig:   &GcfsConfig{Enabled: true},
+			},
+			expectError: false,
+		},
+		{
+			name: "image streaming with the default image type",
+			spec: GCPManagedMachinePoolSpec{
+				NodePoolName: "nodepool1",
+				GcfsConfig:   &GcfsConfig{Enabled: true},
+			},
+			expectError: false,
+		},
+		{
+			name: "image streaming with the ubuntu_containerd image type",
+			spec: GCPManagedMachinePoolSpec{
+				NodePoolName: "nodepool1",
+				ImageType:    ptr.To("ubuntu_containerd"),
+				GcfsConfig:   &GcfsConfig{Enabled: true},
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -147,6 +284,7 @@ func TestGCPManagedMachinePoolValidatingWebhookCreate(t *testing.T) {
 func TestGCPManagedMachinePoolValidatingWebhookUpdate(t *testing.T) {
 	tests := []struct {
 		name        string
+		oldSpec     *GCPManagedMachinePoolSpec
 		spec        GCPManagedMachinePoolSpec
 		expectError bool
 	}{
@@ -167,6 +305,30 @@ func TestGCPManagedMachinePoolValidatingWebhookUpdate(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "node version is mutated",
+			spec: GCPManagedMachinePoolSpec{
+				NodePoolName: "nodepool1",
+				NodeVersion:  ptr.To("1.31.1"),
+			},
+			expectError: false,
+		},
+		{
+			name: "immutable field ephemeral storage local SSD config is mutated",
+			spec: GCPManagedMachinePoolSpec{
+				NodePoolName:                   "nodepool1",
+				EphemeralStorageLocalSsdConfig: &EphemeralStorageLocalSsdConfig{LocalSsdCount: 1},
+			},
+			expectError: true,
+		},
+		{
+			name: "immutable field max pods per node is mutated",
+			spec: GCPManagedMachinePoolSpec{
+				NodePoolName:   "nodepool1",
+				MaxPodsPerNode: &maxPods,
+			},
+			expectError: true,
+		},
 		{
 			name: "immutable field disk size is mutated",
 			spec: GCPManagedMachinePoolSpec{
@@ -175,6 +337,27 @@ func TestGCPManagedMachinePoolValidatingWebhookUpdate(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "node pool with an image type no longer supported is mutated",
+			oldSpec: &GCPManagedMachinePoolSpec{
+				NodePoolName: "nodepool1",
+				ImageType:    ptr.To("cos"),
+			},
+			spec: GCPManagedMachinePoolSpec{
+				NodePoolName: "nodepool1",
+				ImageType:    ptr.To("cos"),
+				NodeVersion:  ptr.To("1.31.1"),
+			},
+			expectError: false,
+		},
+		{
+			name: "image type is changed to an unsupported one",
+			spec: GCPManagedMachinePoolSpec{
+				NodePoolName: "nodepool1",
+				ImageType:    ptr.To("cos"),
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -189,6 +372,9 @@ func TestGCPManagedMachinePoolValidatingWebhookUpdate(t *testing.T) {
 					NodePoolName: "nodepool1",
 				},
 			}
+			if tc.oldSpec != nil {
+				oldMMP.Spec = *tc.oldSpec
+			}
 
 			warn, err := newMMP.ValidateUpdate(oldMMP)
 
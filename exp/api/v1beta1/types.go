@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	"cloud.google.com/go/container/apiv1/containerpb"
+	"k8s.io/utils/ptr"
 )
 
 // TaintEffect is the effect for a Kubernetes taint.
@@ -95,6 +96,13 @@ func ConvertToSdkAutoscaling(autoscaling *NodePoolAutoScaling) *containerpb.Node
 		if autoscaling.MaxCount != nil {
 			sdkAutoscaling.TotalMaxNodeCount = *autoscaling.MaxCount
 		}
+		if autoscaling.MinCountPerZone != nil || autoscaling.MaxCountPerZone != nil {
+			// The per zone and the total node counts are mutually exclusive.
+			sdkAutoscaling.TotalMinNodeCount = 0
+			sdkAutoscaling.TotalMaxNodeCount = 0
+			sdkAutoscaling.MinNodeCount = ptr.Deref(autoscaling.MinCountPerZone, 0)
+			sdkAutoscaling.MaxNodeCount = ptr.Deref(autoscaling.MaxCountPerZone, max(sdkAutoscaling.MinNodeCount, 1))
+		}
 		if autoscaling.LocationPolicy != nil {
 			sdkAutoscaling.LocationPolicy = convertToSdkLocationPolicy(*autoscaling.LocationPolicy)
 		}
@@ -144,3 +152,187 @@ func ConvertToSdkLinuxNodeConfig(linuxNodeConfig *LinuxNodeConfig) *containerpb.
 	}
 	return &sdkLinuxNodeConfig
 }
+
+// ConvertToSdkEphemeralStorageLocalSsdConfig converts the ephemeral storage local SSD configuration to a value
+// that is used by GCP SDK.
+func ConvertToSdkEphemeralStorageLocalSsdConfig(config *EphemeralStorageLocalSsdConfig) *containerpb.EphemeralStorageLocalSsdConfig {
+	if config == nil {
+		return nil
+	}
+	return &containerpb.EphemeralStorageLocalSsdConfig{
+		LocalSsdCount: config.LocalSsdCount,
+	}
+}
+
+// ConvertToSdkGcfsConfig converts the Google Container File System configuration to a value that is used by GCP SDK.
+func ConvertToSdkGcfsConfig(gcfsConfig *GcfsConfig) *containerpb.GcfsConfig {
+	if gcfsConfig == nil {
+		return nil
+	}
+	return &containerpb.GcfsConfig{
+		Enabled: gcfsConfig.Enabled,
+	}
+}
+
+// ConvertToSdkAddonsConfig converts the addons config to a value that is used by GCP SDK.
+// Addons that are not configured are left unset so that the GKE defaults apply.
+func ConvertToSdkAddonsConfig(addonsConfig *AddonsConfig) *containerpb.AddonsConfig {
+	if addonsConfig == nil {
+		return nil
+	}
+
+	sdkAddonsConfig := containerpb.AddonsConfig{}
+	if addonsConfig.NetworkPolicyConfig != nil {
+		sdkAddonsConfig.NetworkPolicyConfig = &containerpb.NetworkPolicyConfig{
+			Disabled: !addonsConfig.NetworkPolicyConfig.Enabled,
+		}
+	}
+	if addonsConfig.HTTPLoadBalancing != nil {
+		sdkAddonsConfig.HttpLoadBalancing = &containerpb.HttpLoadBalancing{
+			Disabled: !addonsConfig.HTTPLoadBalancing.Enabled,
+		}
+	}
+	if addonsConfig.HorizontalPodAutoscaling != nil {
+		sdkAddonsConfig.HorizontalPodAutoscaling = &containerpb.HorizontalPodAutoscaling{
+			Disabled: !addonsConfig.HorizontalPodAutoscaling.Enabled,
+		}
+	}
+
+	return &sdkAddonsConfig
+}
+
+// ConvertToSdkNetworkPolicy converts the NetworkPolicy addon config to the cluster network policy that is used by GCP SDK.
+func ConvertToSdkNetworkPolicy(addonsConfig *AddonsConfig) *containerpb.NetworkPolicy {
+	if addonsConfig == nil || addonsConfig.NetworkPolicyConfig == nil {
+		return nil
+	}
+	if !addonsConfig.NetworkPolicyConfig.Enabled {
+		return &containerpb.NetworkPolicy{
+			Enabled: false,
+		}
+	}
+
+	return &containerpb.NetworkPolicy{
+		Enabled:  true,
+		Provider: convertToSdkNetworkPolicyProvider(addonsConfig.NetworkPolicyConfig.Provider),
+	}
+}
+
+func convertToSdkNetworkPolicyProvider(provider NetworkPolicyProvider) containerpb.NetworkPolicy_Provider {
+	switch provider {
+	case CalicoNetworkPolicyProvider, "":
+		return containerpb.NetworkPolicy_CALICO
+	default:
+		return containerpb.NetworkPolicy_PROVIDER_UNSPECIFIED
+	}
+}
+
+// ConvertToSdkClusterAutoscaling converts the cluster autoscaling config to a value that is used by GCP SDK.
+// Node pool defaults that are not configured are left unset so that the GKE defaults apply.
+func ConvertToSdkClusterAutoscaling(clusterAutoscaling *ClusterAutoscaling) *containerpb.ClusterAutoscaling {
+	if clusterAutoscaling == nil {
+		return nil
+	}
+
+	sdkClusterAutoscaling := containerpb.ClusterAutoscaling{
+		EnableNodeAutoprovisioning: clusterAutoscaling.EnableNodeAutoprovisioning,
+	}
+	for _, limit := range clusterAutoscaling.ResourceLimits {
+		sdkClusterAutoscaling.ResourceLimits = append(sdkClusterAutoscaling.ResourceLimits, &containerpb.ResourceLimit{
+			ResourceType: limit.ResourceType,
+			Minimum:      limit.Minimum,
+			Maximum:      limit.Maximum,
+		})
+	}
+	if defaults := clusterAutoscaling.AutoprovisioningNodePoolDefaults; defaults != nil {
+		sdkDefaults := containerpb.AutoprovisioningNodePoolDefaults{}
+		if defaults.ServiceAccount != nil {
+			sdkDefaults.ServiceAccount = ptr.Deref(defaults.ServiceAccount.Email, "")
+			sdkDefaults.OauthScopes = defaults.ServiceAccount.Scopes
+		}
+		if defaults.DiskSizeGb != nil {
+			sdkDefaults.DiskSizeGb = *defaults.DiskSizeGb
+		}
+		if defaults.DiskType != nil {
+			sdkDefaults.DiskType = string(*defaults.DiskType)
+		}
+		if defaults.ImageType != nil {
+			sdkDefaults.ImageType = *defaults.ImageType
+		}
+		if defaults.EnableSecureBoot != nil || defaults.EnableIntegrityMonitoring != nil {
+			sdkDefaults.ShieldedInstanceConfig = &containerpb.ShieldedInstanceConfig{
+				EnableSecureBoot:          ptr.Deref(defaults.EnableSecureBoot, false),
+				EnableIntegrityMonitoring: ptr.Deref(defaults.EnableIntegrityMonitoring, false),
+			}
+		}
+		sdkClusterAutoscaling.AutoprovisioningNodePoolDefaults = &sdkDefaults
+	}
+
+	return &sdkClusterAutoscaling
+}
+
+// ConvertToSdkResourceUsageExportConfig converts the resource usage export config to a value that is used by GCP SDK.
+func ConvertToSdkResourceUsageExportConfig(config *ResourceUsageExportConfig) *containerpb.ResourceUsageExportConfig {
+	if config == nil {
+		return nil
+	}
+
+	return &containerpb.ResourceUsageExportConfig{
+		BigqueryDestination: &containerpb.ResourceUsageExportConfig_BigQueryDestination{
+			DatasetId: config.BigQueryDatasetID,
+		},
+		EnableNetworkEgressMetering: config.EnableNetworkEgressMetering,
+		ConsumptionMeteringConfig: &containerpb.ResourceUsageExportConfig_ConsumptionMeteringConfig{
+			Enabled: config.EnableConsumptionMetering,
+		},
+	}
+}
+
+// ConvertToSdkNodePoolDefaults converts the node pool defaults to a value that is used by GCP SDK.
+func ConvertToSdkNodePoolDefaults(defaults *NodePoolDefaults) *containerpb.NodePoolDefaults {
+	if defaults == nil {
+		return nil
+	}
+
+	sdkDefaults := containerpb.NodePoolDefaults{}
+	if defaults.NodeConfigDefaults != nil {
+		sdkDefaults.NodeConfigDefaults = &containerpb.NodeConfigDefaults{
+			GcfsConfig:    ConvertToSdkGcfsConfig(defaults.NodeConfigDefaults.GcfsConfig),
+			LoggingConfig: ConvertToSdkLoggingVariant(defaults.NodeConfigDefaults.LoggingVariant),
+		}
+	}
+
+	return &sdkDefaults
+}
+
+// ConvertToSdkLoggingVariant converts the logging variant to a node pool logging config that is used by GCP SDK.
+func ConvertToSdkLoggingVariant(variant *LoggingVariant) *containerpb.NodePoolLoggingConfig {
+	if variant == nil {
+		return nil
+	}
+
+	sdkVariant := containerpb.LoggingVariantConfig_VARIANT_UNSPECIFIED
+	switch *variant {
+	case DefaultLoggingVariant:
+		sdkVariant = containerpb.LoggingVariantConfig_DEFAULT
+	case MaxThroughputLoggingVariant:
+		sdkVariant = containerpb.LoggingVariantConfig_MAX_THROUGHPUT
+	}
+
+	return &containerpb.NodePoolLoggingConfig{
+		VariantConfig: &containerpb.LoggingVariantConfig{
+			Variant: sdkVariant,
+		},
+	}
+}
+
+// ConvertToSdkAutopilotWorkloadPolicy converts the autopilot workload policy to a value that is used by GCP SDK.
+func ConvertToSdkAutopilotWorkloadPolicy(policy *AutopilotWorkloadPolicy) *containerpb.WorkloadPolicyConfig {
+	if policy == nil {
+		return nil
+	}
+
+	return &containerpb.WorkloadPolicyConfig{
+		AllowNetAdmin: ptr.To(policy.AllowNetAdmin),
+	}
+}
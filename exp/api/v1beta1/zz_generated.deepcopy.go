@@ -26,6 +26,36 @@ import (
 	cluster_apiapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonsConfig) DeepCopyInto(out *AddonsConfig) {
+	*out = *in
+	if in.NetworkPolicyConfig != nil {
+		in, out := &in.NetworkPolicyConfig, &out.NetworkPolicyConfig
+		*out = new(NetworkPolicyConfig)
+		**out = **in
+	}
+	if in.HTTPLoadBalancing != nil {
+		in, out := &in.HTTPLoadBalancing, &out.HTTPLoadBalancing
+		*out = new(HTTPLoadBalancing)
+		**out = **in
+	}
+	if in.HorizontalPodAutoscaling != nil {
+		in, out := &in.HorizontalPodAutoscaling, &out.HorizontalPodAutoscaling
+		*out = new(HorizontalPodAutoscaling)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonsConfig.
+func (in *AddonsConfig) DeepCopy() *AddonsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthenticatorGroupConfig) DeepCopyInto(out *AuthenticatorGroupConfig) {
 	*out = *in
@@ -41,6 +71,91 @@ func (in *AuthenticatorGroupConfig) DeepCopy() *AuthenticatorGroupConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutopilotWorkloadPolicy) DeepCopyInto(out *AutopilotWorkloadPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutopilotWorkloadPolicy.
+func (in *AutopilotWorkloadPolicy) DeepCopy() *AutopilotWorkloadPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AutopilotWorkloadPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoprovisioningNodePoolDefaults) DeepCopyInto(out *AutoprovisioningNodePoolDefaults) {
+	*out = *in
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(ServiceAccountConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DiskSizeGb != nil {
+		in, out := &in.DiskSizeGb, &out.DiskSizeGb
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DiskType != nil {
+		in, out := &in.DiskType, &out.DiskType
+		*out = new(DiskType)
+		**out = **in
+	}
+	if in.ImageType != nil {
+		in, out := &in.ImageType, &out.ImageType
+		*out = new(string)
+		**out = **in
+	}
+	if in.EnableSecureBoot != nil {
+		in, out := &in.EnableSecureBoot, &out.EnableSecureBoot
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableIntegrityMonitoring != nil {
+		in, out := &in.EnableIntegrityMonitoring, &out.EnableIntegrityMonitoring
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoprovisioningNodePoolDefaults.
+func (in *AutoprovisioningNodePoolDefaults) DeepCopy() *AutoprovisioningNodePoolDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoprovisioningNodePoolDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAutoscaling) DeepCopyInto(out *ClusterAutoscaling) {
+	*out = *in
+	if in.ResourceLimits != nil {
+		in, out := &in.ResourceLimits, &out.ResourceLimits
+		*out = make([]ResourceLimit, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutoprovisioningNodePoolDefaults != nil {
+		in, out := &in.AutoprovisioningNodePoolDefaults, &out.AutoprovisioningNodePoolDefaults
+		*out = new(AutoprovisioningNodePoolDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAutoscaling.
+func (in *ClusterAutoscaling) DeepCopy() *ClusterAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterNetwork) DeepCopyInto(out *ClusterNetwork) {
 	*out = *in
@@ -101,6 +216,51 @@ func (in *ClusterNetworkService) DeepCopy() *ClusterNetworkService {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfidentialNodes) DeepCopyInto(out *ConfidentialNodes) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfidentialNodes.
+func (in *ConfidentialNodes) DeepCopy() *ConfidentialNodes {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfidentialNodes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultSnatStatus) DeepCopyInto(out *DefaultSnatStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultSnatStatus.
+func (in *DefaultSnatStatus) DeepCopy() *DefaultSnatStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultSnatStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EphemeralStorageLocalSsdConfig) DeepCopyInto(out *EphemeralStorageLocalSsdConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EphemeralStorageLocalSsdConfig.
+func (in *EphemeralStorageLocalSsdConfig) DeepCopy() *EphemeralStorageLocalSsdConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EphemeralStorageLocalSsdConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCPManagedCluster) DeepCopyInto(out *GCPManagedCluster) {
 	*out = *in
@@ -297,6 +457,21 @@ func (in *GCPManagedControlPlaneSpec) DeepCopyInto(out *GCPManagedControlPlaneSp
 		*out = new(ClusterNetwork)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AutopilotWorkloadPolicy != nil {
+		in, out := &in.AutopilotWorkloadPolicy, &out.AutopilotWorkloadPolicy
+		*out = new(AutopilotWorkloadPolicy)
+		**out = **in
+	}
+	if in.IdentityServiceConfig != nil {
+		in, out := &in.IdentityServiceConfig, &out.IdentityServiceConfig
+		*out = new(IdentityServiceConfig)
+		**out = **in
+	}
+	if in.ConfidentialNodes != nil {
+		in, out := &in.ConfidentialNodes, &out.ConfidentialNodes
+		*out = new(ConfidentialNodes)
+		**out = **in
+	}
 	if in.ReleaseChannel != nil {
 		in, out := &in.ReleaseChannel, &out.ReleaseChannel
 		*out = new(ReleaseChannel)
@@ -323,6 +498,31 @@ func (in *GCPManagedControlPlaneSpec) DeepCopyInto(out *GCPManagedControlPlaneSp
 		*out = new(MonitoringService)
 		**out = **in
 	}
+	if in.AddonsConfig != nil {
+		in, out := &in.AddonsConfig, &out.AddonsConfig
+		*out = new(AddonsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterAutoscaling != nil {
+		in, out := &in.ClusterAutoscaling, &out.ClusterAutoscaling
+		*out = new(ClusterAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceUsageExportConfig != nil {
+		in, out := &in.ResourceUsageExportConfig, &out.ResourceUsageExportConfig
+		*out = new(ResourceUsageExportConfig)
+		**out = **in
+	}
+	if in.NodePoolDefaults != nil {
+		in, out := &in.NodePoolDefaults, &out.NodePoolDefaults
+		*out = new(NodePoolDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultSnatStatus != nil {
+		in, out := &in.DefaultSnatStatus, &out.DefaultSnatStatus
+		*out = new(DefaultSnatStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlaneSpec.
@@ -345,6 +545,11 @@ func (in *GCPManagedControlPlaneStatus) DeepCopyInto(out *GCPManagedControlPlane
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NextMaintenanceWindow != nil {
+		in, out := &in.NextMaintenanceWindow, &out.NextMaintenanceWindow
+		*out = new(MaintenanceWindowOccurrence)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlaneStatus.
@@ -434,6 +639,11 @@ func (in *GCPManagedMachinePoolSpec) DeepCopyInto(out *GCPManagedMachinePoolSpec
 		*out = new(int32)
 		**out = **in
 	}
+	if in.EphemeralStorageLocalSsdConfig != nil {
+		in, out := &in.EphemeralStorageLocalSsdConfig, &out.EphemeralStorageLocalSsdConfig
+		*out = new(EphemeralStorageLocalSsdConfig)
+		**out = **in
+	}
 	if in.Scaling != nil {
 		in, out := &in.Scaling, &out.Scaling
 		*out = new(NodePoolAutoScaling)
@@ -500,6 +710,16 @@ func (in *GCPManagedMachinePoolSpec) DeepCopyInto(out *GCPManagedMachinePoolSpec
 		*out = new(LinuxNodeConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GcfsConfig != nil {
+		in, out := &in.GcfsConfig, &out.GcfsConfig
+		*out = new(GcfsConfig)
+		**out = **in
+	}
+	if in.NodeVersion != nil {
+		in, out := &in.NodeVersion, &out.NodeVersion
+		*out = new(string)
+		**out = **in
+	}
 	if in.ProviderIDList != nil {
 		in, out := &in.ProviderIDList, &out.ProviderIDList
 		*out = make([]string, len(*in))
@@ -539,6 +759,66 @@ func (in *GCPManagedMachinePoolStatus) DeepCopy() *GCPManagedMachinePoolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GcfsConfig) DeepCopyInto(out *GcfsConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GcfsConfig.
+func (in *GcfsConfig) DeepCopy() *GcfsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GcfsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPLoadBalancing) DeepCopyInto(out *HTTPLoadBalancing) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPLoadBalancing.
+func (in *HTTPLoadBalancing) DeepCopy() *HTTPLoadBalancing {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPLoadBalancing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizontalPodAutoscaling) DeepCopyInto(out *HorizontalPodAutoscaling) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizontalPodAutoscaling.
+func (in *HorizontalPodAutoscaling) DeepCopy() *HorizontalPodAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizontalPodAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdentityServiceConfig) DeepCopyInto(out *IdentityServiceConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityServiceConfig.
+func (in *IdentityServiceConfig) DeepCopy() *IdentityServiceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IdentityServiceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinuxNodeConfig) DeepCopyInto(out *LinuxNodeConfig) {
 	*out = *in
@@ -564,6 +844,23 @@ func (in *LinuxNodeConfig) DeepCopy() *LinuxNodeConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowOccurrence) DeepCopyInto(out *MaintenanceWindowOccurrence) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.EndTime.DeepCopyInto(&out.EndTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowOccurrence.
+func (in *MaintenanceWindowOccurrence) DeepCopy() *MaintenanceWindowOccurrence {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowOccurrence)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MasterAuthorizedNetworksConfig) DeepCopyInto(out *MasterAuthorizedNetworksConfig) {
 	*out = *in
@@ -610,6 +907,46 @@ func (in *MasterAuthorizedNetworksConfigCidrBlock) DeepCopy() *MasterAuthorizedN
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyConfig) DeepCopyInto(out *NetworkPolicyConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyConfig.
+func (in *NetworkPolicyConfig) DeepCopy() *NetworkPolicyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeConfigDefaults) DeepCopyInto(out *NodeConfigDefaults) {
+	*out = *in
+	if in.LoggingVariant != nil {
+		in, out := &in.LoggingVariant, &out.LoggingVariant
+		*out = new(LoggingVariant)
+		**out = **in
+	}
+	if in.GcfsConfig != nil {
+		in, out := &in.GcfsConfig, &out.GcfsConfig
+		*out = new(GcfsConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeConfigDefaults.
+func (in *NodeConfigDefaults) DeepCopy() *NodeConfigDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeConfigDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeNetworkConfig) DeepCopyInto(out *NodeNetworkConfig) {
 	*out = *in
@@ -658,6 +995,16 @@ func (in *NodePoolAutoScaling) DeepCopyInto(out *NodePoolAutoScaling) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.MinCountPerZone != nil {
+		in, out := &in.MinCountPerZone, &out.MinCountPerZone
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxCountPerZone != nil {
+		in, out := &in.MaxCountPerZone, &out.MaxCountPerZone
+		*out = new(int32)
+		**out = **in
+	}
 	if in.EnableAutoscaling != nil {
 		in, out := &in.EnableAutoscaling, &out.EnableAutoscaling
 		*out = new(bool)
@@ -680,6 +1027,26 @@ func (in *NodePoolAutoScaling) DeepCopy() *NodePoolAutoScaling {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolDefaults) DeepCopyInto(out *NodePoolDefaults) {
+	*out = *in
+	if in.NodeConfigDefaults != nil {
+		in, out := &in.NodeConfigDefaults, &out.NodeConfigDefaults
+		*out = new(NodeConfigDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolDefaults.
+func (in *NodePoolDefaults) DeepCopy() *NodePoolDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodePoolManagement) DeepCopyInto(out *NodePoolManagement) {
 	*out = *in
@@ -741,6 +1108,36 @@ func (in *PrivateCluster) DeepCopy() *PrivateCluster {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceLimit) DeepCopyInto(out *ResourceLimit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceLimit.
+func (in *ResourceLimit) DeepCopy() *ResourceLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceUsageExportConfig) DeepCopyInto(out *ResourceUsageExportConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsageExportConfig.
+func (in *ResourceUsageExportConfig) DeepCopy() *ResourceUsageExportConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceUsageExportConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceAccountConfig) DeepCopyInto(out *ServiceAccountConfig) {
 	*out = *in
@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/networks"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/subnets"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -52,6 +53,8 @@ type GCPManagedClusterReconciler struct {
 	client.Client
 	WatchFilterValue string
 	ReconcileTimeout time.Duration
+	// DryRun makes the controller report the changes to GCP resources without applying them.
+	DryRun bool
 }
 
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedclusters,verbs=get;list;watch;create;update;patch;delete
@@ -117,6 +120,7 @@ func (r *GCPManagedClusterReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		Cluster:                cluster,
 		GCPManagedCluster:      gcpCluster,
 		GCPManagedControlPlane: controlPlane,
+		DryRun:                 r.DryRun,
 	})
 	if err != nil {
 		return ctrl.Result{}, errors.Errorf("failed to create scope: %+v", err)
@@ -202,6 +206,10 @@ func (r *GCPManagedClusterReconciler) reconcile(ctx context.Context, clusterScop
 	for name, r := range reconcilers {
 		log.V(4).Info("Calling reconciler", "reconciler", name)
 		if err := r.Reconcile(ctx); err != nil {
+			if errors.Is(err, shared.ErrDryRun) {
+				log.Info("Dry-run mode enabled, stopping before the changes which depend on resources that would have been created", "reconciler", name)
+				return nil
+			}
 			log.Error(err, "Reconcile error", "reconciler", name)
 			record.Warnf(clusterScope.GCPManagedCluster, "GCPManagedClusterReconcile", "Reconcile error - %v", err)
 			return err
@@ -233,6 +241,14 @@ func (r *GCPManagedClusterReconciler) reconcileDelete(ctx context.Context, clust
 		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
 	}
 
+	if clusterScope.DryRun() {
+		// The resources are not deleted in dry-run mode, so the status referencing them is kept.
+		status := clusterScope.GCPManagedCluster.Status.DeepCopy()
+		defer func() {
+			clusterScope.GCPManagedCluster.Status = *status
+		}()
+	}
+
 	reconcilers := map[string]cloud.Reconciler{
 		"subnets":  subnets.New(clusterScope),
 		"networks": networks.New(clusterScope),
@@ -247,6 +263,11 @@ func (r *GCPManagedClusterReconciler) reconcileDelete(ctx context.Context, clust
 		}
 	}
 
+	if clusterScope.DryRun() {
+		log.Info("Dry-run mode enabled, keeping the finalizer")
+		return ctrl.Result{}, nil
+	}
+
 	controllerutil.RemoveFinalizer(clusterScope.GCPManagedCluster, infrav1exp.ClusterFinalizer)
 	record.Event(clusterScope.GCPManagedCluster, "GCPClusterReconcile", "Reconciled")
 
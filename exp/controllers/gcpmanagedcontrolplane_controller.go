@@ -50,6 +50,8 @@ type GCPManagedControlPlaneReconciler struct {
 	client.Client
 	ReconcileTimeout time.Duration
 	WatchFilterValue string
+	// DryRun makes the controller report the changes to GCP resources without applying them.
+	DryRun bool
 }
 
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedcontrolplanes,verbs=get;list;watch;create;update;patch;delete
@@ -131,6 +133,7 @@ func (r *GCPManagedControlPlaneReconciler) Reconcile(ctx context.Context, req ct
 		Cluster:                cluster,
 		GCPManagedCluster:      managedCluster,
 		GCPManagedControlPlane: gcpManagedControlPlane,
+		DryRun:                 r.DryRun,
 	})
 	if err != nil {
 		return ctrl.Result{}, errors.Errorf("failed to create scope: %+v", err)
@@ -58,6 +58,8 @@ type GCPManagedMachinePoolReconciler struct {
 	client.Client
 	ReconcileTimeout time.Duration
 	WatchFilterValue string
+	// DryRun makes the controller report the changes to GCP resources without applying them.
+	DryRun bool
 }
 
 // GetOwnerClusterKey returns only the Cluster name and namespace.
@@ -294,6 +296,7 @@ func (r *GCPManagedMachinePoolReconciler) Reconcile(ctx context.Context, req ctr
 		GCPManagedCluster:      gcpManagedCluster,
 		GCPManagedControlPlane: gcpManagedControlPlane,
 		GCPManagedMachinePool:  gcpManagedMachinePool,
+		DryRun:                 r.DryRun,
 	})
 	if err != nil {
 		return ctrl.Result{}, errors.Errorf("failed to create scope: %+v", err)
@@ -68,6 +68,7 @@ func init() {
 
 var (
 	enableLeaderElection        bool
+	dryRun                      bool
 	leaderElectionNamespace     string
 	watchNamespace              string
 	profilerAddress             string
@@ -190,6 +191,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) error {
 		Client:           mgr.GetClient(),
 		ReconcileTimeout: reconcileTimeout,
 		WatchFilterValue: watchFilterValue,
+		DryRun:           dryRun,
 	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: gcpMachineConcurrency}); err != nil {
 		return fmt.Errorf("setting up GCPMachine controller: %w", err)
 	}
@@ -197,6 +199,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) error {
 		Client:           mgr.GetClient(),
 		ReconcileTimeout: reconcileTimeout,
 		WatchFilterValue: watchFilterValue,
+		DryRun:           dryRun,
 	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: gcpClusterConcurrency}); err != nil {
 		return fmt.Errorf("setting up GCPCluster controller: %w", err)
 	}
@@ -208,6 +211,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) error {
 			Client:           mgr.GetClient(),
 			ReconcileTimeout: reconcileTimeout,
 			WatchFilterValue: watchFilterValue,
+			DryRun:           dryRun,
 		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: gcpClusterConcurrency}); err != nil {
 			return fmt.Errorf("setting up GCPManagedCluster controller: %w", err)
 		}
@@ -216,6 +220,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) error {
 			Client:           mgr.GetClient(),
 			ReconcileTimeout: reconcileTimeout,
 			WatchFilterValue: watchFilterValue,
+			DryRun:           dryRun,
 		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: gcpClusterConcurrency}); err != nil {
 			return fmt.Errorf("setting up GCPManagedControlPlane controller: %w", err)
 		}
@@ -224,6 +229,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) error {
 			Client:           mgr.GetClient(),
 			ReconcileTimeout: reconcileTimeout,
 			WatchFilterValue: watchFilterValue,
+			DryRun:           dryRun,
 		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: gcpMachineConcurrency}); err != nil {
 			return fmt.Errorf("setting up GCPManagedMachinePool controller: %w", err)
 		}
@@ -368,6 +374,12 @@ func initFlags(fs *pflag.FlagSet) {
 		"The address the health endpoint binds to.",
 	)
 
+	fs.BoolVar(&dryRun,
+		"dry-run",
+		false,
+		fmt.Sprintf("Report the changes the controllers would make to GCP resources as events, without applying them. Single objects can be reconciled in dry-run mode with the %s annotation.", infrav1beta1.DryRunAnnotation),
+	)
+
 	fs.DurationVar(&reconcileTimeout,
 		"reconcile-timeout",
 		reconciler.DefaultLoopTimeout,